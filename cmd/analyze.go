@@ -35,13 +35,13 @@ was visited in which order and how types were determined.`,
 		if analyzeProcess {
 			parser.Tracer.WriteProcess(os.Stdout)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "\nAnalysis error: %v\n", err)
+				reportError(err, func() { fmt.Fprintf(os.Stderr, "\nAnalysis error: %v\n", err) })
 			}
 			return
 		}
 
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			reportError(err, func() { fmt.Fprintf(os.Stderr, "Error: %v\n", err) })
 			return
 		}
 
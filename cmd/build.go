@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/redneckbeard/thanos/compiler"
+	"github.com/redneckbeard/thanos/parser"
+	"github.com/spf13/cobra"
+)
+
+const thanosModule = "github.com/redneckbeard/thanos"
+
+var (
+	BuildOutput, BuildModule, BuildVersion, BuildBinary string
+	BuildVendor                                         bool
+)
+
+var buildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Compile Ruby to a standalone Go module",
+	Long: `'thanos build' compiles the provided Ruby into a Go module that builds on its
+	own: a go.mod with the module path of your choosing, a go.sum, and the
+	generated sources. The thanos runtime packages the program uses are either
+	required at a published version or, with --vendor, copied into
+	internal/thanos so the module has no dependency on thanos at all. Pass
+	--binary to also build an executable.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if BuildOutput == "" {
+			color.Red("an output directory is required (--output)")
+			exit(1)
+		}
+		out, err := filepath.Abs(BuildOutput)
+		if err != nil {
+			reportError(err, func() { color.Red(err.Error()) })
+			exit(1)
+		}
+		modPath := BuildModule
+		if modPath == "" {
+			modPath = filepath.Base(out)
+		}
+		if Source == "" {
+			color.Green("Input your Ruby and build with Ctrl-D.")
+		}
+		program, err := parser.ParseFile(Source)
+		if err != nil {
+			reportError(err, func() { color.Red(err.Error()) })
+			exit(1)
+		}
+		program.ModulePath = modPath
+		result, err := compiler.Compile(program)
+		if err != nil {
+			reportError(err, func() { color.Red(err.Error()) })
+			exit(1)
+		}
+		if err := writeModule(result, out, modPath); err != nil {
+			reportError(err, func() { color.Red(err.Error()) })
+			exit(1)
+		}
+		if BuildBinary != "" {
+			bin, _ := filepath.Abs(BuildBinary)
+			if err := goCommand(out, "build", "-o", bin, "."); err != nil {
+				reportError(err, func() { color.Red(err.Error()) })
+				exit(1)
+			}
+		}
+	},
+}
+
+// writeModule writes the compiled program to dir as a self-contained module
+// named modPath, vendoring or requiring the thanos runtime packages it imports,
+// and resolves its dependencies to produce go.sum.
+func writeModule(result *compiler.CompileResult, dir, modPath string) error {
+	files, err := moduleFiles(result, dir, modPath, BuildVersion, BuildVendor)
+	if err != nil {
+		return err
+	}
+	for path, src := range files {
+		fullPath := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fullPath, []byte(src), 0644); err != nil {
+			return err
+		}
+	}
+	return goCommand(dir, "mod", "tidy")
+}
+
+// moduleFiles returns the files of the module writeModule writes, keyed by
+// their paths in it, go.mod included. With vendor, the thanos runtime
+// packages the program imports are copied in; otherwise go.mod requires the
+// modules they belong to at version, or at the version of this binary if
+// version is empty.
+func moduleFiles(result *compiler.CompileResult, dir, modPath, version string, vendor bool) (map[string]string, error) {
+	imports, err := thanosImports(result.Files)
+	if err != nil {
+		return nil, err
+	}
+	files := outputFiles(result, dir)
+	var requires []string
+	if vendor {
+		vendored, err := vendorPackages(imports, modPath)
+		if err != nil {
+			return nil, err
+		}
+		for path, src := range vendored {
+			files[path] = src
+		}
+		for path, src := range files {
+			files[path] = rewriteThanosImports(src, modPath)
+		}
+	} else {
+		for _, mod := range runtimeModules(imports) {
+			v := version
+			if v == "" {
+				v = runtimeVersion(mod)
+			}
+			if v == "" {
+				return nil, fmt.Errorf("this thanos was built from a source checkout, so it can't tell which version of %s to require; pass --thanos-version or --vendor", mod)
+			}
+			requires = append(requires, fmt.Sprintf("\t%s %s\n", mod, v))
+		}
+	}
+
+	goMod := fmt.Sprintf("module %s\n\ngo 1.23\n", modPath)
+	if len(requires) > 0 {
+		goMod += "\nrequire (\n" + strings.Join(requires, "") + ")\n"
+	}
+	files["go.mod"] = goMod
+	return files, nil
+}
+
+// goCommand runs the go tool in dir, outside of any workspace the output
+// directory might happen to sit in, so that the module resolves its
+// dependencies the way it will for whoever builds it next.
+func goCommand(dir string, args ...string) error {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("go %s failed: %s", strings.Join(args, " "), stderr.String())
+	}
+	return nil
+}
+
+// thanosImports returns the thanos runtime packages imported by the given Go
+// sources, sorted.
+func thanosImports(files map[string]string) ([]string, error) {
+	seen := map[string]bool{}
+	fset := token.NewFileSet()
+	for path, src := range files {
+		f, err := goparser.ParseFile(fset, path, src, goparser.ImportsOnly)
+		if err != nil {
+			return nil, err
+		}
+		for _, imp := range f.Imports {
+			importPath, _ := strconv.Unquote(imp.Path.Value)
+			if strings.HasPrefix(importPath, thanosModule+"/") {
+				seen[importPath] = true
+			}
+		}
+	}
+	var imports []string
+	for path := range seen {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	return imports, nil
+}
+
+// runtimeModules maps thanos packages to the modules that contain them.
+// stdlib and shims are modules of their own; everything else lives in the
+// root module.
+func runtimeModules(imports []string) []string {
+	seen := map[string]bool{}
+	var mods []string
+	for _, imp := range imports {
+		mod := thanosModule
+		for _, sub := range []string{"stdlib", "shims"} {
+			if imp == thanosModule+"/"+sub {
+				mod = imp
+			}
+		}
+		if !seen[mod] {
+			seen[mod] = true
+			mods = append(mods, mod)
+		}
+	}
+	return mods
+}
+
+// runtimeVersion returns the version of a thanos module that this binary was
+// built against, or "" if it was built from a checkout with the module
+// replaced by a local directory.
+func runtimeVersion(mod string) string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if mod == info.Main.Path {
+		if info.Main.Version == "(devel)" {
+			return ""
+		}
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == mod && dep.Replace == nil {
+			return dep.Version
+		}
+	}
+	return ""
+}
+
+// vendorPackages copies the given thanos packages, and the thanos packages
+// they import in turn, out of the thanos checkout. The result maps paths
+// under internal/thanos to file contents.
+func vendorPackages(imports []string, modPath string) (map[string]string, error) {
+	root := findThanosRoot()
+	files := map[string]string{}
+	queue := append([]string{}, imports...)
+	seen := map[string]bool{}
+	fset := token.NewFileSet()
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		if seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+		rel := strings.TrimPrefix(pkg, thanosModule+"/")
+		srcs, _ := filepath.Glob(filepath.Join(root, filepath.FromSlash(rel), "*.go"))
+		if len(srcs) == 0 {
+			return nil, fmt.Errorf("could not find the source of %s in %s to vendor it", pkg, root)
+		}
+		for _, src := range srcs {
+			if strings.HasSuffix(src, "_test.go") {
+				continue
+			}
+			b, err := os.ReadFile(src)
+			if err != nil {
+				return nil, err
+			}
+			f, err := goparser.ParseFile(fset, src, b, goparser.ImportsOnly)
+			if err != nil {
+				return nil, err
+			}
+			for _, imp := range f.Imports {
+				importPath, _ := strconv.Unquote(imp.Path.Value)
+				if strings.HasPrefix(importPath, thanosModule+"/") {
+					queue = append(queue, importPath)
+				}
+			}
+			files[filepath.Join("internal", "thanos", filepath.FromSlash(rel), filepath.Base(src))] = string(b)
+		}
+	}
+	return files, nil
+}
+
+// rewriteThanosImports points imports of thanos packages at their vendored
+// copies under internal/thanos.
+func rewriteThanosImports(src, modPath string) string {
+	return strings.ReplaceAll(src, `"`+thanosModule+"/", `"`+modPath+"/internal/thanos/")
+}
+
+func init() {
+	rootCmd.AddCommand(buildCmd)
+	buildCmd.Flags().StringVarP(&Source, "source", "s", "", "Ruby file to build (defaults to stdin)")
+	buildCmd.Flags().StringVarP(&BuildOutput, "output", "o", "", "Directory to write the Go module to")
+	buildCmd.Flags().StringVarP(&BuildModule, "module", "m", "", "Module path for the generated go.mod (defaults to the output directory's name)")
+	buildCmd.Flags().StringVar(&BuildVersion, "thanos-version", "", "Version of the thanos runtime modules to require (defaults to the version of this binary)")
+	buildCmd.Flags().BoolVar(&BuildVendor, "vendor", false, "Copy the thanos runtime packages into internal/thanos instead of requiring them")
+	buildCmd.Flags().StringVarP(&BuildBinary, "binary", "b", "", "Also build an executable at this path")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/redneckbeard/thanos/compiler"
+	"github.com/redneckbeard/thanos/parser"
+)
+
+func TestRuntimeModules(t *testing.T) {
+	tests := []struct {
+		imports, expected []string
+	}{
+		{nil, nil},
+		{[]string{thanosModule + "/stdlib"}, []string{thanosModule + "/stdlib"}},
+		{[]string{thanosModule + "/bst", thanosModule + "/csv", thanosModule + "/stdlib"}, []string{thanosModule, thanosModule + "/stdlib"}},
+		{[]string{thanosModule + "/shims", thanosModule + "/stdlib"}, []string{thanosModule + "/shims", thanosModule + "/stdlib"}},
+	}
+	for i, tt := range tests {
+		if got := runtimeModules(tt.imports); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("[%d] expected %v, got %v", i, tt.expected, got)
+		}
+	}
+}
+
+func TestModuleFiles(t *testing.T) {
+	program, err := parser.ParseString("exit 3 if ARGV.empty?\nputs ARGV.first\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	program.ModulePath = "example.com/greet"
+	result, err := compiler.Compile(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := moduleFiles(result, t.TempDir(), "example.com/greet", "v1.2.3", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "module example.com/greet\n\ngo 1.23\n\nrequire (\n\t" + thanosModule + "/stdlib v1.2.3\n)\n"
+	if files["go.mod"] != expected {
+		t.Errorf("expected go.mod:\n%s\ngot:\n%s", expected, files["go.mod"])
+	}
+	if !strings.Contains(files["main.go"], `"`+thanosModule+`/stdlib"`) {
+		t.Errorf("expected main.go to import the required stdlib, got:\n%s", files["main.go"])
+	}
+
+	files, err = moduleFiles(result, t.TempDir(), "example.com/greet", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files["go.mod"] != "module example.com/greet\n\ngo 1.23\n" {
+		t.Errorf("expected a vendored module to require nothing, got:\n%s", files["go.mod"])
+	}
+	if !strings.Contains(files["main.go"], `"example.com/greet/internal/thanos/stdlib"`) {
+		t.Errorf("expected main.go to import the vendored stdlib, got:\n%s", files["main.go"])
+	}
+	if _, ok := files["internal/thanos/stdlib/exit.go"]; !ok {
+		t.Errorf("expected stdlib to be vendored under internal/thanos")
+	}
+	for path, src := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			t.Errorf("expected tests to be left out of the vendored packages, got %s", path)
+		}
+		if strings.Contains(src, `"`+thanosModule+"/") {
+			t.Errorf("expected %s to import only vendored thanos packages", path)
+		}
+	}
+}
@@ -12,22 +12,37 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var Target, Source string
+var (
+	Target, Source string
+	Watch          bool
+)
 
 var compileCmd = &cobra.Command{
 	Use:   "compile",
 	Short: "Convert Ruby to Go",
 	Long:  `Compile source Ruby to Go to the best of thanos's ability. Lacking functionality is described at https://github.com/redneckbeard/thanos#readme and https://github.com/redneckbeard/thanos/issues`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if Watch {
+			if Source == "" || Target == "" {
+				color.Red("--watch needs both a source file (-s) and a target directory (-t)")
+				exit(1)
+			}
+			watch(Source, Target)
+			return
+		}
 		if Source == "" {
 			color.Green("Input your Ruby and compile with Ctrl-D.")
 		}
 		program, err := parser.ParseFile(Source)
 		if err != nil {
-			color.Red(err.Error())
+			reportError(err, func() { color.Red(err.Error()) })
+			return
+		}
+		result, err := compiler.Compile(program)
+		if err != nil {
+			reportError(err, func() { color.Red(err.Error()) })
 			return
 		}
-		result, _ := compiler.Compile(program)
 		if Target == "" {
 			color.Green(strings.Repeat("-", 20))
 			for path, src := range result.Files {
@@ -37,7 +52,7 @@ var compileCmd = &cobra.Command{
 				quick.Highlight(os.Stdout, src, "go", "terminal256", "monokai")
 			}
 		} else {
-			for path, src := range result.Files {
+			for path, src := range outputFiles(result, Target) {
 				fullPath := filepath.Join(Target, path)
 				os.MkdirAll(filepath.Dir(fullPath), 0755)
 				err = os.WriteFile(fullPath, []byte(src), 0644)
@@ -49,8 +64,25 @@ var compileCmd = &cobra.Command{
 	},
 }
 
+// outputFiles returns the files to write to dir for a compiled program: the
+// Go sources and, beside each, its source map.
+func outputFiles(result *compiler.CompileResult, dir string) map[string]string {
+	files := map[string]string{}
+	abs, _ := filepath.Abs(dir)
+	for path, src := range result.Files {
+		files[path] = src
+		if sm := result.SourceMaps[path]; sm != nil {
+			if b, err := sm.Marshal(filepath.Dir(filepath.Join(abs, path))); err == nil {
+				files[path+compiler.SourceMapSuffix] = string(b) + "\n"
+			}
+		}
+	}
+	return files
+}
+
 func init() {
 	rootCmd.AddCommand(compileCmd)
 	compileCmd.Flags().StringVarP(&Target, "target", "t", "", "Destination for resulting Go (defaults to stdout)")
 	compileCmd.Flags().StringVarP(&Source, "source", "s", "", "Destination for resulting Go (defaults to stdin)")
+	compileCmd.Flags().BoolVarP(&Watch, "watch", "w", false, "Recompile whenever the source, a file it requires or its facade config changes")
 }
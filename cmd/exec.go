@@ -6,9 +6,11 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"strings"
 
@@ -22,76 +24,94 @@ var File string
 
 // execCmd represents the exec command
 var execCmd = &cobra.Command{
-	Use:   "exec",
+	Use:   "exec [-- args...]",
 	Short: "Compiles and executes the input",
 	Long: `'thanos exec' compiles the provided Ruby and immediately executes the Go
 	output. Useful for exploring edge cases that might be missing from the test
-	suite.`,
+	suite, or as a drop-in replacement for 'ruby' in a pipeline: arguments after
+	'--' become ARGV, stdin, stdout and stderr are connected to the program, and
+	thanos exits with the program's status.`,
+	Args: cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		if File == "" {
 			color.Green("Input your Ruby and execute with Ctrl-D.")
 		}
 		program, err := parser.ParseFile(File)
 		if err != nil {
-			fmt.Println(err)
-			return
+			reportError(err, func() { fmt.Fprintln(os.Stderr, err) })
+			exit(1)
+		}
+		if program.TestSuite != "" {
+			fmt.Fprintf(os.Stderr, "%s is a test suite, which compiles to a Go test rather than a program; use 'thanos compile' and run it with 'go test'\n", File)
+			exit(1)
 		}
 		result, err := compiler.Compile(program)
 		if err != nil {
-			fmt.Println(err)
-			if result != nil {
-				fmt.Println(result.MainFile())
-			}
-			return
+			reportError(err, func() {
+				fmt.Fprintln(os.Stderr, err)
+				if result != nil {
+					fmt.Fprintln(os.Stderr, result.MainFile())
+				}
+			})
+			exit(1)
 		}
 
-		stdout, stderr, err := execCompileResult(result)
+		code, stderr, err := execCompileResult(result, args)
 		if err != nil {
 			allSrc := ""
 			for path, src := range result.Files {
 				allSrc += fmt.Sprintf("// === %s ===\n%s\n", path, src)
 			}
-			color.Red("Execution failed for compiled Go:\n------\n%s------\nError: %s", allSrc, stderr)
-		} else {
-			color.Green(strings.Repeat("-", 20))
-			fmt.Print(stdout)
+			fmt.Fprint(os.Stderr, color.RedString("Execution failed for compiled Go:\n------\n%s------\nError: %s\n", allSrc, stderr))
+			exit(1)
 		}
+		exit(code)
 	},
 }
 
-// execCompileResult runs a compiled Go project. For single-file results with
-// no external dependencies it uses a simple `go run tmp.go`. For multi-file
-// results or results that import stdlib/shims, it creates a temporary directory
-// with a go.mod and runs `go run .`.
-func execCompileResult(result *compiler.CompileResult) (string, string, error) {
+// execCompileResult builds a compiled Go project and runs it with the given
+// arguments, connected to thanos's own stdin, stdout and stderr. For
+// single-file results with no external dependencies it builds the file on its
+// own. For multi-file results or results that import stdlib/shims, it creates
+// a temporary directory with a go.mod and builds the package there. It
+// returns the program's exit status, or the build output and an error if the
+// program didn't build.
+func execCompileResult(result *compiler.CompileResult, args []string) (int, string, error) {
 	mainSrc := result.MainFile()
 	needsModule := len(result.Files) > 1 ||
 		strings.Contains(mainSrc, "github.com/redneckbeard/thanos/stdlib") ||
 		strings.Contains(mainSrc, "github.com/redneckbeard/thanos/shims")
 
 	if !needsModule {
-		return execSingleFile(mainSrc)
+		return execSingleFile(mainSrc, args)
 	}
-	return execMultiFile(result)
+	return execMultiFile(result, args)
 }
 
-func execSingleFile(compiled string) (string, string, error) {
-	goTmp, _ := os.CreateTemp("", "thanos-exec-*.go")
-	defer os.Remove(goTmp.Name())
-	goTmp.WriteString(compiled)
-	goTmp.Close()
+func execSingleFile(compiled string, args []string) (int, string, error) {
+	tmpDir, err := os.MkdirTemp("", "thanos-exec-*")
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	goFile := filepath.Join(tmpDir, "main.go")
+	os.WriteFile(goFile, []byte(compiled), 0644)
 
-	run := exec.Command("go", "run", goTmp.Name())
+	bin := filepath.Join(tmpDir, "main")
+	build := exec.Command("go", "build", "-o", bin, goFile)
 	var stderr bytes.Buffer
-	run.Stderr = &stderr
-	stdout, err := run.Output()
-	return string(stdout), stderr.String(), err
+	build.Stderr = &stderr
+	if err := build.Run(); err != nil {
+		return 0, stderr.String(), err
+	}
+	return runProgram(bin, args)
 }
 
-func execMultiFile(result *compiler.CompileResult) (string, string, error) {
+func execMultiFile(result *compiler.CompileResult, args []string) (int, string, error) {
 	tmpDir, err := os.MkdirTemp("", "thanos-exec-*")
 	if err != nil {
-		return "", "", fmt.Errorf("failed to create temp dir: %w", err)
+		return 0, "", fmt.Errorf("failed to create temp dir: %w", err)
 	}
 	defer os.RemoveAll(tmpDir)
 
@@ -118,17 +138,43 @@ func execMultiFile(result *compiler.CompileResult) (string, string, error) {
 	var tidyErr bytes.Buffer
 	tidy.Stderr = &tidyErr
 	if err := tidy.Run(); err != nil {
-		return "", tidyErr.String(), fmt.Errorf("go mod tidy failed: %s", tidyErr.String())
+		return 0, tidyErr.String(), fmt.Errorf("go mod tidy failed: %s", tidyErr.String())
 	}
 
-	// Run the project
-	run := exec.Command("go", "run", ".")
-	run.Dir = tmpDir
-	var stdout, stderr bytes.Buffer
-	run.Stdout = &stdout
-	run.Stderr = &stderr
-	err = run.Run()
-	return stdout.String(), stderr.String(), err
+	// Build the project
+	bin := filepath.Join(tmpDir, "main")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = tmpDir
+	var stderr bytes.Buffer
+	build.Stderr = &stderr
+	if err := build.Run(); err != nil {
+		return 0, stderr.String(), err
+	}
+	return runProgram(bin, args)
+}
+
+// runProgram runs a built program in the foreground and returns its exit
+// status. Interrupts are left for the program to handle, so that Ctrl-C in a
+// pipeline behaves as it would for the program run directly.
+func runProgram(bin string, args []string) (int, string, error) {
+	run := exec.Command(bin, args...)
+	run.Stdin = os.Stdin
+	run.Stdout = os.Stdout
+	run.Stderr = os.Stderr
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+	if err := run.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if code := exitErr.ExitCode(); code >= 0 {
+				return code, "", nil
+			}
+			// Killed by a signal
+			return 1, "", nil
+		}
+		return 0, err.Error(), err
+	}
+	return 0, "", nil
 }
 
 // findThanosRoot locates the thanos project root by looking for the stdlib/
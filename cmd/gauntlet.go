@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redneckbeard/thanos/compiler"
+	"github.com/redneckbeard/thanos/parser"
+)
+
+// gauntletModule is the module path of the module each test file's
+// gauntlet tests are compiled into, one package per test.
+const gauntletModule = "gauntlet"
+
+// A gauntlet is one gauntlet("name") { ... } test from a file in the test
+// directory.
+type gauntlet struct {
+	name, file, script string
+}
+
+const (
+	statusPass  = "pass"
+	statusFail  = "fail"  // the Go printed something other than Ruby did
+	statusError = "error" // the test couldn't be run: Ruby, thanos or Go failed
+)
+
+// A gauntletResult is the outcome of one gauntlet test, as reported by
+// --json.
+type gauntletResult struct {
+	Name     string  `json:"name"`
+	File     string  `json:"file"`
+	Status   string  `json:"status"`
+	Seconds  float64 `json:"seconds"`
+	Message  string  `json:"message,omitempty"`  // the output diff, or what went wrong
+	Compiled string  `json:"compiled,omitempty"` // the translation, for tests that didn't pass
+}
+
+// gauntletCase is a test on its way through the runner.
+type gauntletCase struct {
+	gauntlet
+	pkg      string // package directory in its file's module
+	expected string // what Ruby printed
+	compiled string
+	elapsed  time.Duration
+	err      error
+}
+
+// gauntletRunner runs gauntlet tests with at most cap(jobs) Ruby processes,
+// go builds and compiled programs running at once. Each test file becomes a
+// module with a package per test, so that go resolves and builds it once.
+type gauntletRunner struct {
+	jobs chan struct{}
+	// The parser and compiler keep package-level state, so programs are
+	// compiled one at a time.
+	compile sync.Mutex
+	report  func(gauntletResult)
+	mu      sync.Mutex
+	results []gauntletResult
+}
+
+// runGauntlets runs tests, jobs at a time, calling report as each finishes,
+// and returns the results ordered by file and name.
+func runGauntlets(tests []gauntlet, jobs int, report func(gauntletResult)) []gauntletResult {
+	if jobs < 1 {
+		jobs = 1
+	}
+	r := &gauntletRunner{jobs: make(chan struct{}, jobs), report: report}
+	byFile := map[string][]gauntlet{}
+	for _, t := range tests {
+		byFile[t.file] = append(byFile[t.file], t)
+	}
+	var wg sync.WaitGroup
+	for _, fileTests := range byFile {
+		wg.Add(1)
+		go func(fileTests []gauntlet) {
+			defer wg.Done()
+			r.runFile(fileTests)
+		}(fileTests)
+	}
+	wg.Wait()
+	sort.Slice(r.results, func(i, j int) bool {
+		if r.results[i].File != r.results[j].File {
+			return r.results[i].File < r.results[j].File
+		}
+		return r.results[i].Name < r.results[j].Name
+	})
+	return r.results
+}
+
+// parallel runs f for each case, holding a job for each call.
+func (r *gauntletRunner) parallel(cases []*gauntletCase, f func(c *gauntletCase)) {
+	var wg sync.WaitGroup
+	for _, c := range cases {
+		wg.Add(1)
+		go func(c *gauntletCase) {
+			defer wg.Done()
+			r.jobs <- struct{}{}
+			defer func() { <-r.jobs }()
+			f(c)
+		}(c)
+	}
+	wg.Wait()
+}
+
+// runFile runs the tests from one file: Ruby for each of them, then thanos
+// for each, then a single go build of the module they were written to, then
+// each of the programs it built.
+func (r *gauntletRunner) runFile(tests []gauntlet) {
+	dir, err := os.MkdirTemp("", "thanos-gauntlet-*")
+	if err != nil {
+		for _, t := range tests {
+			r.finish(&gauntletCase{gauntlet: t, err: err}, "")
+		}
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	var cases []*gauntletCase
+	pkgs := map[string]bool{}
+	for _, t := range tests {
+		c := &gauntletCase{gauntlet: t, pkg: uniquePackage(goTestPackage(t.name), pkgs)}
+		if t.script == "" {
+			c.err = fmt.Errorf("No Ruby source detected")
+		}
+		cases = append(cases, c)
+	}
+	r.parallel(pending(cases), func(c *gauntletCase) {
+		c.expected, c.err = rubyOutput(c.script)
+	})
+
+	var built []*gauntletCase
+	for _, c := range pending(cases) {
+		start := time.Now()
+		c.err = r.compileCase(c, filepath.Join(dir, c.pkg))
+		c.elapsed += time.Since(start)
+		if c.err == nil {
+			built = append(built, c)
+		}
+	}
+	if len(built) > 0 {
+		if err := r.build(dir, built); err != nil {
+			for _, c := range built {
+				c.err = err
+			}
+		}
+	}
+	for _, c := range cases {
+		if c.err != nil {
+			r.finish(c, "")
+		}
+	}
+	r.parallel(pending(cases), func(c *gauntletCase) {
+		start := time.Now()
+		var out string
+		out, c.err = runGauntletProgram(filepath.Join(dir, "bin", c.pkg))
+		c.elapsed += time.Since(start)
+		if c.err != nil {
+			r.finish(c, "")
+			return
+		}
+		diff, err := compiler.DiffOutputs(c.expected, out)
+		c.err = err
+		r.finish(c, diff)
+	})
+}
+
+// pending returns the cases that haven't failed yet.
+func pending(cases []*gauntletCase) []*gauntletCase {
+	var ok []*gauntletCase
+	for _, c := range cases {
+		if c.err == nil {
+			ok = append(ok, c)
+		}
+	}
+	return ok
+}
+
+// compileCase compiles a test into pkgDir as a package of the file's module.
+func (r *gauntletRunner) compileCase(c *gauntletCase, pkgDir string) (err error) {
+	r.compile.Lock()
+	defer r.compile.Unlock()
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("thanos panicked: %v", p)
+		}
+	}()
+	program, err := parser.ParseString(c.script)
+	if err != nil {
+		return fmt.Errorf("Error parsing: %w", err)
+	}
+	program.ModulePath = gauntletModule + "/" + c.pkg
+	result, err := compiler.Compile(program)
+	if err != nil {
+		return fmt.Errorf("Error compiling: %w", err)
+	}
+	c.compiled = result.MainFile()
+	if len(result.Files) > 1 {
+		c.compiled = ""
+		paths := make([]string, 0, len(result.Files))
+		for path := range result.Files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			c.compiled += fmt.Sprintf("// === %s ===\n%s\n", path, result.Files[path])
+		}
+	}
+	for path, src := range outputFiles(result, pkgDir) {
+		fullPath := filepath.Join(pkgDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fullPath, []byte(src), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// build resolves the module's dependencies and builds every program in it
+// into bin. A test whose package doesn't build gets the compiler's errors
+// for it; the error returned is for failures that aren't any one test's.
+func (r *gauntletRunner) build(dir string, cases []*gauntletCase) error {
+	r.jobs <- struct{}{}
+	defer func() { <-r.jobs }()
+	if err := writeThanosModule(dir, gauntletModule); err != nil {
+		return err
+	}
+	if err := goCommand(dir, "mod", "tidy"); err != nil {
+		return err
+	}
+	cmd := exec.Command("go", "build", "-o", "bin"+string(filepath.Separator), "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if cmd.Run() == nil {
+		return nil
+	}
+	errs := buildErrors(stderr.String())
+	for _, c := range cases {
+		if _, err := os.Stat(filepath.Join(dir, "bin", c.pkg)); err == nil {
+			continue
+		}
+		msg, ok := errs[c.pkg]
+		if !ok {
+			msg = errs[""]
+		}
+		c.err = fmt.Errorf("%sGo compilation of translated source failed for '%s'", msg, c.name)
+	}
+	return nil
+}
+
+var buildErrorHeader = regexp.MustCompile(`^# ` + gauntletModule + `/([^/\s]+)`)
+
+// buildErrors splits what go build printed into the errors of each package
+// of the module, keyed by the test's package directory. Anything printed
+// before the first package is keyed by "".
+func buildErrors(output string) map[string]string {
+	errs := map[string]string{}
+	pkg := ""
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		if m := buildErrorHeader.FindStringSubmatch(line); m != nil {
+			pkg = m[1]
+			continue
+		}
+		errs[pkg] += line + "\n"
+	}
+	return errs
+}
+
+// runGauntletProgram runs a compiled test and returns what it printed.
+func runGauntletProgram(bin string) (string, error) {
+	cmd := exec.Command(bin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s\nCompiled program failed: %w", stderr.String(), err)
+	}
+	return stdout.String(), nil
+}
+
+// finish records the result of a test and reports it.
+func (r *gauntletRunner) finish(c *gauntletCase, diff string) {
+	result := gauntletResult{Name: c.name, File: c.file, Status: statusPass, Seconds: c.elapsed.Seconds()}
+	switch {
+	case c.err != nil:
+		result.Status, result.Message, result.Compiled = statusError, c.err.Error(), c.compiled
+	case diff != "":
+		result.Status, result.Message, result.Compiled = statusFail, diff, c.compiled
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, result)
+	if r.report != nil {
+		r.report(result)
+	}
+}
+
+// uniquePackage returns pkg, or pkg with a number after it if a test in the
+// same file already has that package.
+func uniquePackage(pkg string, taken map[string]bool) string {
+	if pkg == "" {
+		pkg = "gauntlet"
+	}
+	name := pkg
+	for i := 2; taken[name]; i++ {
+		name = pkg + "_" + strconv.Itoa(i)
+	}
+	taken[name] = true
+	return name
+}
+
+// rubyOutput returns what Ruby prints for script. Outputs are cached by the
+// hash of the script, so a test whose source hasn't changed doesn't need Ruby
+// again; --no-cache runs it anyway and refreshes the cache.
+func rubyOutput(script string) (string, error) {
+	dir := gauntletCacheDir()
+	sum := sha256.Sum256([]byte(script))
+	path := filepath.Join(dir, hex.EncodeToString(sum[:]))
+	if dir != "" && !NoCache {
+		if b, err := os.ReadFile(path); err == nil {
+			return string(b), nil
+		}
+	}
+	out, err := compiler.RunMRI(script)
+	if err != nil {
+		return "", err
+	}
+	if dir != "" && os.MkdirAll(dir, 0755) == nil {
+		// Write and rename, so that a reader never sees half an output.
+		if f, err := os.CreateTemp(dir, "*.tmp"); err == nil {
+			_, werr := f.WriteString(out)
+			if f.Close() == nil && werr == nil {
+				os.Rename(f.Name(), path)
+			}
+			os.Remove(f.Name())
+		}
+	}
+	return out, nil
+}
+
+// gauntletCacheDir is where Ruby's outputs are cached, or "" if there's
+// nowhere to put them.
+func gauntletCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "thanos", "gauntlet")
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeRuby points the runner at a Ruby that prints whatever a script's
+// "# ruby:" comments say it should, counting the scripts it runs, and caches
+// outputs in a directory of the test's own. Go keeps using the caches it
+// already has.
+func fakeRuby(t *testing.T) (runs func() int) {
+	goEnv, err := exec.Command("go", "env", "GOCACHE", "GOMODCACHE", "GOPATH").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range strings.Fields(string(goEnv)) {
+		t.Setenv([]string{"GOCACHE", "GOMODCACHE", "GOPATH"}[i], v)
+	}
+	dir := t.TempDir()
+	count := filepath.Join(dir, "runs")
+	ruby := filepath.Join(dir, "ruby")
+	script := "#!/bin/sh\necho >> " + count + "\nsed -n 's/^# ruby: //p'\n"
+	if err := os.WriteFile(ruby, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("THANOS_RUBY", ruby)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(dir, "cache"))
+	t.Setenv("HOME", dir)
+	return func() int {
+		b, _ := os.ReadFile(count)
+		return strings.Count(string(b), "\n")
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected map[string]string
+	}{
+		{"", map[string]string{"": "\n"}},
+		{
+			"go: downloading something\n# gauntlet/array_reject\narray_reject/main.go:5:2: undefined: x\n# gauntlet/array_reject_2\narray_reject_2/main.go:7:1: syntax error\narray_reject_2/main.go:8:1: too many errors\n",
+			map[string]string{
+				"":               "go: downloading something\n",
+				"array_reject":   "array_reject/main.go:5:2: undefined: x\n",
+				"array_reject_2": "array_reject_2/main.go:7:1: syntax error\narray_reject_2/main.go:8:1: too many errors\n",
+			},
+		},
+	}
+	for i, tt := range tests {
+		if got := buildErrors(tt.output); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("[%d] expected %q, got %q", i, tt.expected, got)
+		}
+	}
+}
+
+func TestGauntletRunnerFinish(t *testing.T) {
+	var reported []string
+	r := &gauntletRunner{report: func(result gauntletResult) { reported = append(reported, result.Name) }}
+	tests := []struct {
+		c        *gauntletCase
+		diff     string
+		expected gauntletResult
+	}{
+		{&gauntletCase{gauntlet: gauntlet{name: "passes", file: "a.rb"}, compiled: "package main"}, "", gauntletResult{Name: "passes", File: "a.rb", Status: statusPass}},
+		{&gauntletCase{gauntlet: gauntlet{name: "differs", file: "a.rb"}, compiled: "package main"}, "-1\n+2\n", gauntletResult{Name: "differs", File: "a.rb", Status: statusFail, Message: "-1\n+2\n", Compiled: "package main"}},
+		{&gauntletCase{gauntlet: gauntlet{name: "crashes", file: "a.rb"}, err: errors.New("Compiled program failed")}, "", gauntletResult{Name: "crashes", File: "a.rb", Status: statusError, Message: "Compiled program failed"}},
+	}
+	for i, tt := range tests {
+		r.finish(tt.c, tt.diff)
+		if got := r.results[i]; !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("[%d] expected %+v, got %+v", i, tt.expected, got)
+		}
+	}
+	if !reflect.DeepEqual(reported, []string{"passes", "differs", "crashes"}) {
+		t.Errorf("expected each result to be reported as it finished, got %v", reported)
+	}
+}
+
+func TestRubyOutputCache(t *testing.T) {
+	runs := fakeRuby(t)
+	noCache := NoCache
+	defer func() { NoCache = noCache }()
+
+	script := "# ruby: 1\nputs 1\n"
+	for i, tt := range []struct {
+		noCache bool
+		runs    int
+	}{{false, 1}, {false, 1}, {true, 2}, {false, 2}} {
+		NoCache = tt.noCache
+		out, err := rubyOutput(script)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out != "1\n" {
+			t.Errorf("[%d] expected Ruby's output 1, got %q", i, out)
+		}
+		if got := runs(); got != tt.runs {
+			t.Errorf("[%d] expected Ruby to have run %d times, got %d", i, tt.runs, got)
+		}
+	}
+}
+
+func TestRunGauntlets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the compiled programs with go")
+	}
+	fakeRuby(t)
+	tests := []gauntlet{
+		{"Array#reject", "b.rb", "# ruby: 1\nputs 1\n"},
+		{"Array#reject!", "b.rb", "# ruby: 2\nputs 1\n"},
+		{"exits", "b.rb", "# ruby: 1\nputs 1\nexit 3\n"},
+		{"undefined", "a.rb", "# ruby: 1\nputs undefined_thing\n"},
+		{"empty", "a.rb", ""},
+		{"prints", "a.rb", "# ruby: 1\n# ruby: 2\nputs 1\nputs 2\n"},
+	}
+	var reported int
+	results := runGauntlets(tests, 2, func(gauntletResult) { reported++ })
+	expected := []struct{ name, status, message string }{
+		{"empty", statusError, "No Ruby source detected"},
+		{"prints", statusPass, ""},
+		{"undefined", statusError, "Error parsing"},
+		{"Array#reject", statusPass, ""},
+		{"Array#reject!", statusFail, "2"},
+		{"exits", statusError, "exit status 3"},
+	}
+	if len(results) != len(expected) || reported != len(expected) {
+		t.Fatalf("expected %d results, all reported, got %d with %d reported: %+v", len(expected), len(results), reported, results)
+	}
+	for i, want := range expected {
+		got := results[i]
+		if got.Name != want.name || got.Status != want.status || !strings.Contains(got.Message, want.message) {
+			t.Errorf("[%d] expected %s to be %s with a message containing %q, got %+v", i, want.name, want.status, want.message, got)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/redneckbeard/thanos/compiler"
+	"github.com/redneckbeard/thanos/parser"
+)
+
+// emitGoTests compiles each gauntlet test into a package of its own under
+// dir, named for the test (with a number after it when another test's name
+// comes out the same, as Array#reject and Array#reject! do), with a Go test
+// that compares what the program prints against what Ruby printed. dir is a module that points back at the
+// thanos checkout for the runtime packages. Tests that Ruby or thanos can't
+// handle are reported and skipped.
+func emitGoTests(tests map[string]string, dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	modPath := filepath.Base(dir)
+	if err := writeThanosModule(dir, modPath); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(tests))
+	for name := range tests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var emitted, failed int
+	pkgs := map[string]bool{}
+	for _, name := range names {
+		fmt.Printf("Emitting test '%s': ", name)
+		pkg := uniquePackage(goTestPackage(name), pkgs)
+		if err := emitGoTest(tests[name], name, filepath.Join(dir, pkg), modPath+"/"+pkg); err != nil {
+			color.Red("FAIL\n    ")
+			color.Red(err.Error())
+			failed++
+			continue
+		}
+		color.Green("OK")
+		emitted++
+	}
+	if err := goCommand(dir, "mod", "tidy"); err != nil {
+		return err
+	}
+	summary := fmt.Sprintf("\n%d tests written to %s, %d failed\n", emitted, dir, failed)
+	if failed > 0 {
+		color.Red(summary)
+	} else {
+		color.Green(summary)
+	}
+	return nil
+}
+
+// emitGoTest writes the compiled program and its Go test to pkgDir,
+// replacing whatever an earlier run left there.
+func emitGoTest(script, name, pkgDir, importPath string) error {
+	if script == "" {
+		return fmt.Errorf("No Ruby source detected")
+	}
+	expected, err := rubyOutput(script)
+	if err != nil {
+		return err
+	}
+	program, err := parser.ParseString(script)
+	if err != nil {
+		return err
+	}
+	program.ModulePath = importPath
+	result, err := compiler.Compile(program)
+	if err != nil {
+		return err
+	}
+	test, err := compiler.GoTest(name, expected)
+	if err != nil {
+		return err
+	}
+	files := outputFiles(result, pkgDir)
+	files[compiler.GoTestFile] = test
+	if err := os.RemoveAll(pkgDir); err != nil {
+		return err
+	}
+	for path, src := range files {
+		fullPath := filepath.Join(pkgDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fullPath, []byte(src), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeThanosModule writes the go.mod for a module of compiled programs,
+// unless there is one already, replacing the thanos modules with the
+// checkout they were compiled by.
+func writeThanosModule(dir, modPath string) error {
+	goModPath := filepath.Join(dir, "go.mod")
+	if _, err := os.Stat(goModPath); err == nil {
+		return nil
+	}
+	root, err := filepath.Abs(findThanosRoot())
+	if err != nil {
+		return err
+	}
+	if rel, err := filepath.Rel(dir, root); err == nil {
+		root = rel
+	}
+	var requires, replaces strings.Builder
+	for _, mod := range []string{thanosModule, thanosModule + "/stdlib", thanosModule + "/shims"} {
+		fmt.Fprintf(&requires, "\t%s v0.0.0\n", mod)
+		path := filepath.ToSlash(filepath.Join(root, strings.TrimPrefix(strings.TrimPrefix(mod, thanosModule), "/")))
+		if !strings.HasPrefix(path, ".") && !filepath.IsAbs(path) {
+			path = "./" + path
+		}
+		fmt.Fprintf(&replaces, "replace %s => %s\n", mod, path)
+	}
+	goMod := fmt.Sprintf("module %s\n\ngo 1.23\n\nrequire (\n%s)\n\n%s", modPath, requires.String(), replaces.String())
+	return os.WriteFile(goModPath, []byte(goMod), 0644)
+}
+
+// goTestPackage turns a gauntlet test name into a package directory name:
+// "array each_with_index" becomes array_each_with_index.
+func goTestPackage(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(words, "_")
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoTestPackages(t *testing.T) {
+	names := []string{"Array#reject", "Array#reject!", "Hash#key", "Hash#key?", "arr[x..]", "arr[x...]", "arr x 2", "!"}
+	want := []string{"array_reject", "array_reject_2", "hash_key", "hash_key_2", "arr_x", "arr_x_2", "arr_x_2_2", "gauntlet"}
+	pkgs := map[string]bool{}
+	var got []string
+	for _, name := range names {
+		got = append(got, uniquePackage(goTestPackage(name), pkgs))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected packages %v, got %v", want, got)
+	}
+}
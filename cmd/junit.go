@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The JUnit XML report written by 'thanos test --junit', in the form CI
+// servers read: a testsuite per test file, a testcase per gauntlet test.
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// writeJUnit writes results, ordered by file, to path as JUnit XML.
+func writeJUnit(results []gauntletResult, path string) error {
+	report := junitSuites{}
+	var total float64
+	for _, r := range results {
+		if len(report.Suites) == 0 || report.Suites[len(report.Suites)-1].Name != r.File {
+			report.Suites = append(report.Suites, junitSuite{Name: r.File})
+		}
+		suite := &report.Suites[len(report.Suites)-1]
+		c := junitCase{
+			Name:      r.Name,
+			Classname: strings.TrimSuffix(filepath.Base(r.File), filepath.Ext(r.File)),
+			Time:      junitTime(r.Seconds),
+		}
+		body := r.Message
+		if r.Compiled != "" {
+			body += "\nTranslation:\n------------\n" + r.Compiled
+		}
+		switch r.Status {
+		case statusFail:
+			c.Failure = &junitProblem{Message: "output differs from Ruby's", Body: body}
+			suite.Failures++
+			report.Failures++
+		case statusError:
+			c.Error = &junitProblem{Message: strings.SplitN(strings.TrimSpace(r.Message), "\n", 2)[0], Body: body}
+			suite.Errors++
+			report.Errors++
+		}
+		suite.Cases = append(suite.Cases, c)
+		suite.Tests++
+		report.Tests++
+		total += r.Seconds
+	}
+	for i := range report.Suites {
+		var seconds float64
+		for _, r := range results {
+			if r.File == report.Suites[i].Name {
+				seconds += r.Seconds
+			}
+		}
+		report.Suites[i].Time = junitTime(seconds)
+	}
+	report.Time = junitTime(total)
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte(xml.Header), append(b, '\n')...), 0644)
+}
+
+func junitTime(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/redneckbeard/thanos/lsp"
+	"github.com/spf13/cobra"
+)
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Run a language server over stdio",
+	Long: `Run a Language Server Protocol server on stdin and stdout, so that editors
+show what thanos makes of Ruby as it is written.
+
+Hovering over a local, instance variable or method shows its inferred type
+and the Go it becomes. Anything thanos can't compile is reported as a
+diagnostic, and the workspace command 'thanos.generatedGo' returns the Go
+generated for the method at a position. Programs are analyzed again shortly
+after each change, reading open documents from the editor's buffers.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// stdout carries the protocol, so anything else that would be
+		// printed there goes to stderr instead.
+		out := os.Stdout
+		os.Stdout = os.Stderr
+		color.Output = os.Stderr
+		if err := lsp.NewServer(os.Stdin, out).Run(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+}
@@ -5,15 +5,21 @@ Copyright © 2022 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
+	"github.com/fatih/color"
 	"github.com/redneckbeard/thanos/compiler"
+	"github.com/redneckbeard/thanos/inventory"
+	"github.com/redneckbeard/thanos/parser"
 	"github.com/redneckbeard/thanos/types"
 	"github.com/spf13/cobra"
 )
 
-var className string
+var className, reportProject, reportOutput string
 
 var requiresByClass = map[string]string{
 	"Set": "set",
@@ -38,11 +44,59 @@ methods.sort!.each {|m| puts m}`, requires, className)
 	}
 }
 
+// projectReport writes the porting readiness inventory of dir in the given
+// format: Markdown for text, json, or sarif.
+func projectReport(dir, format string, w io.Writer) error {
+	// Anything printed while compiling would otherwise end up in the report.
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	report, err := inventory.Scan(dir)
+	os.Stdout = stdout
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case "sarif":
+		return parser.WriteSARIF(w, report.Diagnostics())
+	}
+	report.WriteMarkdown(w)
+	return nil
+}
+
 var reportCmd = &cobra.Command{
 	Use:   "report",
-	Short: "Generate a report on methods missing from built-in types",
-	Long:  `Generates a report on methods missing from built-in types. This currently deliberately excludes TrueClass and FalseClass because of how the thanos type inference framework handles booleans.`,
+	Short: "Generate a report on methods missing from built-in types, or on a project's readiness to port",
+	Long: `Generates a report on methods missing from built-in types. This currently deliberately excludes TrueClass and FalseClass because of how the thanos type inference framework handles booleans.
+
+With --project, instead inventories a Ruby codebase: every .rb file under the
+directory is parsed in tolerant mode, carrying on past errors, and the report
+ranks unsupported grammar constructs, unresolved methods by receiver type,
+requires with no facade or gem source and uses of metaprogramming, along with
+an estimate of how many methods compile cleanly. It is written as Markdown or,
+with --format json, as JSON for tracking progress over time; with --format
+sarif, each location of a finding is a SARIF result.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if reportProject != "" {
+			w := io.Writer(os.Stdout)
+			if reportOutput != "" {
+				f, err := os.Create(reportOutput)
+				if err != nil {
+					color.Red(err.Error())
+					exit(1)
+				}
+				defer f.Close()
+				w = f
+			}
+			if err := projectReport(reportProject, parser.DiagnosticFormat, w); err != nil {
+				color.Red(err.Error())
+				exit(1)
+			}
+			return
+		}
 		if className != "" {
 			types.ClassRegistry.Initialize()
 			if _, err := types.ClassRegistry.Get(className); err != nil {
@@ -66,4 +120,6 @@ var reportCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(reportCmd)
 	reportCmd.Flags().StringVarP(&className, "class", "c", "", "Ruby class report will be generated for (defaults to all currently implemented core classes")
+	reportCmd.Flags().StringVarP(&reportProject, "project", "p", "", "Directory of a Ruby codebase to report on instead")
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "File to write the project report to (defaults to stdout)")
 }
@@ -5,6 +5,7 @@ Copyright © 2022 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/redneckbeard/thanos/parser"
@@ -19,6 +20,53 @@ var rootCmd = &cobra.Command{
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		switch parser.DiagnosticFormat {
+		case "text", "json", "sarif":
+		default:
+			return fmt.Errorf("unknown format %q (expected text, json or sarif)", parser.DiagnosticFormat)
+		}
+		if diagnosticsOut != "" {
+			f, err := os.Create(diagnosticsOut)
+			if err != nil {
+				return err
+			}
+			parser.DiagnosticOutput = f
+		}
+		return nil
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		flushDiagnostics()
+	},
+}
+
+var diagnosticsOut string
+
+// flushDiagnostics writes out any diagnostics still buffered for the selected
+// format. Commands that exit early must call it first; exit does so for them.
+func flushDiagnostics() {
+	if err := parser.FlushDiagnostics(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if f, ok := parser.DiagnosticOutput.(*os.File); ok && f != os.Stderr {
+		f.Close()
+		parser.DiagnosticOutput = os.Stderr
+	}
+}
+
+// exit flushes diagnostics and exits with the given status.
+func exit(code int) {
+	flushDiagnostics()
+	os.Exit(code)
+}
+
+// reportError reports an error that stops a command. With structured
+// diagnostics selected it becomes a diagnostic; otherwise print is called to
+// show it the way the command always has.
+func reportError(err error, print func()) {
+	if !parser.ReportError(err) {
+		print()
+	}
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -42,4 +90,6 @@ func init() {
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 	rootCmd.PersistentFlags().BoolVar(&parser.NoGems, "no-gems", false, "Disable gem source resolution (facades still work)")
 	rootCmd.PersistentFlags().IntVarP(&parser.Verbosity, "verbosity", "v", 1, "Verbosity level (0=quiet, 1=warnings, 2=notes)")
+	rootCmd.PersistentFlags().StringVar(&parser.DiagnosticFormat, "format", "text", "Format for warnings and errors, and for the report of report --project: text, json (one object per line) or sarif")
+	rootCmd.PersistentFlags().StringVar(&diagnosticsOut, "diagnostics-out", "", "File to write diagnostics to (defaults to stderr)")
 }
@@ -6,9 +6,11 @@ package cmd
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
 
@@ -18,8 +20,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var TestDir, TestFile, TestCase, CommFlags string
-var OnlyFailures bool
+var TestDir, TestFile, TestCase, CommFlags, GoTestsDir string
+var OnlyFailures, EmitGoTests, TestJSON, NoCache bool
+var TestJUnit string
+var TestJobs int
 
 const failuresFile = ".failures"
 
@@ -60,28 +64,25 @@ func writeFailures(failures map[string]bool) {
 	}
 }
 
-func runTest(script, name string) bool {
-	fmt.Printf("Running test '%s': ", name)
-	if script == "" {
-		color.Red("FAIL\n    ")
-		color.Red("No Ruby source detected")
-		return false
-	}
-	if diff, compiled, err := compiler.CompareThanosToMRI(script, name); err != nil {
-		color.Red("FAIL\n    ")
-		color.Red(err.Error())
-		return false
-	} else if diff != "" {
+// printResult prints the result of a test as it finishes.
+func printResult(r gauntletResult) {
+	fmt.Printf("Running test '%s': ", r.Name)
+	switch r.Status {
+	case statusPass:
+		color.Green("PASS")
+	case statusFail:
 		color.Red(`FAIL
    
 %s
 Translation:
 ------------
-%s`, diff, compiled)
-		return false
-	} else {
-		color.Green("PASS")
-		return true
+%s`, r.Message, r.Compiled)
+	default:
+		color.Red("FAIL\n    ")
+		color.Red(r.Message)
+		if r.Compiled != "" {
+			color.Red("Translation:\n------\n%s------", r.Compiled)
+		}
 	}
 }
 
@@ -92,12 +93,24 @@ var testCmd = &cobra.Command{
 	test directory and consumes all 'gauntlet("<test name") { <test body> }'
 	calls. The test runner loads the test for the test name provided (or all
 	tests if no name is given), executes it using system Ruby, transpiles and
-	executes it using system Go, and then compares the resulting stdout.`,
+	executes it using system Go, and then compares the resulting stdout.
+
+	Tests run --jobs at a time. The tests of each file are compiled into one Go
+	module, a package per test, built with a single 'go build'. What Ruby
+	printed is cached by the test's source, so only new or changed tests need
+	Ruby. --json prints the results as JSON once the run is over, --junit
+	writes them as JUnit XML for CI, and the exit status is 1 if any test
+	failed.
+
+	With --emit-go-tests, each test is instead compiled into a package of its
+	own under the --go-tests-dir module, beside a Go test that checks it prints
+	exactly what Ruby did, so that 'go test ./...' guards the translation
+	without Ruby installed.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if CommFlags != "" {
 			compiler.CommFlags = CommFlags
 		}
-		tests := map[string]string{}
+		tests := map[string]gauntlet{}
 		testFiles, err := filepath.Glob(filepath.Join(TestDir, "*"))
 		if err != nil {
 			fmt.Println(err)
@@ -110,60 +123,89 @@ var testCmd = &cobra.Command{
 			// knows what went wrong.
 			program, parseErr := parser.ParseFile(file)
 			if program == nil {
-				color.Red("Parse error in %s: %v", file, parseErr)
+				reportError(parseErr, func() { color.Red("Parse error in %s: %v", file, parseErr) })
 				continue
 			}
 			calls := program.MethodSetStack.Peek().Calls["gauntlet"]
 			if len(calls) == 0 && parseErr != nil {
-				color.Red("Parse error in %s: %v", file, parseErr)
+				reportError(parseErr, func() { color.Red("Parse error in %s: %v", file, parseErr) })
 				continue
 			}
 			for _, call := range calls {
-				tests[strings.Trim(call.Args[0].String(), `"'`)] = call.RawBlock
+				name := strings.Trim(call.Args[0].String(), `"'`)
+				tests[name] = gauntlet{name: name, file: file, script: call.RawBlock}
 			}
 			}
 		}
 		if TestCase != "" {
-			if script, ok := tests[TestCase]; ok {
-				runTest(script, TestCase)
-			} else {
+			test, ok := tests[TestCase]
+			if !ok {
 				fmt.Println("Could not find test:", TestCase)
+				return
 			}
-		} else {
-			previousFailures := loadFailures()
-			currentFailures := map[string]bool{}
-			var passes, fails, skipped int
-			for name, script := range tests {
-				if OnlyFailures && previousFailures != nil && !previousFailures[name] {
-					skipped++
-					continue
-				}
-				if runTest(script, name) {
-					passes++
-				} else {
-					fails++
-					currentFailures[name] = true
-				}
+			tests = map[string]gauntlet{TestCase: test}
+		}
+		if EmitGoTests {
+			scripts := map[string]string{}
+			for name, test := range tests {
+				scripts[name] = test.script
+			}
+			if err := emitGoTests(scripts, GoTestsDir); err != nil {
+				color.Red(err.Error())
+				exit(1)
 			}
-			// Merge: keep previous failures that weren't re-run,
-			// add new failures, remove tests that now pass.
-			merged := map[string]bool{}
-			if previousFailures != nil {
-				for name := range previousFailures {
-					if _, ran := tests[name]; !ran {
-						// Test wasn't loaded (e.g. file filter) — preserve
-						merged[name] = true
-					}
-					if OnlyFailures && !previousFailures[name] {
-						// Wasn't in --only-failures run — preserve
-						merged[name] = true
-					}
-				}
+			return
+		}
+		previousFailures := loadFailures()
+		var run []gauntlet
+		skipped := 0
+		for name, test := range tests {
+			if OnlyFailures && previousFailures != nil && !previousFailures[name] {
+				skipped++
+				continue
 			}
-			for name := range currentFailures {
-				merged[name] = true
+			run = append(run, test)
+		}
+		report := printResult
+		if TestJSON {
+			report = nil
+		}
+		results := runGauntlets(run, TestJobs, report)
+		var passes, fails int
+		// Keep the previous failures of tests that weren't run, add new
+		// failures and drop tests that now pass.
+		failures := map[string]bool{}
+		for name := range previousFailures {
+			failures[name] = true
+		}
+		for _, r := range results {
+			if r.Status == statusPass {
+				passes++
+				delete(failures, r.Name)
+			} else {
+				fails++
+				failures[r.Name] = true
 			}
-			writeFailures(merged)
+		}
+		writeFailures(failures)
+		if TestJUnit != "" {
+			if err := writeJUnit(results, TestJUnit); err != nil {
+				color.Red(err.Error())
+				exit(1)
+			}
+		}
+		if TestJSON {
+			if results == nil {
+				results = []gauntletResult{}
+			}
+			b, _ := json.MarshalIndent(struct {
+				Passed  int              `json:"passed"`
+				Failed  int              `json:"failed"`
+				Skipped int              `json:"skipped"`
+				Tests   []gauntletResult `json:"tests"`
+			}{passes, fails, skipped, results}, "", "  ")
+			fmt.Println(string(b))
+		} else {
 			summary := fmt.Sprintf("\n%d passing, %d failures", passes, fails)
 			if skipped > 0 {
 				summary += fmt.Sprintf(", %d skipped", skipped)
@@ -175,6 +217,9 @@ var testCmd = &cobra.Command{
 				color.Green(summary)
 			}
 		}
+		if fails > 0 {
+			exit(1)
+		}
 	},
 }
 
@@ -184,5 +229,11 @@ func init() {
 	testCmd.Flags().StringVarP(&TestFile, "file", "f", "", "Single file relative to test directory from which tests are loaded (default loads all files)")
 	testCmd.Flags().StringVarP(&TestCase, "gauntlet", "g", "", "Runs only the gauntlet test with the given name")
 	testCmd.Flags().BoolVar(&OnlyFailures, "only-failures", false, "Run only tests that failed in the previous run")
+	testCmd.Flags().IntVarP(&TestJobs, "jobs", "j", runtime.NumCPU(), "Number of tests to run at once")
+	testCmd.Flags().BoolVar(&NoCache, "no-cache", false, "Run Ruby for every test instead of reusing its cached output, and refresh the cache")
+	testCmd.Flags().BoolVar(&TestJSON, "json", false, "Print the results as JSON instead of as the tests finish")
+	testCmd.Flags().StringVar(&TestJUnit, "junit", "", "Also write the results to this file as JUnit XML")
+	testCmd.Flags().BoolVar(&EmitGoTests, "emit-go-tests", false, "Write each gauntlet test as a Go test with Ruby's output as its golden string, instead of running it")
+	testCmd.Flags().StringVarP(&GoTestsDir, "go-tests-dir", "o", "gotests", "Module directory --emit-go-tests writes to")
 	testCmd.Flags().StringVar(&CommFlags, "comm", "", "comm(1) flags for output comparison (default -23; try -12, -3, etc.)")
 }
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/redneckbeard/thanos/compiler"
+	"github.com/spf13/cobra"
+)
+
+var traceDir string
+
+var traceCmd = &cobra.Command{
+	Use:   "trace [file]",
+	Short: "Point Go stack traces and diagnostics back at the Ruby",
+	Long: `'thanos trace' reads output from a compiled program or the Go toolchain —
+panic stack traces, build errors, go vet — and replaces every location in the
+generated Go with the Ruby file and line it came from, using the source maps
+that 'thanos compile -t' and 'thanos build' write beside each Go file.
+Locations no source map covers are left alone.
+
+It reads the named file, or stdin if there is none:
+
+    go run . 2>&1 | thanos trace -d out/
+    go vet ./... 2>&1 | thanos trace`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tracer, err := compiler.LoadSourceMaps(traceDir)
+		if err != nil {
+			color.Red(err.Error())
+			exit(1)
+		}
+		in := io.Reader(os.Stdin)
+		if len(args) == 1 {
+			f, err := os.Open(args[0])
+			if err != nil {
+				color.Red(err.Error())
+				exit(1)
+			}
+			defer f.Close()
+			in = f
+		}
+		// Rewrite line by line so that output piped from a running program
+		// shows up as it is produced.
+		r := bufio.NewReader(in)
+		for {
+			line, err := r.ReadString('\n')
+			fmt.Print(tracer.Rewrite(line))
+			if err != nil {
+				break
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(traceCmd)
+	traceCmd.Flags().StringVarP(&traceDir, "dir", "d", ".", "Directory the Go was generated into, where its source maps are")
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/redneckbeard/thanos/compiler"
+	"github.com/redneckbeard/thanos/parser"
+)
+
+// watchInterval is how often a watcher checks its sources for changes.
+var watchInterval = 500 * time.Millisecond
+
+// A watcher recompiles a program into a target directory whenever one of the
+// files it was built from changes.
+type watcher struct {
+	source, target string
+	// sources maps every file the last compilation read to its state then.
+	sources map[string]fileState
+	// written holds the target-relative paths of the files output by the
+	// last successful compilation.
+	written map[string]bool
+	// diagnostics are those reported by the last compilation.
+	diagnostics []parser.Diagnostic
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{info.ModTime(), info.Size()}
+}
+
+// watch compiles source into target, then again each time its sources
+// change, until the process is interrupted.
+func watch(source, target string) {
+	abs, err := filepath.Abs(source)
+	if err != nil {
+		color.Red(err.Error())
+		exit(1)
+	}
+	w := &watcher{source: abs, target: target, written: map[string]bool{}}
+	for {
+		w.compile()
+		color.Cyan("watching %d files for changes", len(w.sources))
+		for !w.changed() {
+			time.Sleep(watchInterval)
+		}
+	}
+}
+
+// changed reports whether any source differs from when it was last read.
+func (w *watcher) changed() bool {
+	for path, state := range w.sources {
+		if statFile(path) != state {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *watcher) compile() {
+	start := time.Now()
+	var (
+		root   *parser.Root
+		result *compiler.CompileResult
+		err    error
+	)
+	// Note the state of the entry file before reading it, so that edits made
+	// while compiling are picked up by the next round.
+	sources := map[string]fileState{w.source: statFile(w.source)}
+	diagnostics := parser.CollectDiagnostics(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("thanos crashed compiling this program: %v", r)
+			}
+		}()
+		root, err = parser.ParseProgram(w.source)
+		if err == nil {
+			result, err = compiler.Compile(root)
+		}
+	})
+	if root != nil {
+		for _, path := range root.Sources {
+			if _, ok := sources[path]; !ok {
+				sources[path] = statFile(path)
+			}
+		}
+	} else {
+		// Without a root there is no telling what would have been read, so
+		// keep watching what was read last time.
+		for path, state := range w.sources {
+			if _, ok := sources[path]; !ok {
+				sources[path] = state
+			}
+		}
+	}
+	w.sources = sources
+	if err != nil {
+		diagnostics = append(diagnostics, parser.DiagnosticFromError(err))
+	}
+
+	elapsed := time.Since(start).Round(time.Millisecond)
+	stamp := time.Now().Format("15:04:05")
+	if result == nil {
+		color.Red("[%s] compilation failed after %s", stamp, elapsed)
+	} else {
+		written, unchanged, removed, errs := w.write(outputFiles(result, w.target))
+		for _, err := range errs {
+			color.Red(err.Error())
+		}
+		summary := fmt.Sprintf("[%s] compiled in %s: %d files written, %d unchanged", stamp, elapsed, written, unchanged)
+		if removed > 0 {
+			summary += fmt.Sprintf(", %d removed", removed)
+		}
+		color.Green(summary)
+	}
+	w.diff(visibleDiagnostics(diagnostics))
+}
+
+// write writes each output file whose contents differ from what is on
+// disk, and removes files generated last time that no longer are.
+func (w *watcher) write(files map[string]string) (written, unchanged, removed int, errs []error) {
+	paths := map[string]bool{}
+	for path, src := range files {
+		paths[path] = true
+		fullPath := filepath.Join(w.target, path)
+		if existing, err := os.ReadFile(fullPath); err == nil && bytes.Equal(existing, []byte(src)) {
+			unchanged++
+			continue
+		}
+		os.MkdirAll(filepath.Dir(fullPath), 0755)
+		if err := os.WriteFile(fullPath, []byte(src), 0644); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		written++
+	}
+	for path := range w.written {
+		if !paths[path] {
+			if err := os.Remove(filepath.Join(w.target, path)); err == nil {
+				removed++
+			}
+		}
+	}
+	w.written = paths
+	return
+}
+
+// diff prints the diagnostics that appeared since the last compilation and
+// those that went away, as diagnosticDiff reports them.
+func (w *watcher) diff(diagnostics []parser.Diagnostic) {
+	lines := diagnosticDiff(w.diagnostics, diagnostics)
+	for _, line := range lines {
+		if line[0] == '+' {
+			color.Red(line)
+		} else {
+			color.Green(line)
+		}
+	}
+	if len(lines) == 0 && len(diagnostics) > 0 {
+		fmt.Printf("%d diagnostics, none changed\n", len(diagnostics))
+	}
+	w.diagnostics = diagnostics
+}
+
+// diagnosticDiff returns the diagnostics in current that aren't in previous,
+// prefixed with '+', and those in previous that aren't in current, prefixed
+// with '-', sorted by what follows the prefix. Diagnostics are matched
+// ignoring their line so that edits elsewhere in a file don't report them as
+// both fixed and new.
+func diagnosticDiff(previous, current []parser.Diagnostic) []string {
+	key := func(d parser.Diagnostic) string {
+		return strings.Join([]string{d.File, string(d.Severity), d.Code, d.Message}, "\x00")
+	}
+	before := map[string]int{}
+	for _, d := range previous {
+		before[key(d)]++
+	}
+	after := map[string]int{}
+	for _, d := range current {
+		after[key(d)]++
+	}
+	var lines []string
+	for _, d := range current {
+		if k := key(d); before[k] > 0 {
+			before[k]--
+		} else {
+			lines = append(lines, "+ "+formatWatchDiagnostic(d))
+		}
+	}
+	for _, d := range previous {
+		if k := key(d); after[k] > 0 {
+			after[k]--
+		} else {
+			lines = append(lines, "- "+formatWatchDiagnostic(d))
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i][2:] < lines[j][2:] })
+	return lines
+}
+
+// visibleDiagnostics filters out warnings and notes as Verbosity dictates.
+func visibleDiagnostics(diagnostics []parser.Diagnostic) []parser.Diagnostic {
+	var visible []parser.Diagnostic
+	for _, d := range diagnostics {
+		switch {
+		case d.Severity == parser.SeverityWarning && parser.Verbosity < 1, d.Severity == parser.SeverityNote && parser.Verbosity < 2:
+			continue
+		}
+		visible = append(visible, d)
+	}
+	return visible
+}
+
+func formatWatchDiagnostic(d parser.Diagnostic) string {
+	s := fmt.Sprintf("%s: %s", d.Severity, d.Message)
+	if d.Code != "" {
+		s += " [" + d.Code + "]"
+	}
+	if d.File == "" {
+		return s
+	}
+	file := parser.DisplayPath(d.File)
+	if d.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", file, d.Line, s)
+	}
+	return file + ": " + s
+}
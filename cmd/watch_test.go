@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/redneckbeard/thanos/parser"
+)
+
+func TestWatcherWrite(t *testing.T) {
+	dir := t.TempDir()
+	// A file the watcher didn't write is never removed.
+	os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module prog\n"), 0644)
+	w := &watcher{target: dir, written: map[string]bool{}}
+	tests := []struct {
+		files                       map[string]string
+		written, unchanged, removed int
+	}{
+		{map[string]string{"main.go": "a", "cli/cli.go": "b"}, 2, 0, 0},
+		{map[string]string{"main.go": "a", "cli/cli.go": "c"}, 1, 1, 0},
+		{map[string]string{"main.go": "a"}, 0, 1, 1},
+		{map[string]string{"main.go": "d", "cli/cli.go": "b"}, 2, 0, 0},
+	}
+	for i, tt := range tests {
+		written, unchanged, removed, errs := w.write(tt.files)
+		if len(errs) > 0 {
+			t.Fatalf("[%d] write failed: %v", i, errs)
+		}
+		if written != tt.written || unchanged != tt.unchanged || removed != tt.removed {
+			t.Errorf("[%d] expected %d written, %d unchanged and %d removed, got %d, %d and %d", i, tt.written, tt.unchanged, tt.removed, written, unchanged, removed)
+		}
+		for _, path := range []string{"main.go", "cli/cli.go"} {
+			b, err := os.ReadFile(filepath.Join(dir, path))
+			if src, ok := tt.files[path]; ok && string(b) != src {
+				t.Errorf("[%d] expected %s to contain %q, got %q", i, path, src, b)
+			} else if !ok && err == nil {
+				t.Errorf("[%d] expected %s to have been removed", i, path)
+			}
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+		t.Errorf("expected go.mod to be left alone, got %v", err)
+	}
+}
+
+func TestWatcherChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.rb")
+	os.WriteFile(path, []byte("puts 1\n"), 0644)
+	w := &watcher{sources: map[string]fileState{path: statFile(path)}}
+	if w.changed() {
+		t.Errorf("expected no change before the file is edited")
+	}
+	os.WriteFile(path, []byte("puts 12\n"), 0644)
+	if !w.changed() {
+		t.Errorf("expected a change once the file is edited")
+	}
+}
+
+func TestDiagnosticDiff(t *testing.T) {
+	unused := parser.Diagnostic{Location: parser.Location{File: "main.rb", Line: 3}, Severity: parser.SeverityWarning, Code: "unused", Message: "x is never used"}
+	moved := unused
+	moved.Line = 5
+	infer := parser.Diagnostic{Location: parser.Location{File: "main.rb", Line: 7}, Severity: parser.SeverityError, Code: "cannot-infer", Message: "cannot infer type of y"}
+	tests := []struct {
+		previous, current []parser.Diagnostic
+		expected          []string
+	}{
+		{nil, nil, nil},
+		{nil, []parser.Diagnostic{unused}, []string{"+ main.rb:3: warning: x is never used [unused]"}},
+		{[]parser.Diagnostic{unused}, nil, []string{"- main.rb:3: warning: x is never used [unused]"}},
+		{[]parser.Diagnostic{unused}, []parser.Diagnostic{moved}, nil},
+		{[]parser.Diagnostic{unused}, []parser.Diagnostic{moved, moved}, []string{"+ main.rb:5: warning: x is never used [unused]"}},
+		{[]parser.Diagnostic{unused, infer}, []parser.Diagnostic{moved}, []string{"- main.rb:7: error: cannot infer type of y [cannot-infer]"}},
+		{[]parser.Diagnostic{infer}, []parser.Diagnostic{unused}, []string{"+ main.rb:3: warning: x is never used [unused]", "- main.rb:7: error: cannot infer type of y [cannot-infer]"}},
+	}
+	for i, tt := range tests {
+		if got := diagnosticDiff(tt.previous, tt.current); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("[%d] expected %q, got %q", i, tt.expected, got)
+		}
+	}
+}
@@ -55,8 +55,11 @@ type GoProgram struct {
 	GlobalVars      []*ast.ValueSpec
 	Constants       []*ast.ValueSpec
 	TrackerStack    []bst.IdentTracker
-	Warnings        []string
+	Warnings        []parser.Diagnostic
 	Finalizers      []ast.Stmt
+	Deferred        []ast.Expr
+	catchAllRescues []catchAllRescue // every rescue that catches everything, in main and module packages
+	modulePackages  []*modulePackage // module packages compiled but not yet formatted
 	deferredInterps []deferredSprintf
 	it              bst.IdentTracker
 	currentRcvr     *ast.Ident
@@ -64,7 +67,13 @@ type GoProgram struct {
 	orderSafeHashes map[string]bool
 	modulePrefix    string // non-empty when compiling a module into its own package
 	currentMethod   *parser.Method
-	suppressDeref   bool // suppress *T dereference during ||= compilation
+	suppressDeref   bool                  // suppress *T dereference during ||= compilation
+	redoTargets     []*jumpTarget         // enclosing loop and block bodies, innermost last
+	retryTargets    []*jumpTarget         // enclosing rescue clauses, innermost last
+	labels          int                   // redo labels allocated so far
+	unionCases      map[string]*ast.Ident // union locals narrowed by a `case` type switch, to the wrapper it binds
+	methodDecls     map[*parser.Method][]ast.Decl // declarations compiled from each method, shared with module packages
+	stmtSources     map[ast.Stmt]rubySource       // the Ruby each statement was compiled from, shared with module packages
 }
 
 // localName strips the module prefix from a qualified name when compiling
@@ -83,6 +92,19 @@ func (g *GoProgram) localName(qualifiedName string) string {
 	return qualifiedName
 }
 
+// constantName returns the Go identifier for a user-defined constant. Constants
+// owned by a module that was compiled into its own package are qualified with
+// that package's name when referenced from outside it.
+func (g *GoProgram) constantName(constant *parser.Constant) string {
+	if mod := constant.Module(); mod != nil && g.modulePrefix != mod.QualifiedName() {
+		if cls, ok := mod.Type().(*types.Class); ok && cls.PackagePath != "" {
+			g.AddImports(cls.PackagePath)
+			return strings.ToLower(mod.Name()) + "." + strings.TrimPrefix(constant.QualifiedName(), mod.QualifiedName())
+		}
+	}
+	return g.localName(constant.QualifiedName())
+}
+
 // localizeExpr strips self-package qualifiers from idents in an expression
 // when compiling inside a module package.
 func (g *GoProgram) localizeExpr(expr ast.Expr) ast.Expr {
@@ -101,15 +123,24 @@ func (g *GoProgram) localizeExpr(expr ast.Expr) ast.Expr {
 	return expr
 }
 
-func (g *GoProgram) Warn(lineNo int, msg string) {
-	g.Warnings = append(g.Warnings, fmt.Sprintf("warning: line %d: %s", lineNo, msg))
+// Warn records a warning about node, reported once compilation succeeds.
+func (g *GoProgram) Warn(node parser.Node, code, msg string) {
+	g.Warnings = append(g.Warnings, parser.NewDiagnostic(node, parser.SeverityWarning, code, "%s", msg))
+}
+
+// warnGemSkipped reports gem code that was left out of the output because it
+// failed to compile.
+func warnGemSkipped(node parser.Node, format string, args ...interface{}) {
+	parser.Report(parser.NewDiagnostic(node, parser.SeverityWarning, "gem-skipped", format, args...))
 }
 
 // CompileResult holds the output of a compilation — one or more Go source files.
 // For simple programs, only "main.go" is present. When Ruby modules are used,
 // each module produces a separate Go package in its own subdirectory.
 type CompileResult struct {
-	Files map[string]string // relative path -> Go source
+	Files      map[string]string         // relative path -> Go source
+	SourceMaps map[string]*SourceMap     // relative path -> map of its lines back to Ruby
+	Methods    map[*parser.Method]string // Go source of the declarations each method compiled to
 }
 
 // MainFile returns the main.go source for backward compatibility.
@@ -119,7 +150,7 @@ func (r *CompileResult) MainFile() string {
 
 func Compile(p *parser.Root) (*CompileResult, error) {
 	globalIdents = bst.NewIdentTracker()
-	g := &GoProgram{State: &parser.Stack[State]{}, ScopeChain: p.ScopeChain, Imports: make(map[string]bool), BlockStack: &parser.Stack[*ast.BlockStmt]{}}
+	g := &GoProgram{State: &parser.Stack[State]{}, ScopeChain: p.ScopeChain, Imports: make(map[string]bool), BlockStack: &parser.Stack[*ast.BlockStmt]{}, methodDecls: map[*parser.Method][]ast.Decl{}, stmtSources: map[ast.Stmt]rubySource{}}
 	g.cs = newCommentState(p.Comments)
 	g.orderSafeHashes = parser.MarkOrderSafeHashes(p.ScopeChain)
 	g.pushTracker()
@@ -164,6 +195,9 @@ func Compile(p *parser.Root) (*CompileResult, error) {
 	// Emit synthesized struct declarations (from Tuple promotion) — main package only
 	decls = append(decls, g.compileSynthStructs("")...)
 
+	// Emit sealed interfaces and wrapper structs for union types
+	decls = append(decls, g.compileUnions()...)
+
 	// Emit package-level vars for global variables ($var)
 	for name, t := range parser.GlobalVars() {
 		if t != nil {
@@ -177,13 +211,21 @@ func Compile(p *parser.Root) (*CompileResult, error) {
 	// top-level declarations that are assembled after compilation.
 	g.cs.goLine = 1000
 
+	mainName, mainPath := goMain(p)
 	mainFunc := &ast.FuncDecl{
-		Name: &ast.Ident{Name: "main", NamePos: g.cs.pos(g.cs.goLine)},
+		Name: &ast.Ident{Name: mainName, NamePos: g.cs.pos(g.cs.goLine)},
 		Type: &ast.FuncType{
 			Func:   g.cs.pos(g.cs.goLine),
 			Params: &ast.FieldList{},
 		},
 	}
+	if p.TestSuite != "" {
+		mainFunc.Type.Params.List = []*ast.Field{{
+			Names: []*ast.Ident{ast.NewIdent("t")},
+			Type:  &ast.StarExpr{X: &ast.SelectorExpr{X: ast.NewIdent("testing"), Sel: ast.NewIdent("T")}},
+		}}
+		g.AddImports("testing")
+	}
 
 	g.newBlockStmt()
 	g.pushTracker()
@@ -211,6 +253,29 @@ func Compile(p *parser.Root) (*CompileResult, error) {
 	}
 	g.BlockStack.Pop()
 
+	// Compile each top-level module (and nested sub-modules) into packages.
+	// Nothing is formatted until every package is compiled, since a method in
+	// one may exit, which main has to defer the handling of.
+	for _, mod := range p.TopLevelModules {
+		if mod.IsFromGem() {
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						warnGemSkipped(mod, "skipping gem module %s compilation: %s", mod.Name(), truncateMsg(r))
+					}
+				}()
+				g.compileModulePackages(mod, "", p.ScopeChain)
+			}()
+		} else {
+			if err := g.compileModulePackages(mod, "", p.ScopeChain); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(g.Deferred) > 0 {
+		mainFunc.Body.List = append(g.deferInMain(), mainFunc.Body.List...)
+	}
+
 	decls = append(decls, mainFunc)
 
 	importPaths := []string{}
@@ -261,7 +326,7 @@ func Compile(p *parser.Root) (*CompileResult, error) {
 		// Position top-level declarations before the main function body.
 		topLine := 2
 		for _, d := range f.Decls {
-			if fd, ok := d.(*ast.FuncDecl); ok && fd.Name.Name == "main" {
+			if d == mainFunc {
 				continue
 			}
 			topLine++
@@ -295,28 +360,31 @@ func Compile(p *parser.Root) (*CompileResult, error) {
 		return nil, err
 	}
 
-	result := &CompileResult{Files: map[string]string{"main.go": mainSrc}}
+	result := &CompileResult{
+		Files:      map[string]string{mainPath: mainSrc},
+		SourceMaps: map[string]*SourceMap{mainPath: g.sourceMap(mainPath, f, mainSrc)},
+	}
 
-	// Compile each top-level module (and nested sub-modules) into packages
-	for _, mod := range p.TopLevelModules {
-		if mod.IsFromGem() {
-			func() {
-				defer func() {
-					if r := recover(); r != nil {
-						parser.Warn(1, "warning: skipping gem module %s compilation: %s\n", mod.Name(), truncateMsg(r))
-					}
-				}()
-				g.compileModulePackages(mod, "", p.ScopeChain, result)
-			}()
-		} else {
-			if err := g.compileModulePackages(mod, "", p.ScopeChain, result); err != nil {
-				return nil, err
-			}
+	for _, pkg := range g.modulePackages {
+		if err := pkg.write(result); err != nil {
+			return nil, err
 		}
 	}
 
 	for _, w := range g.Warnings {
-		parser.Warn(1, "%s\n", w)
+		parser.Report(w)
+	}
+
+	result.Methods = map[*parser.Method]string{}
+	for m, decls := range g.methodDecls {
+		var buf bytes.Buffer
+		for i, d := range decls {
+			if i > 0 {
+				buf.WriteString("\n\n")
+			}
+			format.Node(&buf, g.cs.fset, d)
+		}
+		result.Methods[m] = buf.String()
 	}
 
 	return result, nil
@@ -361,16 +429,26 @@ func moduleHasContent(mod *parser.Module) bool {
 // separate Go packages. parentPath is the filesystem path prefix (e.g., "outer" for
 // a module nested under Outer). Each module with direct content (class methods or
 // classes) gets its own package file.
-func (g *GoProgram) compileModulePackages(mod *parser.Module, parentPath string, scope parser.ScopeChain, result *CompileResult) (retErr error) {
+func (g *GoProgram) compileModulePackages(mod *parser.Module, parentPath string, scope parser.ScopeChain) (retErr error) {
 	if mod.IsFromGem() {
 		defer func() {
 			if r := recover(); r != nil {
 				var buf [4096]byte
 				n := runtime.Stack(buf[:], false)
-				parser.Warn(1, "warning: gem module %s compilation panic: %v\n%s\n", mod.Name(), r, buf[:n])
+				warnGemSkipped(mod, "gem module %s compilation panic: %v\n%s", mod.Name(), r, buf[:n])
 				retErr = nil // don't propagate
 			}
 		}()
+	} else {
+		defer func() {
+			if r := recover(); r != nil {
+				err, ok := r.(*parser.ParseError)
+				if !ok {
+					panic(r)
+				}
+				retErr = err
+			}
+		}()
 	}
 	pkgName := strings.ToLower(mod.Name())
 	dirPath := pkgName
@@ -386,6 +464,8 @@ func (g *GoProgram) compileModulePackages(mod *parser.Module, parentPath string,
 			Imports:      make(map[string]bool),
 			BlockStack:   &parser.Stack[*ast.BlockStmt]{},
 			modulePrefix: mod.QualifiedName(),
+			methodDecls:  g.methodDecls,
+			stmtSources:  g.stmtSources,
 		}
 		modG.pushTracker()
 
@@ -395,7 +475,7 @@ func (g *GoProgram) compileModulePackages(mod *parser.Module, parentPath string,
 				func() {
 					defer func() {
 						if r := recover(); r != nil {
-							parser.Warn(1, "warning: skipping gem class %s compilation: %s\n", cls.Name(), truncateMsg(r))
+							warnGemSkipped(cls, "skipping gem class %s compilation: %s", cls.Name(), truncateMsg(r))
 						}
 					}()
 					decls := modG.CompileClass(cls)
@@ -403,7 +483,7 @@ func (g *GoProgram) compileModulePackages(mod *parser.Module, parentPath string,
 						if validateDecl(d) {
 							modDecls = append(modDecls, d)
 						} else {
-							parser.Warn(1, "warning: skipping gem class %s decl (invalid Go AST)\n", cls.Name())
+							warnGemSkipped(cls, "skipping gem class %s decl (invalid Go AST)", cls.Name())
 						}
 					}
 				}()
@@ -420,7 +500,7 @@ func (g *GoProgram) compileModulePackages(mod *parser.Module, parentPath string,
 				func() {
 					defer func() {
 						if r := recover(); r != nil {
-							parser.Warn(1, "warning: skipping gem method %s.%s (compile panic): %s\n", mod.Name(), m.Name, truncateMsg(r))
+							warnGemSkipped(m, "skipping gem method %s.%s (compile panic): %s", mod.Name(), m.Name, truncateMsg(r))
 						}
 					}()
 					decls := modG.CompileClassMethod(m, nil)
@@ -429,7 +509,7 @@ func (g *GoProgram) compileModulePackages(mod *parser.Module, parentPath string,
 						if validateDecl(d) {
 							modDecls = append(modDecls, d)
 						} else {
-							parser.Warn(1, "warning: skipping gem method %s.%s (invalid Go AST)\n", mod.Name(), m.Name)
+							warnGemSkipped(m, "skipping gem method %s.%s (invalid Go AST)", mod.Name(), m.Name)
 						}
 					}
 				}()
@@ -444,51 +524,15 @@ func (g *GoProgram) compileModulePackages(mod *parser.Module, parentPath string,
 		// Emit synthesized struct declarations belonging to this module
 		modDecls = append(modDecls, modG.compileSynthStructs(mod.QualifiedName())...)
 
+		g.Deferred = append(g.Deferred, modG.Deferred...)
+		g.catchAllRescues = append(g.catchAllRescues, modG.catchAllRescues...)
 		if len(modDecls) > 0 {
-			modFile := &ast.File{
-				Name: ast.NewIdent(pkgName),
-			}
-
-			modTopDecls := []ast.Decl{}
-			modImportSpecs := []ast.Spec{}
-			for imp := range modG.Imports {
-				modImportSpecs = append(modImportSpecs, &ast.ImportSpec{Path: bst.String(imp)})
-			}
-			if len(modImportSpecs) > 0 {
-				modTopDecls = append(modTopDecls, &ast.GenDecl{Tok: token.IMPORT, Specs: modImportSpecs})
-			}
-			for _, spec := range modG.Constants {
-				modTopDecls = append(modTopDecls, &ast.GenDecl{Tok: token.CONST, Specs: []ast.Spec{spec}})
-			}
-			for _, spec := range modG.GlobalVars {
-				modTopDecls = append(modTopDecls, &ast.GenDecl{Tok: token.VAR, Specs: []ast.Spec{spec}})
-			}
-			modFile.Decls = append(modTopDecls, modDecls...)
-
-			var modSrc string
-			var fmtErr error
-			if mod.IsFromGem() {
-				func() {
-					defer func() {
-						if r := recover(); r != nil {
-							fmtErr = fmt.Errorf("format panic: %v", r)
-						}
-					}()
-					modSrc, fmtErr = formatAndImports(modFile, token.NewFileSet())
-				}()
-			} else {
-				modSrc, fmtErr = formatAndImports(modFile, token.NewFileSet())
-			}
-			if fmtErr != nil {
-				if mod.IsFromGem() {
-					parser.Warn(1, "warning: gem module %s format error: %v\n", mod.Name(), fmtErr)
-				} else {
-					return fmt.Errorf("error compiling module %s: %w", mod.Name(), fmtErr)
-				}
-			} else {
-				filePath := dirPath + "/" + pkgName + ".go"
-				result.Files[filePath] = modSrc
-			}
+			g.modulePackages = append(g.modulePackages, &modulePackage{
+				mod:   mod,
+				g:     modG,
+				decls: modDecls,
+				path:  dirPath + "/" + pkgName + ".go",
+			})
 		}
 	}
 
@@ -499,13 +543,13 @@ func (g *GoProgram) compileModulePackages(mod *parser.Module, parentPath string,
 				func() {
 					defer func() {
 						if r := recover(); r != nil {
-							parser.Warn(1, "warning: skipping gem sub-module %s compilation: %s\n", sub.Name(), truncateMsg(r))
+							warnGemSkipped(sub, "skipping gem sub-module %s compilation: %s", sub.Name(), truncateMsg(r))
 						}
 					}()
-					g.compileModulePackages(sub, dirPath, scope, result)
+					g.compileModulePackages(sub, dirPath, scope)
 				}()
 			} else {
-				if err := g.compileModulePackages(sub, dirPath, scope, result); err != nil {
+				if err := g.compileModulePackages(sub, dirPath, scope); err != nil {
 					return err
 				}
 			}
@@ -515,6 +559,107 @@ func (g *GoProgram) compileModulePackages(mod *parser.Module, parentPath string,
 	return nil
 }
 
+// modulePackage is a module compiled into its own package, waiting to be
+// formatted once every package is compiled.
+type modulePackage struct {
+	mod   *parser.Module
+	g     *GoProgram
+	decls []ast.Decl
+	path  string
+}
+
+// write formats the package's file into result.
+func (pkg *modulePackage) write(result *CompileResult) error {
+	modG, mod := pkg.g, pkg.mod
+	modFile := &ast.File{
+		Name: ast.NewIdent(strings.ToLower(mod.Name())),
+	}
+
+	modTopDecls := []ast.Decl{}
+	modImportSpecs := []ast.Spec{}
+	for imp := range modG.Imports {
+		modImportSpecs = append(modImportSpecs, &ast.ImportSpec{Path: bst.String(imp)})
+	}
+	if len(modImportSpecs) > 0 {
+		modTopDecls = append(modTopDecls, &ast.GenDecl{Tok: token.IMPORT, Specs: modImportSpecs})
+	}
+	for _, spec := range modG.Constants {
+		modTopDecls = append(modTopDecls, &ast.GenDecl{Tok: token.CONST, Specs: []ast.Spec{spec}})
+	}
+	for _, spec := range modG.GlobalVars {
+		modTopDecls = append(modTopDecls, &ast.GenDecl{Tok: token.VAR, Specs: []ast.Spec{spec}})
+	}
+	modFile.Decls = append(modTopDecls, pkg.decls...)
+
+	var modSrc string
+	var fmtErr error
+	if mod.IsFromGem() {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					fmtErr = fmt.Errorf("format panic: %v", r)
+				}
+			}()
+			modSrc, fmtErr = formatAndImports(modFile, token.NewFileSet())
+		}()
+	} else {
+		modSrc, fmtErr = formatAndImports(modFile, token.NewFileSet())
+	}
+	if fmtErr != nil {
+		if mod.IsFromGem() {
+			warnGemSkipped(mod, "gem module %s format error: %v", mod.Name(), fmtErr)
+			return nil
+		}
+		return fmt.Errorf("error compiling module %s: %w", mod.Name(), fmtErr)
+	}
+	result.Files[pkg.path] = modSrc
+	result.SourceMaps[pkg.path] = modG.sourceMap(pkg.path, modFile, modSrc)
+	return nil
+}
+
+// catchAllRescue is the `if r := recover(); r != nil` of a rescue that
+// catches everything, and the program, main's or a module package's, that it
+// was compiled in.
+type catchAllRescue struct {
+	recoverIf *ast.IfStmt
+	g         *GoProgram
+}
+
+// deferInMain defers each call a transform in main or a module package asked
+// for at the top of main, once however many times it was asked for. A program
+// that defers stdlib.Exited can raise SystemExit, which Ruby's bare `rescue`
+// doesn't catch, so the rescues that catch everything raise it again.
+func (g *GoProgram) deferInMain() []ast.Stmt {
+	g.AddImports("github.com/redneckbeard/thanos/stdlib")
+	var stmts []ast.Stmt
+	seen := map[string]bool{}
+	for _, call := range g.Deferred {
+		var buf bytes.Buffer
+		format.Node(&buf, token.NewFileSet(), call)
+		if seen[buf.String()] {
+			continue
+		}
+		seen[buf.String()] = true
+		stmts = append(stmts, &ast.DeferStmt{Call: call.(*ast.CallExpr)})
+	}
+	if seen["stdlib.Exited()"] {
+		for _, rescue := range g.catchAllRescues {
+			rescue.g.AddImports("github.com/redneckbeard/thanos/stdlib")
+			r := rescue.recoverIf.Init.(*ast.AssignStmt).Lhs[0]
+			reraise := &ast.IfStmt{
+				Init: bst.Define([]ast.Expr{g.it.Get("_"), g.it.Get("exiting")}, &ast.TypeAssertExpr{
+					X:    r,
+					Type: &ast.StarExpr{X: bst.Dot("stdlib", "SystemExit")},
+				}),
+				Cond: g.it.Get("exiting"),
+				Body: &ast.BlockStmt{List: []ast.Stmt{&ast.ExprStmt{X: bst.Call(nil, "panic", r)}}},
+			}
+			rescue.recoverIf.Body.List = append([]ast.Stmt{reraise}, rescue.recoverIf.Body.List...)
+		}
+	}
+	return stmts
+}
+
 // truncateMsg formats a recovered panic value, truncating long messages.
 func truncateMsg(r interface{}) string {
 	msg := fmt.Sprintf("%v", r)
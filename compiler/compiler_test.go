@@ -76,3 +76,29 @@ func TestCompile(t *testing.T) {
 		}
 	}
 }
+
+func TestTopLevelConstantInModulePackage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.rb")
+	os.WriteFile(path, []byte(`LIMIT = 3
+
+module Geometry
+  def self.cap(n)
+    [n, ::LIMIT].min
+  end
+end
+
+puts Geometry.cap(5)
+`), 0644)
+	program, err := parser.ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Compile(program)
+	parseErr, ok := err.(*parser.ParseError)
+	if !ok {
+		t.Fatalf("expected a ParseError, got %v", err)
+	}
+	if parseErr.Code() != "unsupported-construct" {
+		t.Errorf("expected code unsupported-construct, got %s", parseErr.Code())
+	}
+}
@@ -93,6 +93,9 @@ func (g *GoProgram) CompileExpr(node parser.Node) ast.Expr {
 				return result
 			}
 		}
+		if expr, ok := g.compileParallelMap(n, false); ok {
+			return expr
+		}
 		if n.RequiresTransform() {
 			// Special case: Hash.new — need to use the variable's refined type
 			if n.MethodName == "new" {
@@ -100,6 +103,9 @@ func (g *GoProgram) CompileExpr(node parser.Node) ast.Expr {
 					if h, isHash := n.Type().(types.Hash); isHash && h.HasDefault {
 						return g.CompileHashNew(n, h)
 					}
+					if q, isQueue := n.Type().(types.Queue); isQueue {
+						return g.CompileQueueNew(q)
+					}
 				}
 			}
 			transform := g.TransformMethodCall(n)
@@ -159,17 +165,25 @@ func (g *GoProgram) CompileExpr(node parser.Node) ast.Expr {
 			return g.CompileExpr(n.MethodCall)
 		}
 		ident := g.it.Get(n.Val)
-		// Dereference nil-default params that were refined to concrete types.
+		// Dereference nil-accepting params that were refined to concrete types.
 		// The Go param is *T but after ||= the analysis refined the local to T.
 		if !g.suppressDeref && g.currentMethod != nil {
 			for _, p := range g.currentMethod.Params {
-				if p.Name == n.Val && p.HasNilDefault() {
+				if p.Name == n.Val && p.AcceptsNil() {
 					if _, isOpt := n.Type().(types.Optional); !isOpt {
 						return &ast.StarExpr{X: ident}
 					}
 				}
 			}
 		}
+		// Where analysis knew which member a union variable holds (before it
+		// was widened, or inside a `when` clause), unwrap the value.
+		if u := g.unionLocal(n.Val); u != nil && u.Has(n.Type()) {
+			if v, ok := g.unionCases[n.Val]; ok {
+				return bst.Dot(v, "Val")
+			}
+			return u.Unwrap(ident, n.Type(), g.it)
+		}
 		return ident
 	case *parser.IVarNode:
 		ivar := n.NormalizedVal()
@@ -183,6 +197,10 @@ func (g *GoProgram) CompileExpr(node parser.Node) ast.Expr {
 	case *parser.CVarNode:
 		return g.it.Get(g.cvarGoName(n))
 	case *parser.GVarNode:
+		if predefined, ok := types.PredefinedGlobals[n.NormalizedVal()]; ok {
+			g.AddImports(predefined.Imports...)
+			return predefined.Expr
+		}
 		return g.it.Get(n.NormalizedVal())
 	case *parser.NilNode:
 		return g.it.Get("nil")
@@ -338,6 +356,25 @@ func (g *GoProgram) CompileExpr(node parser.Node) ast.Expr {
 			return arr
 		}
 	case *parser.HashNode:
+		// SynthStruct: compile as &StructName{Name: v0, Port: v1, ...}
+		if ss, ok := n.Type().(*types.SynthStruct); ok {
+			elts := []ast.Expr{}
+			for _, pair := range n.Pairs {
+				key := pair.Label
+				if key == "" {
+					key = pair.Key.(*parser.SymbolNode).Val[1:]
+				}
+				f, _ := ss.FieldByKey(key)
+				elts = append(elts, &ast.KeyValueExpr{
+					Key:   g.it.Get(f.Name),
+					Value: g.CompileExpr(pair.Value),
+				})
+			}
+			return &ast.UnaryExpr{Op: token.AND, X: &ast.CompositeLit{
+				Type: g.it.Get(ss.Name),
+				Elts: elts,
+			}}
+		}
 		hashType := n.Type().(types.Hash)
 		// Check if the target variable is order-safe (can use native map)
 		if g.hashLhsIsOrderSafe() {
@@ -449,8 +486,19 @@ func (g *GoProgram) CompileExpr(node parser.Node) ast.Expr {
 			g.AddImports(predefined.Imports...)
 			return predefined.Expr
 		}
+		if n.Resolved != nil {
+			return g.it.Get(g.constantName(n.Resolved))
+		}
+		if _, isClass := n.Type().(*types.Class); n.TopLevel && !isClass && g.modulePrefix != "" {
+			// Constants defined in main can't be imported into a module's
+			// package, so compileModulePackages returns this as its error.
+			panic(parser.NewParseError(n, "Top-level constant '%s' is not visible from module package %s", n, strings.ToLower(g.modulePrefix)))
+		}
 		return g.it.Get(g.localName(n.Namespace + n.Val))
 	case *parser.ScopeAccessNode:
+		if n.Resolved != nil {
+			return g.it.Get(g.constantName(n.Resolved))
+		}
 		return g.it.Get(n.ReceiverName() + n.Constant)
 	case *parser.NotExpressionNode:
 		if arg, ok := n.Arg.(*parser.InfixExpressionNode); ok && arg.Operator == "==" {
@@ -480,6 +528,8 @@ func (g *GoProgram) CompileExpr(node parser.Node) ast.Expr {
 		return g.CompileSuperNode(n)
 	case *parser.SplatNode:
 		return g.CompileExpr(n.Arg)
+	case *parser.RescueModNode:
+		return g.CompileRescueModExpr(n)
 	case *parser.LambdaNode:
 		funcType := &ast.FuncType{
 			Params: &ast.FieldList{
@@ -628,7 +678,7 @@ func (g *GoProgram) TransformInfixExpressionNode(node *parser.InfixExpressionNod
 	if node.Operator == "||" {
 		if ba, ok := node.Left.(*parser.BracketAccessNode); ok {
 			if h, isHash := ba.Composite.Type().(types.Hash); isHash && !h.HasDefault {
-				g.Warn(node.LineNo(), "h[key] || default compiles to ok-check pattern. Consider using h.fetch(key, default) for cleaner output.")
+				g.Warn(node, "hash-or-default", "h[key] || default compiles to ok-check pattern. Consider using h.fetch(key, default) for cleaner output.")
 				rcvr := g.CompileExpr(ba.Composite)
 				key := g.CompileExpr(ba.Args[0])
 				def := g.CompileExpr(node.Right)
@@ -736,7 +786,7 @@ func (g *GoProgram) CompileStringNode(node *parser.StringNode) ast.Expr {
 			Kind:  token.STRING,
 			Value: node.GoString(),
 		}
-		if node.Kind == parser.SingleQuote || node.Kind == parser.DoubleQuote {
+		if node.Kind == parser.SingleQuote || node.Kind == parser.DoubleQuote || node.Kind == parser.DynamicSymbol {
 			return str
 		}
 		switch node.Kind {
@@ -745,22 +795,17 @@ func (g *GoProgram) CompileStringNode(node *parser.StringNode) ast.Expr {
 			patt := globalIdents.New("patt")
 			g.addGlobalVar(patt, nil, bst.Call("regexp", "MustCompile", str))
 			return patt
-		case parser.RawWords:
+		case parser.RawWords, parser.RawSymbols:
 			g.AddImports("strings")
 			return bst.Call("strings", "Fields", str)
-		case parser.Words:
+		case parser.Words, parser.Symbols:
 			return &ast.CompositeLit{
 				Type: &ast.ArrayType{Elt: g.it.Get("string")},
 				Elts: g.stringElements(node),
 			}
 		case parser.Exec, parser.RawExec:
-			g.AddImports("os/exec")
-			outputVariable := g.it.New("output")
-			g.appendToCurrentBlock(bst.Define(
-				[]ast.Expr{outputVariable, g.it.Get("_")},
-				bst.Call(bst.Call("exec", "Command", g.stringElements(node)...), "Output"),
-			))
-			return bst.Call(nil, "string", outputVariable)
+			g.AddImports("github.com/redneckbeard/thanos/stdlib")
+			return bst.Call("stdlib", "Backtick", g.stringElements(node)...)
 		default:
 			return str
 		}
@@ -771,10 +816,20 @@ func (g *GoProgram) CompileStringNode(node *parser.StringNode) ast.Expr {
 	// visible when we pick format verbs.
 	delim := `"`
 	switch node.Kind {
-	case parser.Regexp, parser.SingleQuote, parser.RawWords, parser.RawExec:
+	case parser.Regexp, parser.SingleQuote, parser.RawWords, parser.RawExec, parser.RawSymbols:
 		delim = "`"
 	}
 
+	// Interpolated word lists never become a single format string: each word
+	// is compiled on its own.
+	switch node.Kind {
+	case parser.Words, parser.Symbols:
+		return &ast.CompositeLit{
+			Type: &ast.ArrayType{Elt: g.it.Get("string")},
+			Elts: g.stringElements(node),
+		}
+	}
+
 	d := deferredSprintf{delim: delim}
 	var args []ast.Expr
 
@@ -833,19 +888,9 @@ func (g *GoProgram) CompileStringNode(node *parser.StringNode) ast.Expr {
 			bst.Call("regexp", "Compile", formatted),
 		))
 		return patt
-	case parser.Words:
-		return &ast.CompositeLit{
-			Type: &ast.ArrayType{Elt: g.it.Get("string")},
-			Elts: g.stringElements(node),
-		}
 	case parser.Exec, parser.RawExec:
-		g.AddImports("os/exec")
-		outputVariable := g.it.New("output")
-		g.appendToCurrentBlock(bst.Define(
-			[]ast.Expr{outputVariable, g.it.Get("_")},
-			bst.Call(bst.Call("exec", "Command", g.stringElements(node)...), "Output"),
-		))
-		return bst.Call(nil, "string", outputVariable)
+		g.AddImports("github.com/redneckbeard/thanos/stdlib")
+		return bst.Call("stdlib", "Backtick", g.stringElements(node)...)
 	default:
 		return formatted
 	}
@@ -862,6 +907,12 @@ func (g *GoProgram) compileInterpExpr(interp parser.Node) (ast.Expr, string) {
 		g.AddImports("github.com/redneckbeard/thanos/stdlib")
 		return bst.Call("stdlib", "FormatFloat", compiled), "%s"
 	}
+	// "#{nil}" interpolates nothing, so a string that may be nil is as good as
+	// an empty one here.
+	if opt, ok := t.(types.Optional); ok && opt.Element == types.StringType {
+		g.AddImports("github.com/redneckbeard/thanos/stdlib")
+		return bst.Call("stdlib", "OrDefault", compiled, bst.String("")), "%s"
+	}
 	verb := types.FprintVerb(t)
 	if verb == "" {
 		panic(fmt.Sprintf("Unhandled type inference failure for interpolated value in string"))
@@ -935,29 +986,12 @@ func verbFromGoType(goType string) string {
 
 func (g *GoProgram) stringElements(node *parser.StringNode) []ast.Expr {
 	// Ruby interpolated words apply the splitting on whitespace _before_
-	// interpolation. There's no sensible way to achieve this in Go, so we
-	// leave the nonsense in the compiler and have output be a string slice
-	// literal.
+	// interpolation, so the parser hands us one string node per word and each
+	// becomes either a plain literal or its own Sprintf call.
 	var elements []ast.Expr
-
-	for i, seg := range node.BodySegments {
-		if interps, exists := node.Interps[i]; exists {
-			for _, interp := range interps {
-				compiled, fallback := g.compileInterpExpr(interp)
-				elements = append(elements, bst.Call("fmt", "Sprintf", bst.String(fallback), compiled))
-			}
-		}
-		for _, s := range strings.Fields(seg) {
-			elements = append(elements, bst.String(s))
-		}
-	}
-	if trailingInterps, exists := node.Interps[len(node.BodySegments)]; exists {
-		for _, trailingInterp := range trailingInterps {
-			compiled, fallback := g.compileInterpExpr(trailingInterp)
-			elements = append(elements, bst.Call("fmt", "Sprintf", bst.String(fallback), compiled))
-		}
+	for _, word := range node.Words() {
+		elements = append(elements, g.CompileStringNode(word))
 	}
-
 	return elements
 }
 
@@ -1012,24 +1046,14 @@ func (g *GoProgram) CompileArgs(call *parser.MethodCall, args parser.ArgsNode) [
 		p, _ := call.Method.GetParam(i)
 		switch p.Kind {
 		case parser.Positional:
-			argExprs = append(argExprs, types.TypeExpr{p.Type(), g.CompileArg(args[i])})
+			argExprs = append(argExprs, types.TypeExpr{p.Type(), g.compileOptionalArg(p, args[i])})
 		case parser.Named:
 			if i >= len(args) {
 				argExprs = append(argExprs, types.TypeExpr{p.Type(), g.CompileArg(p.Default)})
 			} else if _, ok := args[i].(*parser.KeyValuePair); ok {
 				argExprs = append(argExprs, types.TypeExpr{p.Type(), g.CompileArg(p.Default)})
 			} else {
-				compiled := g.CompileArg(args[i])
-				if optParam, isOpt := p.Type().(types.Optional); isOpt {
-					if _, isNil := args[i].(*parser.NilNode); !isNil {
-						// Don't wrap if arg type already matches the param's Optional type
-						argType := args[i].Type()
-						if argType == nil || !argType.Equals(optParam) {
-							compiled = g.wrapPtr(compiled, optParam.Element)
-						}
-					}
-				}
-				argExprs = append(argExprs, types.TypeExpr{p.Type(), compiled})
+				argExprs = append(argExprs, types.TypeExpr{p.Type(), g.compileOptionalArg(p, args[i])})
 			}
 		case parser.Keyword:
 
@@ -1183,8 +1207,25 @@ func (g *GoProgram) CompileArg(node parser.Node) ast.Expr {
 	return g.CompileExpr(node)
 }
 
+// compileOptionalArg compiles a call-site argument, wrapping concrete values
+// in stdlib.Ptr[T]() when the parameter is Optional(T).
+func (g *GoProgram) compileOptionalArg(p *parser.Param, arg parser.Node) ast.Expr {
+	compiled := g.CompileArg(arg)
+	if optParam, isOpt := p.Type().(types.Optional); isOpt {
+		if _, isNil := arg.(*parser.NilNode); !isNil {
+			// Don't wrap if arg type already matches the param's Optional type
+			argType := arg.Type()
+			if argType == nil || !argType.Equals(optParam) {
+				compiled = g.wrapPtr(compiled, optParam.Element)
+			}
+		}
+	}
+	return compiled
+}
+
 // wrapPtr wraps an expression in stdlib.Ptr[T](...) to convert a value to a pointer.
 func (g *GoProgram) wrapPtr(expr ast.Expr, elemType types.Type) ast.Expr {
+	g.AddImports("github.com/redneckbeard/thanos/stdlib")
 	return &ast.CallExpr{
 		Fun:  g.it.Get(fmt.Sprintf("stdlib.Ptr[%s]", elemType.GoType())),
 		Args: []ast.Expr{expr},
@@ -1225,6 +1266,25 @@ func (g *GoProgram) negativeIndex(rcvr ast.Expr, node parser.Node, idx ast.Expr)
 	return idx
 }
 
+// CompileQueueNew generates Go code for Queue.new. The queue's element type
+// comes from what is pushed onto it later, so like Hash.new it is taken from
+// the variable the queue is assigned to.
+func (g *GoProgram) CompileQueueNew(q types.Queue) ast.Expr {
+	if g.CurrentLhs != nil {
+		if ident, ok := g.CurrentLhs[0].(*parser.IdentNode); ok {
+			if local := g.ScopeChain.ResolveVar(ident.Val); local != nil {
+				if refined, ok := local.Type().(types.Queue); ok {
+					q = refined
+				}
+			}
+		}
+	}
+	g.AddImports("github.com/redneckbeard/thanos/stdlib")
+	return &ast.CallExpr{
+		Fun: g.it.Get(fmt.Sprintf("stdlib.NewQueue[%s]", q.Element.GoType())),
+	}
+}
+
 // CompileHashNew generates Go code for Hash.new(default) and Hash.new { |h, k| ... }
 func (g *GoProgram) CompileHashNew(n *parser.MethodCall, h types.Hash) ast.Expr {
 	// Get the refined type from the LHS variable if available
@@ -104,6 +104,7 @@ func (g *GoProgram) CompileFunc(m *parser.Method, c *parser.Class) []ast.Decl {
 	}
 
 	decls = append(decls, decl)
+	g.methodDecls[m] = decls
 
 	return decls
 }
@@ -205,6 +206,7 @@ func (g *GoProgram) CompileClassMethod(m *parser.Method, c *parser.Class, prefix
 	}
 
 	decls = append(decls, decl)
+	g.methodDecls[m] = decls
 	return decls
 }
 
@@ -0,0 +1,107 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/redneckbeard/thanos/parser"
+)
+
+// GoTestFile is the name of the file GoTest's source is written to, beside
+// the compiled program's main.go.
+const GoTestFile = "main_test.go"
+
+var goTestTemplate = template.Must(template.New("gotest").Parse(`package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+// gauntletOutput is what Ruby printed for the gauntlet test {{ printf "%q" .Name }}.
+const gauntletOutput = {{ .Expected }}
+
+// TestMain runs the program instead of the tests when the test binary is run
+// again by {{ .Func }}, so that an exit or an exception nobody rescued ends
+// that run rather than go test.
+func TestMain(m *testing.M) {
+	if os.Getenv("THANOS_GAUNTLET_MAIN") != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func {{ .Func }}(t *testing.T) {
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), "THANOS_GAUNTLET_MAIN=1")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	if got := stdout.String(); got != gauntletOutput {
+		t.Errorf("output differs from Ruby's.\n--- ruby\n%s\n--- go\n%s", gauntletOutput, got)
+	}
+	if err != nil {
+		t.Errorf("program failed: %v\n%s", err, stderr.String())
+	}
+}
+`))
+
+// GoTest returns the source of a Go test for the gauntlet test called name,
+// to sit in package main beside its compiled program. The test runs main in a
+// process of its own and checks that it prints exactly expected, the output
+// of the Ruby, and exits successfully, as the gauntlet runner does.
+func GoTest(name, expected string) (string, error) {
+	literal := strconv.Quote(expected)
+	if !strings.Contains(expected, "`") && !strings.Contains(expected, "\r") {
+		literal = "`" + expected + "`"
+	}
+	var buf bytes.Buffer
+	err := goTestTemplate.Execute(&buf, struct{ Name, Func, Expected string }{name, GoTestName(name), literal})
+	if err != nil {
+		return "", err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("formatting test for '%s': %w", name, err)
+	}
+	return string(src), nil
+}
+
+// GoTestName turns a gauntlet test name into the name of a Go test function:
+// "array each_with_index" becomes TestArrayEachWithIndex.
+func GoTestName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	b.WriteString("Test")
+	for _, w := range words {
+		runes := []rune(w)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
+
+// goMain returns the name of the function a program's top-level statements
+// compile into and the file it goes in. That's main in main.go, unless the
+// program is a test suite: test/calc_test.rb becomes TestCalc in
+// calc_test.go and spec/calc_spec.rb TestCalcSpec in calc_spec_test.go, with
+// their describe and it blocks as subtests.
+func goMain(p *parser.Root) (name, path string) {
+	if p.TestSuite == "" {
+		return "main", "main.go"
+	}
+	base := strings.TrimSuffix(filepath.Base(p.TestSuite), filepath.Ext(p.TestSuite))
+	base = strings.TrimSuffix(base, "_test")
+	return GoTestName(base), base + "_test.go"
+}
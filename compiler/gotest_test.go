@@ -0,0 +1,124 @@
+package compiler
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rubyparser "github.com/redneckbeard/thanos/parser"
+)
+
+func TestGoTestName(t *testing.T) {
+	tests := map[string]string{
+		"drop":                          "TestDrop",
+		"Array#first with no arguments": "TestArrayFirstWithNoArguments",
+		"arr[x..]":                      "TestArrX",
+		"each_with_index 2":             "TestEachWithIndex2",
+	}
+	for name, want := range tests {
+		if got := GoTestName(name); got != want {
+			t.Errorf("GoTestName(%q) = %s, expected %s", name, got, want)
+		}
+	}
+}
+
+func TestGoTest(t *testing.T) {
+	tests := []struct {
+		expected, literal string
+	}{
+		{"1\n2\n", "`1\n2\n`"},
+		{"`tick`\n", "\"`tick`\\n\""},
+	}
+	for _, tt := range tests {
+		src, err := GoTest("values_at", tt.expected)
+		if err != nil {
+			t.Fatalf("GoTest failed: %v", err)
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), GoTestFile, src, 0); err != nil {
+			t.Fatalf("GoTest produced invalid Go: %v\n%s", err, src)
+		}
+		if !strings.Contains(src, "func TestValuesAt(t *testing.T)") {
+			t.Errorf("expected a TestValuesAt function, got:\n%s", src)
+		}
+		if !strings.Contains(src, "func TestMain(m *testing.M)") || !strings.Contains(src, "exec.Command(os.Args[0])") {
+			t.Errorf("expected the test to run main in a process of its own, got:\n%s", src)
+		}
+		if !strings.Contains(src, "const gauntletOutput = "+tt.literal+"\n") {
+			t.Errorf("expected the output %q as %s, got:\n%s", tt.expected, tt.literal, src)
+		}
+	}
+}
+
+func TestCompileTestSuite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stack_spec.rb")
+	os.WriteFile(path, []byte(`class Stack
+  def initialize
+    @items = []
+  end
+
+  def push(x)
+    @items << x
+    self
+  end
+
+  def size
+    @items.size
+  end
+
+  def pop
+    raise IndexError, "empty" if @items.empty?
+    @items.pop
+  end
+end
+
+describe Stack do
+  let(:stack) { Stack.new }
+
+  it "pushes" do
+    stack.push(1)
+    expect(stack.size).to eq(1)
+  end
+
+  context "when empty" do
+    it "raises on pop" do
+      expect { stack.pop }.to raise_error(IndexError)
+    end
+  end
+end
+`), 0644)
+	program, err := rubyparser.ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := Compile(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, ok := result.Files["stack_spec_test.go"]
+	if !ok {
+		t.Fatalf("expected stack_spec_test.go, got %v", result.Files)
+	}
+	for _, want := range []string{
+		"func TestStackSpec(t *testing.T) {",
+		`t.Run("Stack", func(t *testing.T) {`,
+		`t.Run("when empty", func(t *testing.T) {`,
+		"stack := NewStack()",
+		"if got := stack.Size(); !(1 == got) {",
+		`t.Errorf("Expected: %v\n  Actual: %v", 1, got)`,
+		"case *stdlib.IndexError:",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("expected the test to contain %s, got:\n%s", want, src)
+		}
+	}
+	if n := strings.Count(src, "stack.Size()"); n != 1 {
+		t.Errorf("expected the asserted expression to be evaluated once, got %d calls:\n%s", n, src)
+	}
+	if strings.Contains(src, "func main()") {
+		t.Errorf("expected no main function in a test suite, got:\n%s", src)
+	}
+}
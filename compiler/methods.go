@@ -107,18 +107,29 @@ func (g *GoProgram) BuildBlock(blk *parser.Block) *types.Block {
 	}
 	g.newBlockStmt()
 	g.State.Push(InBlockBody)
+	// Locals declared in the block, like an empty array it fills, are typed
+	// in the block's scope.
+	scope := g.ScopeChain
+	if blk.Scope != nil {
+		g.ScopeChain = blk.Scope
+	}
 	defer func() {
 		g.BlockStack.Pop()
 		g.State.Pop()
+		g.ScopeChain = scope
 	}()
+	hoistBlockLocals(blk, g)
+	redo := &jumpTarget{name: "redo", label: true}
+	g.redoTargets = append(g.redoTargets, redo)
 	for _, s := range blk.Body.Statements {
 		g.CompileStmt(s)
 	}
+	g.redoTargets = g.redoTargets[:len(g.redoTargets)-1]
 	g.popTracker()
 	return &types.Block{
 		ReturnType: blk.Body.ReturnType,
 		Args:       args,
 		ArgTypes:   argTypes,
-		Statements: g.BlockStack.Peek().List,
+		Statements: redo.labelRedo(g.BlockStack.Peek().List),
 	}
 }
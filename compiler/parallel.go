@@ -0,0 +1,116 @@
+package compiler
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/redneckbeard/thanos/bst"
+	"github.com/redneckbeard/thanos/parser"
+	"github.com/redneckbeard/thanos/types"
+)
+
+// compileParallelMap compiles
+//
+//	items.map { |i| Thread.new { work(i) } }.map(&:value)
+//
+// to a call to stdlib.ParallelMap, which maps the thread's block over items
+// on a bounded number of goroutines rather than one per item, keeping the
+// results in order. What the block raises comes back as an error, which is
+// raised again here, as Thread#value would. If discard is set, the results
+// aren't kept. It reports false if n isn't the outer map of that pattern.
+func (g *GoProgram) compileParallelMap(n *parser.MethodCall, discard bool) (ast.Expr, bool) {
+	threads, thread, ok := parallelMapParts(n)
+	if !ok {
+		return nil, false
+	}
+	arr := threads.Receiver.Type().(types.Array)
+	result := n.Type().(types.Array).Element
+
+	items := g.CompileExpr(threads.Receiver)
+	blk := g.BuildBlock(&parser.Block{
+		Body:      thread.Block.Body,
+		Scope:     thread.Block.Scope,
+		ParamList: threads.Block.ParamList,
+	})
+	if len(blk.Args) == 0 {
+		blk.Args = []ast.Expr{g.it.Get("_")}
+	}
+	blk.ArgTypes = []types.Type{arr.Element}
+	blk.ReturnType = result
+
+	mapped, err := g.it.New("mapped"), g.it.New("err")
+	if discard {
+		mapped = g.it.Get("_")
+	}
+	g.AddImports("github.com/redneckbeard/thanos/stdlib")
+	g.appendToCurrentBlock(
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{mapped, err},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{bst.Call("stdlib", "ParallelMap", items, blk.FuncLit(g.it))},
+		},
+		&ast.IfStmt{
+			Cond: bst.Binary(err, token.NEQ, g.it.Get("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ExprStmt{X: bst.Call(nil, "panic", err)},
+			}},
+		},
+	)
+	return mapped, true
+}
+
+// parallelMapParts picks apart the map-then-value pattern, returning the map
+// that starts a thread per element and the Thread.new call in its block.
+func parallelMapParts(n *parser.MethodCall) (threads, thread *parser.MethodCall, ok bool) {
+	if n.MethodName != "map" || len(n.Args) > 0 || !callsOnParam(n.Block, "value") {
+		return nil, nil, false
+	}
+	if arr, isArr := n.Type().(types.Array); !isArr || arr.Element == types.NilType {
+		return nil, nil, false
+	}
+	threads, ok = n.Receiver.(*parser.MethodCall)
+	if !ok || threads.MethodName != "map" || threads.Receiver == nil || len(threads.Args) > 0 || threads.Block == nil {
+		return nil, nil, false
+	}
+	if _, isArr := threads.Receiver.Type().(types.Array); !isArr {
+		return nil, nil, false
+	}
+	params := threads.Block.Params
+	if len(params) > 1 || (len(params) == 1 && params[0].Kind != parser.Positional) {
+		return nil, nil, false
+	}
+	thread, ok = blockValue(threads.Block).(*parser.MethodCall)
+	if !ok || thread.MethodName != "new" || thread.Block == nil || len(thread.Args) > 0 || thread.Receiver == nil {
+		return nil, nil, false
+	}
+	if thread.Receiver.Type() != types.ThreadClass || len(thread.Block.Params) > 0 {
+		return nil, nil, false
+	}
+	return threads, thread, true
+}
+
+// callsOnParam reports whether blk is { |x| x.method } with no arguments,
+// which is also what &:method becomes.
+func callsOnParam(blk *parser.Block, method string) bool {
+	if blk == nil || len(blk.Params) != 1 {
+		return false
+	}
+	call, ok := blockValue(blk).(*parser.MethodCall)
+	if !ok || call.MethodName != method || len(call.Args) > 0 || call.Block != nil {
+		return false
+	}
+	ident, ok := call.Receiver.(*parser.IdentNode)
+	return ok && ident.Val == blk.Params[0].Name
+}
+
+// blockValue returns the expression a block consisting of just that
+// expression returns, or nil for a longer block.
+func blockValue(blk *parser.Block) parser.Node {
+	if len(blk.Body.Statements) != 1 {
+		return nil
+	}
+	if ret, ok := blk.Body.Statements[0].(*parser.ReturnNode); ok && len(ret.Val) == 1 {
+		return ret.Val[0]
+	}
+	return blk.Body.Statements[0]
+}
@@ -0,0 +1,255 @@
+package compiler
+
+import (
+	"encoding/json"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redneckbeard/thanos/parser"
+)
+
+// A SourceMap maps lines of a generated Go file back to the Ruby that
+// produced them. It is written next to the Go file as <file>.map.json.
+type SourceMap struct {
+	Version  int       `json:"version"`
+	File     string    `json:"file"` // the Go file, relative to the output directory
+	Mappings []Mapping `json:"mappings"`
+}
+
+// A Mapping attributes the Go lines GoStart through GoEnd to a line of Ruby.
+// Mappings in a SourceMap are sorted and don't overlap.
+type Mapping struct {
+	GoStart int    `json:"goStart"`
+	GoEnd   int    `json:"goEnd"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+}
+
+// SourceMapSuffix is appended to a Go file's path to name its source map.
+const SourceMapSuffix = ".map.json"
+
+type rubySource struct {
+	file string
+	line int
+}
+
+// recordSource notes the Ruby node each of stmts was compiled from, unless a
+// more specific node has already been recorded for it.
+func (g *GoProgram) recordSource(node parser.Node, stmts ...ast.Stmt) {
+	line := sourceLine(node)
+	if line == 0 {
+		return
+	}
+	for _, stmt := range stmts {
+		if _, ok := g.stmtSources[stmt]; !ok {
+			g.stmtSources[stmt] = rubySource{node.File(), line}
+		}
+	}
+}
+
+// sourceLine is the line a Ruby statement starts on. Conditionals, case
+// expressions and assignments spanning several lines record the line they end
+// on, so theirs comes from the expression they test or assign to.
+func sourceLine(node parser.Node) int {
+	var head parser.Node
+	switch n := node.(type) {
+	case *parser.Condition:
+		head = n.Condition
+	case *parser.CaseNode:
+		head = n.Value
+	case *parser.AssignmentNode:
+		if len(n.Left) > 0 {
+			head = n.Left[0]
+		}
+	}
+	if head != nil && head.LineNo() > 0 && head.LineNo() < node.LineNo() {
+		return head.LineNo()
+	}
+	return node.LineNo()
+}
+
+// sourceMap builds the source map for f, whose formatted source is src.
+// Positions in f are synthetic, so src is parsed again and its declarations
+// walked alongside those of f to learn where each statement ended up.
+func (g *GoProgram) sourceMap(path string, f *ast.File, src string) *SourceMap {
+	sm := &SourceMap{Version: 1, File: path}
+	fset := token.NewFileSet()
+	formatted, err := goparser.ParseFile(fset, path, src, 0)
+	if err != nil {
+		return sm
+	}
+	methods := map[ast.Decl]*parser.Method{}
+	for m, decls := range g.methodDecls {
+		for _, d := range decls {
+			methods[d] = m
+		}
+	}
+
+	// Each line belongs to the innermost statement spanning it, so outer
+	// nodes are assigned first and inner ones overwrite them.
+	lines := make([]rubySource, fset.File(formatted.Pos()).LineCount()+2)
+	assign := func(n ast.Node, source rubySource) {
+		start, end := fset.Position(n.Pos()).Line, fset.Position(n.End()).Line
+		for l := start; l <= end && l < len(lines); l++ {
+			lines[l] = source
+		}
+	}
+	ours, theirs := nonImportDecls(f.Decls), nonImportDecls(formatted.Decls)
+	if len(ours) != len(theirs) {
+		return sm
+	}
+	for i, decl := range ours {
+		if m, ok := methods[decl]; ok && m.LineNo() > 0 {
+			assign(theirs[i], rubySource{m.File(), m.LineNo()})
+		}
+		ourStmts, theirStmts := statements(decl), statements(theirs[i])
+		if len(ourStmts) != len(theirStmts) {
+			continue
+		}
+		for j, stmt := range ourStmts {
+			if source, ok := g.stmtSources[stmt]; ok {
+				assign(theirStmts[j], source)
+			}
+		}
+	}
+
+	for l := 1; l < len(lines); l++ {
+		source := lines[l]
+		if source.line == 0 {
+			continue
+		}
+		if n := len(sm.Mappings); n > 0 {
+			if last := &sm.Mappings[n-1]; last.GoEnd == l-1 && last.File == source.file && last.Line == source.line {
+				last.GoEnd = l
+				continue
+			}
+		}
+		sm.Mappings = append(sm.Mappings, Mapping{GoStart: l, GoEnd: l, File: source.file, Line: source.line})
+	}
+	return sm
+}
+
+func nonImportDecls(decls []ast.Decl) []ast.Decl {
+	var filtered []ast.Decl
+	for _, d := range decls {
+		if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			continue
+		}
+		filtered = append(filtered, d)
+	}
+	return filtered
+}
+
+// statements returns the statements in n in depth-first order.
+func statements(n ast.Node) []ast.Stmt {
+	var stmts []ast.Stmt
+	ast.Inspect(n, func(n ast.Node) bool {
+		if stmt, ok := n.(ast.Stmt); ok {
+			stmts = append(stmts, stmt)
+		}
+		return true
+	})
+	return stmts
+}
+
+// Lookup returns the mapping covering the given Go line.
+func (sm *SourceMap) Lookup(line int) (Mapping, bool) {
+	i := sort.Search(len(sm.Mappings), func(i int) bool { return sm.Mappings[i].GoEnd >= line })
+	if i < len(sm.Mappings) && sm.Mappings[i].GoStart <= line {
+		return sm.Mappings[i], true
+	}
+	return Mapping{}, false
+}
+
+// Marshal encodes the source map for writing to dir, making Ruby paths
+// relative to it where possible so the output can be moved along with its
+// sources.
+func (sm *SourceMap) Marshal(dir string) ([]byte, error) {
+	out := *sm
+	out.Mappings = make([]Mapping, len(sm.Mappings))
+	for i, m := range sm.Mappings {
+		if filepath.IsAbs(m.File) && filepath.IsAbs(dir) {
+			if rel, err := filepath.Rel(dir, m.File); err == nil {
+				m.File = filepath.ToSlash(rel)
+			}
+		}
+		out.Mappings[i] = m
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// A Tracer rewrites locations in generated Go to the Ruby they came from.
+type Tracer struct {
+	maps map[string]*SourceMap // keyed by the Go file's slash-separated path
+}
+
+// LoadSourceMaps reads every source map under dir. Relative Ruby paths are
+// resolved against the directory of the map that names them.
+func LoadSourceMaps(dir string) (*Tracer, error) {
+	t := &Tracer{maps: map[string]*SourceMap{}}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go"+SourceMapSuffix) {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var sm SourceMap
+		if err := json.Unmarshal(b, &sm); err != nil {
+			return err
+		}
+		for i, m := range sm.Mappings {
+			if m.File != "" && !filepath.IsAbs(m.File) {
+				sm.Mappings[i].File = filepath.Join(filepath.Dir(path), filepath.FromSlash(m.File))
+			}
+		}
+		rel, _ := filepath.Rel(dir, strings.TrimSuffix(path, SourceMapSuffix))
+		t.maps[filepath.ToSlash(rel)] = &sm
+		return nil
+	})
+	return t, err
+}
+
+var goLocation = regexp.MustCompile(`([^\s:"'()]+\.go):(\d+)(:\d+)?`)
+
+// Rewrite replaces each file.go:line[:column] in text that a source map
+// covers with the Ruby file and line, as found in panic stack traces, compiler
+// errors and go vet output. Ruby paths are shown relative to the working
+// directory when they are beneath it.
+func (t *Tracer) Rewrite(text string) string {
+	return goLocation.ReplaceAllStringFunc(text, func(loc string) string {
+		parts := goLocation.FindStringSubmatch(loc)
+		sm := t.mapFor(parts[1])
+		if sm == nil {
+			return loc
+		}
+		line, _ := strconv.Atoi(parts[2])
+		m, ok := sm.Lookup(line)
+		if !ok || m.File == "" {
+			return loc
+		}
+		return parser.DisplayPath(m.File) + ":" + strconv.Itoa(m.Line)
+	})
+}
+
+// mapFor finds the source map for a Go path as it appears in output, which
+// may be absolute or relative to wherever the go command ran: the map whose
+// file is the longest suffix of the path wins.
+func (t *Tracer) mapFor(path string) *SourceMap {
+	path = filepath.ToSlash(path)
+	var best string
+	for file := range t.maps {
+		if (path == file || strings.HasSuffix(path, "/"+file)) && len(file) > len(best) {
+			best = file
+		}
+	}
+	return t.maps[best]
+}
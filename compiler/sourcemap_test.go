@@ -0,0 +1,82 @@
+package compiler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/redneckbeard/thanos/parser"
+)
+
+func TestSourceMap(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.rb")
+	os.WriteFile(path, []byte(`def halve(n)
+  puts "halving"
+  n / 2
+end
+
+x = 10
+if x > 5
+  puts halve(x)
+end
+`), 0644)
+	program, err := parser.ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := Compile(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm := result.SourceMaps["main.go"]
+	lineOf := func(goSrc string) int {
+		for i, l := range strings.Split(result.MainFile(), "\n") {
+			if strings.TrimSpace(l) == goSrc {
+				return i + 1
+			}
+		}
+		return 0
+	}
+	for _, tt := range []struct {
+		goLine string
+		line   int
+	}{
+		{"func Halve(n int) int {", 1},
+		{`fmt.Println("halving")`, 2},
+		{"return n / 2", 3},
+		{"x := 10", 6},
+		{"if x > 5 {", 7},
+		{"fmt.Println(Halve(x))", 8},
+	} {
+		goLine := lineOf(tt.goLine)
+		if m, ok := sm.Lookup(goLine); !ok || m.File != path || m.Line != tt.line {
+			t.Errorf("expected %q on line %d to map to main.rb:%d, got %+v", tt.goLine, goLine, tt.line, m)
+		}
+	}
+
+	out := filepath.Join(dir, "out")
+	os.Mkdir(out, 0755)
+	b, err := sm.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"file": "../main.rb"`) {
+		t.Errorf("expected Ruby paths relative to the output directory, got %s", b)
+	}
+	os.WriteFile(filepath.Join(out, "main.go"+SourceMapSuffix), b, 0644)
+	tracer, err := LoadSourceMaps(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trace := fmt.Sprintf("main.main()\n\t/tmp/build123/main.go:%d +0x1d\n", lineOf("fmt.Println(Halve(x))"))
+	got := tracer.Rewrite(trace)
+	if expected := "main.main()\n\t" + path + ":8 +0x1d\n"; got != expected {
+		t.Errorf("expected trace rewritten to\n%s\ngot\n%s", expected, got)
+	}
+	if vet := "./other.go:3:2: declared and not used: y"; tracer.Rewrite(vet) != vet {
+		t.Errorf("expected locations without a source map to be left alone, got %s", tracer.Rewrite(vet))
+	}
+}
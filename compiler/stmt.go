@@ -11,6 +11,19 @@ import (
 // Statement translation methods never return AST nodes. Instead, they always
 // append to the current block statement.
 func (g *GoProgram) CompileStmt(node parser.Node) {
+	block := g.BlockStack.Peek()
+	if block == nil {
+		g.compileStmt(node)
+		return
+	}
+	before := len(block.List)
+	g.compileStmt(node)
+	if len(block.List) > before {
+		g.recordSource(node, block.List[before:]...)
+	}
+}
+
+func (g *GoProgram) compileStmt(node parser.Node) {
 	switch n := node.(type) {
 	case *parser.NoopNode:
 		return
@@ -46,6 +59,10 @@ func (g *GoProgram) CompileStmt(node parser.Node) {
 					g.State.Push(InReturnStatement)
 					defer g.State.Pop()
 					g.CompileStmt(stmt)
+				} else {
+					g.appendToCurrentBlock(&ast.ReturnStmt{
+						Results: g.wrapOptionalReturn(g.mapToExprs(n.Val), n.Val),
+					})
 				}
 			default:
 				g.appendToCurrentBlock(&ast.ReturnStmt{
@@ -61,6 +78,7 @@ func (g *GoProgram) CompileStmt(node parser.Node) {
 		if n.TypeGuard {
 			return // runtime type check — redundant in Go
 		}
+		g.hoistUnionLocals(n)
 		cond := g.CompileExpr(n.Condition)
 		// If the condition is an Optional or Proc type, compare against nil
 		condType := n.Condition.Type()
@@ -105,6 +123,10 @@ func (g *GoProgram) CompileStmt(node parser.Node) {
 			g.compileArrayPatternCase(arrVal, n.Whens)
 			break
 		}
+		if n.TypeSwitch {
+			g.compileUnionCase(n)
+			break
+		}
 		stmt := &ast.SwitchStmt{}
 		tag := g.CompileExpr(n.Value)
 		if n.Value != nil && !n.RequiresExpansion {
@@ -153,6 +175,14 @@ func (g *GoProgram) CompileStmt(node parser.Node) {
 	case *parser.PatternMatchNode:
 		g.compilePatternMatch(n)
 	case *parser.MethodCall:
+		if g.State.Peek() == InReturnStatement {
+			if mapped, ok := g.compileParallelMap(n, false); ok {
+				g.appendToCurrentBlock(&ast.ReturnStmt{Results: []ast.Expr{mapped}})
+				return
+			}
+		} else if _, ok := g.compileParallelMap(n, true); ok {
+			return
+		}
 		if n.RequiresTransform() {
 			stmtContext := g.State.Peek() != InReturnStatement
 			var transform types.Transform
@@ -171,6 +201,7 @@ func (g *GoProgram) CompileStmt(node parser.Node) {
 			if len(transform.Finalizers) > 0 {
 				g.Finalizers = append(g.Finalizers, transform.Finalizers...)
 			}
+			g.Deferred = append(g.Deferred, transform.Deferred...)
 			if g.State.Peek() == InReturnStatement && n.Type() != types.NilType {
 				results := g.wrapOptionalReturn([]ast.Expr{transform.Expr}, []parser.Node{n})
 				g.appendToCurrentBlock(&ast.ReturnStmt{
@@ -200,8 +231,12 @@ func (g *GoProgram) CompileStmt(node parser.Node) {
 		// Ruby while loops don't create a new scope, so variables first-assigned
 		// inside the body must be hoisted to the enclosing scope (like ForInNode).
 		hoistWhileLoopVars(n.Body, g)
+		if lhs, ok := optionalWhileAssignment(n.Condition); ok {
+			g.compileWhileAssigned(n, lhs)
+			return
+		}
 		forStmt := &ast.ForStmt{
-			Body: g.CompileBlockStmt(n.Body),
+			Body: g.compileLoopBody(n.Body),
 		}
 		// `loop do...end` desugars to WhileNode with true condition — emit bare `for {}`
 		if boolNode, ok := n.Condition.(*parser.BooleanNode); !ok || boolNode.Val != "true" {
@@ -227,10 +262,29 @@ func (g *GoProgram) CompileStmt(node parser.Node) {
 				Tok: token.CONTINUE,
 			})
 		}
+	case *parser.RedoNode:
+		if len(g.redoTargets) == 0 {
+			panic(fmt.Sprintf("line %d: redo used outside of a loop or block", n.LineNo()))
+		}
+		g.appendToCurrentBlock(&ast.BranchStmt{
+			Tok:   token.GOTO,
+			Label: g.redoTargets[len(g.redoTargets)-1].get(g),
+		})
+	case *parser.RetryNode:
+		if len(g.retryTargets) == 0 {
+			panic(fmt.Sprintf("line %d: retry used outside of a rescue clause", n.LineNo()))
+		}
+		// Flag the attempt for another run and leave the deferred recover.
+		g.appendToCurrentBlock(
+			bst.Assign(g.retryTargets[len(g.retryTargets)-1].get(g), g.it.Get("true")),
+			&ast.ReturnStmt{},
+		)
 	case *parser.AliasNode:
 		// Handled in CompileClass — no-op here
 	case *parser.BeginNode:
 		g.CompileBeginNode(n)
+	case *parser.RescueModNode:
+		g.CompileRescueModStmt(n)
 	case *parser.ForInNode:
 		// Ruby for-loops don't create a new variable scope, so we have to declare
 		// the variable upfront and use an assignment rather than declaration operator
@@ -250,7 +304,7 @@ func (g *GoProgram) CompileStmt(node parser.Node) {
 		loop := &ast.RangeStmt{
 			Tok:  token.ASSIGN,
 			X:    rangeExpr,
-			Body: g.CompileBlockStmt(n.Body),
+			Body: g.compileLoopBody(n.Body),
 		}
 		if _, ok := n.In.Type().(types.Hash); ok {
 			loop.Key, loop.Value = outerScope[0], outerScope[1]
@@ -287,8 +341,9 @@ func (g *GoProgram) CompileStmt(node parser.Node) {
 		// A single ident being returned here means we've prepended statements and
 		// a transform has supplied an ident for potential chained operations. If
 		// we got here, we're not going to make further calls on this object, so
-		// skip it.
-		if _, ok := expr.(*ast.Ident); !ok {
+		// skip it. A bare Kernel call like `exit` may also have supplied only
+		// statements.
+		if _, ok := expr.(*ast.Ident); !ok && expr != nil {
 			g.appendToCurrentBlock(&ast.ExprStmt{
 				X: expr,
 			})
@@ -490,6 +545,7 @@ func (g *GoProgram) CompileAssignmentNode(node *parser.AssignmentNode) {
 		}
 	}
 	var assignFunc bst.AssignFunc
+	var derefRight bool
 	if node.OpAssignment {
 		// operator-assignment can only have singular left and right hand sides ever
 		infix := node.Right[0].(*parser.InfixExpressionNode)
@@ -505,7 +561,7 @@ func (g *GoProgram) CompileAssignmentNode(node *parser.AssignmentNode) {
 			return
 		}
 		// For ||= with Optional types, compile as: if x == nil { _v := rhs; x = &_v }
-		// Also handles nil-default params where the ident was refined to the
+		// Also handles nil-accepting params where the ident was refined to the
 		// concrete type during analysis but the param signature is *T.
 		if infix.Operator == "||" {
 			isOpt := false
@@ -513,7 +569,7 @@ func (g *GoProgram) CompileAssignmentNode(node *parser.AssignmentNode) {
 				isOpt = true
 			} else if ident, ok := infix.Left.(*parser.IdentNode); ok && g.currentMethod != nil {
 				for _, p := range g.currentMethod.Params {
-					if p.Name == ident.Val && p.HasNilDefault() {
+					if p.Name == ident.Val && p.AcceptsNil() {
 						isOpt = true
 						break
 					}
@@ -538,6 +594,11 @@ func (g *GoProgram) CompileAssignmentNode(node *parser.AssignmentNode) {
 			}
 		}
 		assignFunc = bst.OpAssign(infix.Operator)
+		// `total += v`, where v may be nil, adds what v points to, as
+		// `total + v` does.
+		_, leftOpt := infix.Left.Type().(types.Optional)
+		_, rightOpt := infix.Right.Type().(types.Optional)
+		derefRight = rightOpt && !leftOpt
 		node = node.Copy().(*parser.AssignmentNode)
 		node.Right = []parser.Node{infix.Right}
 	} else if node.Reassignment {
@@ -575,6 +636,9 @@ func (g *GoProgram) CompileAssignmentNode(node *parser.AssignmentNode) {
 			rhs = append(rhs, g.CompileExpr(right))
 		}
 	}
+	if derefRight {
+		rhs[0] = &ast.StarExpr{X: rhs[0]}
+	}
 	// Detect variable shadowing: if LHS name matches a package selector in RHS,
 	// remap the variable to avoid illegal Go like `lcs := lcs.Foo()`
 	if !node.Reassignment && !node.OpAssignment {
@@ -603,6 +667,8 @@ func (g *GoProgram) CompileAssignmentNode(node *parser.AssignmentNode) {
 				g.CompileStmt(call)
 			}
 			rhs = append(rhs[:i], rhs[i+1:]...)
+		} else if ident, ok := left.(*parser.IdentNode); ok && g.unionLocal(ident.Val) != nil {
+			lhs = append(lhs, g.it.Get(ident.Val))
 		} else {
 			lhs = append(lhs, g.CompileExpr(left))
 		}
@@ -705,6 +771,28 @@ func (g *GoProgram) CompileAssignmentNode(node *parser.AssignmentNode) {
 				}
 			}
 		}
+		// Values assigned to a union variable go in their member's wrapper,
+		// and the variable itself is declared with the union's type.
+		if len(lhs) == 1 && len(rhs) == 1 {
+			if ident, ok := node.Left[0].(*parser.IdentNode); ok {
+				if u := g.unionLocal(ident.Val); u != nil {
+					rhs[0] = g.wrapUnionValue(u, rhs[0], node.Right[0].Type())
+					if !node.Reassignment && !node.OpAssignment {
+						g.appendToCurrentBlock(&ast.DeclStmt{
+							Decl: &ast.GenDecl{
+								Tok: token.VAR,
+								Specs: []ast.Spec{&ast.ValueSpec{
+									Names:  []*ast.Ident{lhs[0].(*ast.Ident)},
+									Type:   g.it.Get(u.GoType()),
+									Values: rhs,
+								}},
+							},
+						})
+						return
+					}
+				}
+			}
+		}
 		g.appendToCurrentBlock(assignFunc(lhs, rhs))
 	}
 }
@@ -743,50 +831,43 @@ func (g *GoProgram) CompileBeginNode(node *parser.BeginNode) {
 	g.newBlockStmt()
 
 	// Emit ensure defer first (runs LAST due to LIFO = after rescue, matching Ruby)
+	var ensureDefer ast.Stmt
 	if node.EnsureBody != nil {
 		ensureBlock := g.CompileBlockStmt(node.EnsureBody)
-		g.appendToCurrentBlock(&ast.DeferStmt{
+		ensureDefer = &ast.DeferStmt{
 			Call: &ast.CallExpr{
 				Fun: &ast.FuncLit{
 					Type: &ast.FuncType{Params: &ast.FieldList{}},
 					Body: ensureBlock,
 				},
 			},
-		})
+		}
+		g.appendToCurrentBlock(ensureDefer)
 	}
 
 	// Emit rescue defer second (runs FIRST due to LIFO = before ensure, matching Ruby)
+	retry := &jumpTarget{name: "retry"}
 	if len(node.RescueClauses) > 0 {
-		hasTypedClauses := false
+		hasTypedClauses, hasCatchAll := false, false
 		for _, clause := range node.RescueClauses {
 			if len(clause.ExceptionTypes) > 0 {
 				hasTypedClauses = true
-				break
+			} else {
+				hasCatchAll = true
 			}
 		}
 
 		r := g.it.New("r")
 
 		var rescueBodyStmts []ast.Stmt
+		g.retryTargets = append(g.retryTargets, retry)
 		if hasTypedClauses {
 			rescueBodyStmts = g.compileTypedRescue(node.RescueClauses, r)
 		} else {
 			rescueBodyStmts = g.compileSimpleRescue(node.RescueClauses, r)
 		}
-		recoverIf := &ast.IfStmt{
-			Init: bst.Define(r, bst.Call(nil, "recover")),
-			Cond: bst.Binary(r, token.NEQ, g.it.Get("nil")),
-			Body: &ast.BlockStmt{List: rescueBodyStmts},
-		}
-
-		g.appendToCurrentBlock(&ast.DeferStmt{
-			Call: &ast.CallExpr{
-				Fun: &ast.FuncLit{
-					Type: &ast.FuncType{Params: &ast.FieldList{}},
-					Body: &ast.BlockStmt{List: []ast.Stmt{recoverIf}},
-				},
-			},
-		})
+		g.retryTargets = g.retryTargets[:len(g.retryTargets)-1]
+		g.appendToCurrentBlock(g.deferRecover(r, rescueBodyStmts, hasCatchAll))
 	}
 
 	// Compile begin body
@@ -797,23 +878,305 @@ func (g *GoProgram) CompileBeginNode(node *parser.BeginNode) {
 	iifeBody := g.BlockStack.Peek()
 	g.BlockStack.Pop()
 
-	// Wrap in IIFE
+	if retry.ident == nil {
+		// Wrap in IIFE
+		g.appendToCurrentBlock(iife(iifeBody))
+		return
+	}
+
+	// A rescue clause retries: run the body in a loop until an attempt
+	// finishes without the rescue flagging another one. Ensure runs once,
+	// after the last attempt, so it moves out to a closure around the loop.
+	//
+	//	for {
+	//		retry := false
+	//		func() {
+	//			defer func() {
+	//				if r := recover(); r != nil {
+	//					retry = true
+	//					return
+	//				}
+	//			}()
+	//			...
+	//		}()
+	//		if !retry {
+	//			break
+	//		}
+	//	}
+	if ensureDefer != nil {
+		iifeBody.List = iifeBody.List[1:]
+	}
+	loop := &ast.ForStmt{Body: &ast.BlockStmt{List: []ast.Stmt{
+		bst.Define(retry.ident, g.it.Get("false")),
+		iife(iifeBody),
+		&ast.IfStmt{
+			Cond: &ast.UnaryExpr{Op: token.NOT, X: retry.ident},
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.BranchStmt{Tok: token.BREAK}}},
+		},
+	}}}
+	if ensureDefer == nil {
+		g.appendToCurrentBlock(loop)
+		return
+	}
+	g.appendToCurrentBlock(iife(&ast.BlockStmt{List: []ast.Stmt{ensureDefer, loop}}))
+}
+
+// iife wraps a block in an immediately invoked closure.
+func iife(body *ast.BlockStmt) ast.Stmt {
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.FuncLit{
+				Type: &ast.FuncType{Params: &ast.FieldList{}},
+				Body: body,
+			},
+		},
+	}
+}
+
+// jumpTarget is where a `redo` or `retry` transfers control: a label at the
+// top of a loop body, or a flag that re-runs a begin block. The identifier is
+// only allocated when something jumps to it, so constructs that don't use
+// redo or retry compile exactly as before.
+type jumpTarget struct {
+	name  string
+	label bool
+	ident *ast.Ident
+}
+
+func (t *jumpTarget) get(g *GoProgram) *ast.Ident {
+	if t.ident == nil {
+		if t.label {
+			// Go labels are function-scoped and can't be shadowed, so they're
+			// numbered per program rather than allocated from the block-scoped
+			// ident tracker.
+			name := t.name
+			if g.labels > 0 {
+				name = fmt.Sprintf("%s%d", name, g.labels)
+			}
+			g.labels++
+			t.ident = ast.NewIdent(name)
+		} else {
+			t.ident = g.it.New(t.name)
+		}
+	}
+	return t.ident
+}
+
+// labelRedo attaches the redo label to the first statement of a loop body
+// when a `redo` in the body jumps to it.
+func (t *jumpTarget) labelRedo(stmts []ast.Stmt) []ast.Stmt {
+	if t.ident != nil && len(stmts) > 0 {
+		stmts[0] = &ast.LabeledStmt{Label: t.ident, Stmt: stmts[0]}
+	}
+	return stmts
+}
+
+// optionalWhileAssignment reports whether cond is an assignment of something
+// that may be nil to a local, as in `while line = gets`, returning the local.
+func optionalWhileAssignment(cond parser.Node) (*parser.IdentNode, bool) {
+	asgn, ok := cond.(*parser.AssignmentNode)
+	if !ok || len(asgn.Left) != 1 || len(asgn.Right) != 1 {
+		return nil, false
+	}
+	ident, ok := asgn.Left[0].(*parser.IdentNode)
+	if !ok {
+		return nil, false
+	}
+	_, isOpt := asgn.Right[0].Type().(types.Optional)
+	return ident, isOpt
+}
+
+// compileWhileAssigned compiles `while x = expr`, where expr may be nil, to a
+// loop that assigns x at the top of each iteration and stops once it is nil.
+func (g *GoProgram) compileWhileAssigned(n *parser.WhileNode, lhs *parser.IdentNode) {
+	g.newBlockStmt()
+	g.CompileStmt(n.Condition)
+	g.appendToCurrentBlock(&ast.IfStmt{
+		Cond: bst.Binary(g.it.Get(lhs.Val), token.EQL, g.it.Get("nil")),
+		Body: &ast.BlockStmt{List: []ast.Stmt{&ast.BranchStmt{Tok: token.BREAK}}},
+	})
+	head := g.BlockStack.Pop()
+	body := g.compileLoopBody(n.Body)
+	body.List = append(head.List, body.List...)
+	g.appendToCurrentBlock(&ast.ForStmt{Body: body})
+}
+
+// compileLoopBody compiles the body of a while or for loop, making it the
+// target of any `redo` inside.
+func (g *GoProgram) compileLoopBody(body parser.Statements) *ast.BlockStmt {
+	redo := &jumpTarget{name: "redo", label: true}
+	g.redoTargets = append(g.redoTargets, redo)
+	blockStmt := g.CompileBlockStmt(body)
+	g.redoTargets = g.redoTargets[:len(g.redoTargets)-1]
+	blockStmt.List = redo.labelRedo(blockStmt.List)
+	return blockStmt
+}
+
+// deferRecover builds `defer func() { if r := recover(); r != nil { body } }()`.
+// catchAll is whether body handles whatever was raised rather than switching
+// on its type.
+func (g *GoProgram) deferRecover(r *ast.Ident, body []ast.Stmt, catchAll bool) ast.Stmt {
+	recoverIf := &ast.IfStmt{
+		Init: bst.Define(r, bst.Call(nil, "recover")),
+		Cond: bst.Binary(r, token.NEQ, g.it.Get("nil")),
+		Body: &ast.BlockStmt{List: body},
+	}
+	if catchAll {
+		g.catchAllRescues = append(g.catchAllRescues, catchAllRescue{recoverIf, g})
+	}
+	return &ast.DeferStmt{
+		Call: &ast.CallExpr{
+			Fun: &ast.FuncLit{
+				Type: &ast.FuncType{Params: &ast.FieldList{}},
+				Body: &ast.BlockStmt{List: []ast.Stmt{recoverIf}},
+			},
+		},
+	}
+}
+
+// CompileRescueModStmt compiles `expr rescue fallback` in statement position
+// to an immediately invoked closure that runs the fallback if expr panics.
+func (g *GoProgram) CompileRescueModStmt(node *parser.RescueModNode) {
+	if node.FallbackJumps() {
+		g.compileRescueModJump(node, false)
+		return
+	}
+	g.newBlockStmt()
+	g.CompileStmt(node.Fallback)
+	rescueBody := g.BlockStack.Pop()
+
+	g.newBlockStmt()
+	g.appendToCurrentBlock(g.deferRecover(g.it.New("r"), rescueBody.List, true))
+	g.CompileStmt(node.Expr)
+	body := g.BlockStack.Pop()
+
 	g.appendToCurrentBlock(&ast.ExprStmt{
 		X: &ast.CallExpr{
 			Fun: &ast.FuncLit{
 				Type: &ast.FuncType{Params: &ast.FieldList{}},
-				Body: iifeBody,
+				Body: body,
+			},
+		},
+	})
+}
+
+// CompileRescueModExpr compiles `expr rescue fallback` in value position to an
+// immediately invoked closure with a named result. The deferred recover
+// overwrites the result with the fallback if evaluating expr panics:
+//
+//	n := func() (v int) {
+//		defer func() {
+//			if r := recover(); r != nil {
+//				v = 0
+//			}
+//		}()
+//		return Parse(s)
+//	}()
+func (g *GoProgram) CompileRescueModExpr(node *parser.RescueModNode) ast.Expr {
+	if node.FallbackJumps() {
+		return g.compileRescueModJump(node, true)
+	}
+	result := g.it.New("v")
+
+	g.newBlockStmt()
+	g.appendToCurrentBlock(bst.Assign(result, g.compileRescueModValue(node.Fallback, node.Type())))
+	rescueBody := g.BlockStack.Pop()
+
+	g.newBlockStmt()
+	g.appendToCurrentBlock(g.deferRecover(g.it.New("r"), rescueBody.List, true))
+	g.appendToCurrentBlock(&ast.ReturnStmt{
+		Results: []ast.Expr{g.compileRescueModValue(node.Expr, node.Type())},
+	})
+	body := g.BlockStack.Pop()
+
+	return &ast.CallExpr{
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: &ast.FieldList{List: []*ast.Field{{
+					Names: []*ast.Ident{result},
+					Type:  g.it.Get(node.Type().GoType()),
+				}}},
 			},
+			Body: body,
 		},
+	}
+}
+
+// compileRescueModJump compiles a rescue modifier whose fallback is next,
+// break, redo or return. A jump can't leave the closure that recovers, so the
+// closure reports whether expr finished and the fallback runs after it, at the
+// level of the statement it jumps out of:
+//
+//	v, ok := func() (v int, ok bool) {
+//		defer func() {
+//			if r := recover(); r != nil {
+//			}
+//		}()
+//		return stdlib.Integer(s, 0), true
+//	}()
+//	if !ok {
+//		continue
+//	}
+//
+// valued is whether the modifier is used for its value, v, which is returned.
+func (g *GoProgram) compileRescueModJump(node *parser.RescueModNode, valued bool) ast.Expr {
+	var result *ast.Ident
+	ok := g.it.New("ok")
+	results := []*ast.Field{{Names: []*ast.Ident{ok}, Type: g.it.Get("bool")}}
+	lhs := []ast.Expr{ok}
+
+	g.newBlockStmt()
+	g.appendToCurrentBlock(g.deferRecover(g.it.New("r"), nil, true))
+	if valued {
+		result = g.it.New("v")
+		results = append([]*ast.Field{{Names: []*ast.Ident{result}, Type: g.it.Get(node.Type().GoType())}}, results...)
+		lhs = append([]ast.Expr{result}, lhs...)
+		g.appendToCurrentBlock(&ast.ReturnStmt{Results: []ast.Expr{g.CompileExpr(node.Expr), g.it.Get("true")}})
+	} else {
+		g.CompileStmt(node.Expr)
+		g.appendToCurrentBlock(&ast.ReturnStmt{Results: []ast.Expr{g.it.Get("true")}})
+	}
+	body := g.BlockStack.Pop()
+
+	g.appendToCurrentBlock(bst.Define(lhs, &ast.CallExpr{
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{Params: &ast.FieldList{}, Results: &ast.FieldList{List: results}},
+			Body: body,
+		},
+	}))
+	g.appendToCurrentBlock(&ast.IfStmt{
+		Cond: &ast.UnaryExpr{Op: token.NOT, X: ok},
+		Body: g.CompileBlockStmt(parser.Statements{node.Fallback}),
 	})
+	if result == nil {
+		return nil
+	}
+	return result
+}
+
+// compileRescueModValue compiles one operand of a rescue modifier, wrapping
+// concrete values in stdlib.Ptr[T]() when the modifier is Optional(T).
+func (g *GoProgram) compileRescueModValue(node parser.Node, t types.Type) ast.Expr {
+	expr := g.CompileExpr(node)
+	if opt, ok := t.(types.Optional); ok {
+		if _, isNil := node.(*parser.NilNode); !isNil && !node.Type().Equals(opt) {
+			expr = g.wrapPtr(expr, opt.Element)
+		}
+	}
+	return expr
 }
 
 func (g *GoProgram) compileSimpleRescue(clauses []*parser.RescueClause, r *ast.Ident) []ast.Stmt {
 	var stmts []ast.Stmt
 	for _, clause := range clauses {
-		bodyBlock := g.CompileBlockStmt(clause.Body)
+		// Name the rescue variable before the body refers to it.
+		var e *ast.Ident
 		if clause.ExceptionVar != "" && rescueVarUsed(clause.Body, clause.ExceptionVar) {
-			e := g.it.New(clause.ExceptionVar)
+			e = g.it.Get(clause.ExceptionVar)
+		}
+		bodyBlock := g.CompileBlockStmt(clause.Body)
+		if e != nil {
 			stmts = append(stmts, bst.Define(e, &ast.TypeAssertExpr{
 				X:    r,
 				Type: ast.NewIdent("error"),
@@ -839,7 +1202,7 @@ func (g *GoProgram) compileTypedRescue(clauses []*parser.RescueClause, r *ast.Id
 	}
 	var switchVar *ast.Ident
 	if switchVarName != "" {
-		switchVar = g.it.New(switchVarName)
+		switchVar = g.it.Get(switchVarName)
 	}
 
 	// Build type switch: switch [e :=] r.(type) { case *stdlib.X: ... }
@@ -941,6 +1304,14 @@ func nodeReferencesVar(node parser.Node, name string) bool {
 				return true
 			}
 		}
+	case *parser.StringNode:
+		for _, interp := range n.OrderedInterps() {
+			if nodeReferencesVar(interp, name) {
+				return true
+			}
+		}
+	case *parser.InfixExpressionNode:
+		return nodeReferencesVar(n.Left, name) || nodeReferencesVar(n.Right, name)
 	}
 	return false
 }
@@ -950,16 +1321,35 @@ func nodeReferencesVar(node parser.Node, name string) bool {
 // be declared in the enclosing Go scope to be accessible after the loop.
 func hoistWhileLoopVars(stmts parser.Statements, g *GoProgram) {
 	hoisted := map[string]bool{}
-	hoistWalk(stmts, hoisted, g)
+	hoistWalk(stmts, hoisted, nil, g)
 }
 
-func hoistWalk(stmts parser.Statements, hoisted map[string]bool, g *GoProgram) {
+// hoistBlockLocals declares a block's block-local variables (`|x; tmp|`) at
+// the top of the compiled body, so a first assignment nested in a
+// conditional still lands on the block's own copy rather than the outer one.
+func hoistBlockLocals(blk *parser.Block, g *GoProgram) {
+	if len(blk.Locals) == 0 {
+		return
+	}
+	only := map[string]bool{}
+	for _, p := range blk.Locals {
+		only[p.Name] = true
+	}
+	hoistWalk(blk.Body.Statements, map[string]bool{}, only, g)
+}
+
+// hoistWalk declares variables first assigned in stmts and marks those
+// assignments as reassignments. If only is non-nil, other names are left alone.
+func hoistWalk(stmts parser.Statements, hoisted, only map[string]bool, g *GoProgram) {
 	for _, stmt := range stmts {
 		switch s := stmt.(type) {
 		case *parser.AssignmentNode:
 			if !s.Reassignment && !s.OpAssignment && !s.SetterCall {
 				for i, left := range s.Left {
 					if ident, ok := left.(*parser.IdentNode); ok {
+						if only != nil && !only[ident.Val] {
+							continue
+						}
 						// Use RHS type since LHS ident may not be typed yet for first-assigns
 						var rhsType types.Type
 						if i < len(s.Right) {
@@ -976,12 +1366,12 @@ func hoistWalk(stmts parser.Statements, hoisted map[string]bool, g *GoProgram) {
 				}
 			}
 		case *parser.WhileNode:
-			hoistWalk(s.Body, hoisted, g)
+			hoistWalk(s.Body, hoisted, only, g)
 		case *parser.Condition:
-			hoistWalk(s.True, hoisted, g)
+			hoistWalk(s.True, hoisted, only, g)
 			if s.False != nil {
 				if cond, ok := s.False.(*parser.Condition); ok {
-					hoistWalk(cond.True, hoisted, g)
+					hoistWalk(cond.True, hoisted, only, g)
 				}
 			}
 		}
@@ -1091,6 +1481,14 @@ func (g *GoProgram) wrapOptionalReturn(exprs []ast.Expr, nodes []parser.Node) []
 		return exprs
 	}
 	retType := g.currentMethod.ReturnType()
+	if u, isUnion := retType.(*types.Union); isUnion {
+		for i := range exprs {
+			if i < len(nodes) {
+				exprs[i] = g.wrapUnionValue(u, exprs[i], nodes[i].Type())
+			}
+		}
+		return exprs
+	}
 	opt, isOpt := retType.(types.Optional)
 	if !isOpt {
 		return exprs
@@ -11,7 +11,9 @@ import (
 )
 
 // compileSynthStruct emits a Go struct type declaration plus Get and Set
-// methods for a synthesized struct derived from a Ruby Tuple.
+// methods for a synthesized struct derived from a Ruby Tuple. Structs derived
+// from hash literals are only ever accessed by static key, so they get no
+// index-based accessors.
 func (g *GoProgram) compileSynthStruct(ss *types.SynthStruct) []ast.Decl {
 	var decls []ast.Decl
 
@@ -33,6 +35,10 @@ func (g *GoProgram) compileSynthStruct(ss *types.SynthStruct) []ast.Decl {
 		},
 	})
 
+	if ss.Keyed() {
+		return decls
+	}
+
 	// 2. Get(i int) interface{} method
 	decls = append(decls, g.synthGetMethod(ss))
 
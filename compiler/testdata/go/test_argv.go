@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/redneckbeard/thanos/stdlib"
+)
+
+func Usage(args []string) {
+	if len(args) == 0 {
+		fmt.Println("usage: greet NAME...")
+		stdlib.Exit(2)
+	}
+}
+func main() {
+	defer stdlib.Exited()
+	Usage(os.Args[1:])
+	for _, name := range os.Args[1:] {
+		fmt.Printf("Hello, %s\n", name)
+	}
+	if len(os.Args[1:]) > 1 {
+		stdlib.Exit(0)
+	}
+	fmt.Println("just one")
+}
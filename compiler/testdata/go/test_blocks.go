@@ -1,5 +1,7 @@
 package main
 
+import "fmt"
+
 type fooBlk func(b int) float64
 
 func Foo(x, y int, blk fooBlk) float64 {
@@ -9,4 +11,15 @@ func main() {
 	Foo(7, 8, func(b int) float64 {
 		return float64(b) / 10.0
 	})
+	acc := "outer"
+	for _, x := range []int{1, 2, 3} {
+		var acc int
+		if x%2 == 1 {
+			acc = x * 2
+		} else {
+			acc = x
+		}
+		fmt.Println(acc)
+	}
+	fmt.Println(acc)
 }
@@ -34,6 +34,9 @@ func (c *CTDriver) Initialize(age int) int {
 func (c *CTDriver) Can_drive() bool {
 	return c.age >= CTDriverLICENSE_AGE
 }
+func (c *CTDriver) Can_practice() bool {
+	return c.age >= PERMIT_AGE
+}
 
 type CrossStateCommercialCTDriver struct {
 	age int
@@ -47,7 +50,7 @@ func NewCrossStateCommercialCTDriver() *CrossStateCommercialCTDriver {
 var CrossStateCommercialCTDriverClass = stdlib.NewMetaclass[CrossStateCommercialCTDriver]("CrossStateCommercialCTDriver")
 
 func main() {
-	if NewCTDriver(19).Can_drive() {
+	if NewCTDriver(19).Can_drive() && NewCTDriver(19).Can_practice() {
 		fmt.Println(CrossStateCommercialCTDriverLICENSE_AGE)
 	}
 }
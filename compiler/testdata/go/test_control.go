@@ -6,6 +6,12 @@ import (
 	"github.com/redneckbeard/thanos/stdlib"
 )
 
+func Halve(n int) int {
+	if n%2 == 1 {
+		panic(&stdlib.ArgumentError{StandardError: stdlib.StandardError{RubyError: stdlib.RubyError{Msg: "odd"}}})
+	}
+	return n / 2
+}
 func main() {
 	x := 100
 	for x > 0 {
@@ -47,4 +53,75 @@ func main() {
 			continue
 		}
 	}
+	h := func() (v1 int) {
+		defer func() {
+			if r := recover(); r != nil {
+				v1 = 0
+			}
+		}()
+		return Halve(3)
+	}()
+	fmt.Println(h)
+	func() {
+		defer func() {
+			if r1 := recover(); r1 != nil {
+				fmt.Println("could not halve")
+			}
+		}()
+		Halve(5)
+	}()
+	n := func() (v2 int) {
+		defer func() {
+			if r2 := recover(); r2 != nil {
+				v2 = 0
+			}
+		}()
+		return stdlib.Integer("12abc", 0)
+	}()
+	fmt.Println(n + stdlib.Integer("0x1f", 0) + stdlib.Integer("17", 8))
+	for _, s := range []string{"4", "x"} {
+		v, ok := func() (v int, ok bool) {
+			defer func() {
+				if r := recover(); r != nil {
+				}
+			}()
+			return stdlib.Integer(s, 0), true
+		}()
+		if !ok {
+			continue
+		}
+		m := v
+		fmt.Println(m * 2)
+	}
+	tries := 0
+	for {
+		retry := false
+		func() {
+			defer func() {
+				if r3 := recover(); r3 != nil {
+					switch r3.(type) {
+					case *stdlib.ArgumentError:
+						retry = true
+						return
+					default:
+						panic(r3)
+					}
+				}
+			}()
+			tries++
+			Halve(tries)
+		}()
+		if !retry {
+			break
+		}
+	}
+	z := 0
+	for z < 3 {
+	redo:
+		z++
+		if z == 2 {
+			goto redo
+		}
+		fmt.Println(z)
+	}
 }
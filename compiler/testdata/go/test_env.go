@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/redneckbeard/thanos/stdlib"
+)
+
+func Port() int {
+	asInt, _ := strconv.Atoi(stdlib.OrDefault(stdlib.LookupEnv("PORT"), "8080"))
+	return asInt
+}
+func main() {
+	home := stdlib.LookupEnv("HOME")
+	if home != nil && *home == "/root" {
+		fmt.Println(*home)
+	}
+	level := stdlib.OrDefault(stdlib.LookupEnv("LOG_LEVEL"), "info")
+	token := stdlib.FetchEnv("TOKEN", func(name string) string {
+		return fmt.Sprintf("missing %s", name)
+	})
+	secret := stdlib.FetchEnv("SECRET", nil)
+	os.Setenv("LOG_LEVEL", level)
+	os.Unsetenv("TOKEN")
+	if stdlib.HasEnv("DEBUG") {
+		fmt.Println(Port())
+		fmt.Println(token)
+		fmt.Println(secret)
+	}
+	for name, value := range stdlib.Environ() {
+		fmt.Printf("%s=%s\n", name, value)
+	}
+	n := 0
+	for k := range stdlib.Environ() {
+		if k == "X" {
+			n++
+		}
+	}
+	for _, v := range stdlib.Environ() {
+		fmt.Println(v)
+	}
+	for range stdlib.Environ() {
+		n++
+	}
+	fmt.Println(n)
+	fmt.Println(stdlib.EnvHash().Len())
+}
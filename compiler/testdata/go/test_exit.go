@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redneckbeard/thanos/stdlib"
+)
+
+func Check_config(path string) {
+	if path == "" {
+		stdlib.Abort("no config given")
+	}
+	func() {
+		defer func() {
+			fmt.Printf("checked %s\n", path)
+		}()
+		defer func() {
+			if r := recover(); r != nil {
+				if _, exiting := r.(*stdlib.SystemExit); exiting {
+					panic(r)
+				}
+				switch e := r.(type) {
+				case *stdlib.IOError:
+					stdlib.Abort(fmt.Sprintf("could not read %s: %s", path, e.Error()))
+				default:
+					stdlib.Exit(2)
+				}
+			}
+		}()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(len(string(data)))
+	}()
+}
+func main() {
+	defer stdlib.Exited()
+	stdlib.AtExit(func() {
+		fmt.Println("shutting down")
+	})
+	stdlib.AtExit(func() {
+		fmt.Println("flushing")
+	})
+	Check_config(strings.Join(os.Args[1:], ""))
+	fmt.Printf("pid %d\n", os.Getpid())
+	if !(stdlib.System("git", "status") && stdlib.LastStatus.SuccessQ()) {
+		stdlib.Abort(fmt.Sprintf("git exited %d", stdlib.LastStatus.Exitstatus()))
+	}
+	retries := len(os.Args[1:])
+	time.Sleep(time.Duration(1) * time.Second)
+	time.Sleep(time.Duration(0.5 * float64(time.Second)))
+	if retries > 3 {
+		os.Exit(1)
+	}
+	stdlib.Exit(stdlib.ExitStatus(retries == 0))
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"os"
+	"tmpmod/cli"
+
+	"github.com/redneckbeard/thanos/stdlib"
+)
+
+func main() {
+	defer stdlib.Exited()
+	cli.Run(os.Args[1:])
+}
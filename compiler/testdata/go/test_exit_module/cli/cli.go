@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/redneckbeard/thanos/stdlib"
+)
+
+func Run(args []string) {
+	func() {
+		defer func() {
+			fmt.Println("done")
+		}()
+		defer func() {
+			if r := recover(); r != nil {
+				if _, exiting := r.(*stdlib.SystemExit); exiting {
+					panic(r)
+				}
+				e := r.(error)
+				fmt.Printf("rescued %s\n", e.Error())
+			}
+		}()
+		if len(args) == 0 {
+			stdlib.Exit(3)
+		}
+		fmt.Printf("args: %d\n", len(args))
+	}()
+}
@@ -26,4 +26,7 @@ func main() {
 		val1 = fmt.Sprintf("default for %v", k)
 	}
 	y := val1
+	i := 2
+	h.Set(fmt.Sprintf("item_%d", i), "z")
+	found := h.Data[fmt.Sprintf("item_%d", i)] == h.Data["item_2"]
 }
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/redneckbeard/thanos/stdlib"
+)
+
+func Count_words(io *os.File) int {
+	words := 0
+	for line := range stdlib.EachLine(io) {
+		words += len(strings.Fields(line))
+	}
+	return words
+}
+func main() {
+	header := stdlib.Gets(os.Stdin)
+	fmt.Fprintf(os.Stderr, "header: %s\n", stdlib.OrDefault(header, ""))
+	lines := 0
+	for {
+		line := stdlib.ARGF.Gets()
+		if line == nil {
+			break
+		}
+		lines++
+		fmt.Fprint(os.Stdout, strings.ToUpper(*line))
+	}
+	fmt.Fprint(os.Stderr, "lines: ", lines, "\n")
+	fmt.Fprintln(os.Stdout, Count_words(os.Stdin))
+	for line := range stdlib.ARGF.EachLine() {
+		fmt.Print(line)
+	}
+}
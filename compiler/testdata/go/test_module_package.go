@@ -10,4 +10,5 @@ import (
 func main() {
 	c := geometry.NewCircle(10)
 	fmt.Println(stdlib.FormatFloat(c.Area()))
+	fmt.Println(stdlib.FormatFloat(geometry.PI))
 }
@@ -2,6 +2,8 @@ package geometry
 
 import "github.com/redneckbeard/thanos/stdlib"
 
+const PI = 3.14
+
 type Circle struct {
 	radius int
 }
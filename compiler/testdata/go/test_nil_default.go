@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/redneckbeard/thanos/stdlib"
 )
@@ -21,9 +22,18 @@ func Find_index(arr []int, target int) *int {
 	}
 	return nil
 }
+func Pad(width *int) string {
+	if width == nil {
+		v := 4
+		width = &v
+	}
+	return strings.Repeat("x", *width)
+}
 func main() {
 	Greet(stdlib.Ptr[string]("paul"))
 	Greet(nil)
 	fmt.Println(*Find_index([]int{10, 20, 30}, 20))
 	fmt.Println(Find_index([]int{10, 20, 30}, 99) == nil)
+	fmt.Println(Pad(nil))
+	fmt.Println(Pad(stdlib.Ptr[int](2)))
 }
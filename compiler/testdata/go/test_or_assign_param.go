@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redneckbeard/thanos/stdlib"
+)
+
+func Pad(w *int) string {
+	if w == nil {
+		v := 4
+		w = &v
+	}
+	return strings.Repeat("x", *w)
+}
+func main() {
+	fmt.Println(Pad(nil))
+	fmt.Println(Pad(stdlib.Ptr[int](2)))
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redneckbeard/thanos/stdlib"
+)
+
+func Work(i int) int {
+	if i == 13 {
+		panic(&stdlib.ArgumentError{StandardError: stdlib.StandardError{RubyError: stdlib.RubyError{Msg: fmt.Sprintf("bad %d", i)}}})
+	}
+	return i * i
+}
+func Squares(xs []int) []int {
+	mapped, err := stdlib.ParallelMap(xs, func(x int) int {
+		return Work(x)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return mapped
+}
+func main() {
+	items := []int{3, 1, 4, 1, 5}
+	mapped, err := stdlib.ParallelMap(items, func(i int) int {
+		return Work(i)
+	})
+	if err != nil {
+		panic(err)
+	}
+	results := mapped
+	segments := []string{}
+	for _, x := range results {
+		segments = append(segments, fmt.Sprintf("%v", x))
+	}
+	fmt.Println(strings.Join(segments, " "))
+	names := strings.Fields(`a b c`)
+	mapped1, err1 := stdlib.ParallelMap(names, func(n string) string {
+		return strings.ToUpper(n) + "!"
+	})
+	if err1 != nil {
+		panic(err1)
+	}
+	upper := mapped1
+	fmt.Println(strings.Join(upper, ","))
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				switch e := r.(type) {
+				case *stdlib.ArgumentError:
+					fmt.Printf("raised: %s\n", e.Error())
+				default:
+					panic(r)
+				}
+			}
+		}()
+		_, err2 := stdlib.ParallelMap([]int{12, 13, 14}, func(i int) int {
+			return Work(i)
+		})
+		if err2 != nil {
+			panic(err2)
+		}
+	}()
+	fmt.Println(stdlib.Sum(Squares([]int{2, 3})))
+	_, err3 := stdlib.ParallelMap([]int{7, 8}, func(_ int) int {
+		return Work(2)
+	})
+	if err3 != nil {
+		panic(err3)
+	}
+}
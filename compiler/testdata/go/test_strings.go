@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strings"
 
@@ -47,6 +46,8 @@ func main() {
 	Extract_third_octet("127.0.0.1")
 	terms := strings.Fields(`foo bar baz`)
 	interp_terms := []string{"foo", fmt.Sprintf("%s", "BAR BAZ QUUX"), "bar"}
-	output, _ := exec.Command("man", "-P", "cat", fmt.Sprintf("%s", "date")).Output()
-	fmt.Println(string(output))
+	prefixed_terms := []string{fmt.Sprintf("%s-a", greeting), "b", fmt.Sprintf("c%dd", 38)}
+	syms := strings.Fields(`foo bar`)
+	interp_syms := []string{fmt.Sprintf("%s_sym", greeting), "baz"}
+	fmt.Println(stdlib.Backtick("man", "-P", "cat", fmt.Sprintf("%s", "date")))
 }
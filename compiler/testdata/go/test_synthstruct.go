@@ -1,5 +1,7 @@
 package main
 
+import "fmt"
+
 func Build_entries(names []string) []*EntriesEntry {
 	entries := []*EntriesEntry{}
 	i := 0
@@ -87,7 +89,29 @@ func (s *LinksEntry) Set(i int, v interface{}) {
 		panic("index out of range")
 	}
 }
+
+type ConfigEntry struct {
+	Name string
+	Port int
+	Tls  bool
+}
+type ServersEntry struct {
+	Host   string
+	Weight int
+}
+
 func main() {
 	result := Build_entries([]string{"alice", "bob"})
 	chain := Build_links(5)
+	config := &ConfigEntry{Name: "api", Port: 80, Tls: true}
+	config.Port = 8443
+	if config.Tls {
+		fmt.Printf("%s:%d\n", config.Name, config.Port)
+	}
+	servers := []*ServersEntry{&ServersEntry{Host: "a", Weight: 2}, &ServersEntry{Weight: 5, Host: "b"}}
+	for _, server := range servers {
+		if server.Weight > 3 {
+			fmt.Println(server.Host)
+		}
+	}
 }
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/redneckbeard/thanos/stdlib"
+)
+
+func main() {
+	count := 0
+	m := &sync.Mutex{}
+	threads := []*stdlib.Thread[int]{}
+	for i := 0; i < 5; i++ {
+		threads = append(threads, stdlib.NewThread(func() int {
+			return func() int {
+				m.Lock()
+				defer m.Unlock()
+				count += i
+				return count
+			}()
+		}))
+	}
+	for _, _elem := range threads {
+		_elem.Join()
+	}
+	fmt.Println(count)
+	q := stdlib.NewQueue[int]()
+	producer := stdlib.NewThread(func() *stdlib.Queue[int] {
+		for _, n := range []int{1, 2, 3} {
+			q.Push(n * 10)
+		}
+		return q.Close()
+	})
+	total := 0
+	for {
+		v := q.Pop()
+		if v == nil {
+			break
+		}
+		total += *v
+	}
+	producer.Join()
+	fmt.Println(total)
+	t := func(x int) *stdlib.Thread[int] {
+		return stdlib.NewThread(func() int {
+			return x * x
+		})
+	}(4)
+	fmt.Println(t.Value())
+	sq := func() int {
+		m.Lock()
+		defer m.Unlock()
+		return count * 2
+	}()
+	fmt.Println(sq)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redneckbeard/thanos/stdlib"
+)
+
+func Pick(flag bool) IntegerOrString {
+	var x IntegerOrString
+	if flag {
+		x = IntegerOrStringInteger{Val: 42}
+	} else {
+		x = IntegerOrStringString{Val: "forty-two"}
+	}
+	return x
+}
+func Describe(flag bool) string {
+	var v IntegerOrString = Pick(flag)
+	switch v1 := v.(type) {
+	case IntegerOrStringInteger:
+		n := v1.Val + 1
+		return fmt.Sprintf("int %d", n)
+	case IntegerOrStringString:
+		return fmt.Sprintf("str %s", strings.ToUpper(v1.Val))
+	default:
+		panic("unreachable")
+	}
+}
+
+type IntegerOrString interface {
+	isIntegerOrString()
+}
+type IntegerOrStringInteger struct {
+	Val int
+}
+
+func (IntegerOrStringInteger) isIntegerOrString() {
+}
+func (w IntegerOrStringInteger) String() string {
+	return strconv.Itoa(w.Val)
+}
+
+type IntegerOrStringString struct {
+	Val string
+}
+
+func (IntegerOrStringString) isIntegerOrString() {
+}
+func (w IntegerOrStringString) String() string {
+	return w.Val
+}
+func main() {
+	var y IntegerOrString = Pick(false)
+	var result string
+	switch v := y.(type) {
+	case IntegerOrStringInteger:
+		result = strconv.Itoa(v.Val)
+	case IntegerOrStringString:
+		result = v.Val
+	}
+	fmt.Println(len(result))
+	fmt.Println(Describe(true))
+	fmt.Println(Describe(false))
+	for _, i := range []int{1, 2} {
+		w := i * 2
+		fmt.Println(stdlib.Abs(w))
+	}
+	for _, s := range strings.Fields(`a b`) {
+		w := s + "!"
+		fmt.Println(strings.ToUpper(w))
+	}
+	result1 := false
+	if v1, ok := y.(IntegerOrStringString); ok {
+		result1 = v1.Val == "forty-two"
+	}
+	fmt.Println(result1)
+	result2 := false
+	if v2, ok1 := y.(IntegerOrStringInteger); ok1 {
+		result2 = v2.Val == 42
+	}
+	fmt.Println(result2)
+}
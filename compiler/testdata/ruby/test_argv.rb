@@ -0,0 +1,13 @@
+def usage(args)
+  if args.empty?
+    puts "usage: greet NAME..."
+    exit 2
+  end
+end
+
+usage(ARGV)
+ARGV.each do |name|
+  puts "Hello, #{name}"
+end
+exit if ARGV.length > 1
+puts "just one"
@@ -5,3 +5,14 @@ end
 foo(7, 8) do |b|
   b / 10.0
 end
+
+acc = "outer"
+[1, 2, 3].each do |x; acc|
+  if x.odd?
+    acc = x * 2
+  else
+    acc = x
+  end
+  puts acc
+end
+puts acc
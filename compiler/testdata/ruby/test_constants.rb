@@ -14,6 +14,10 @@ class CTDriver
   def can_drive?
     @age >= LICENSE_AGE
   end
+
+  def can_practice?
+    @age >= ::PERMIT_AGE
+  end
 end
 
 class CrossStateCommercialCTDriver < CTDriver
@@ -22,6 +26,6 @@ class CrossStateCommercialCTDriver < CTDriver
   eggplant = 'veg'
 end
 
-if CTDriver.new(19).can_drive?
+if CTDriver.new(19).can_drive? && CTDriver.new(19).can_practice?
   puts CrossStateCommercialCTDriver::LICENSE_AGE
 end
@@ -33,3 +33,33 @@ end
 for k, v in {foo: 1, bar: 2, baz: 3, quux: 4} do
   next if k == :foo || v == 10
 end
+
+def halve(n)
+  raise ArgumentError, "odd" if n % 2 == 1
+  n / 2
+end
+
+h = halve(3) rescue 0
+puts h
+halve(5) rescue puts("could not halve")
+n = Integer("12abc") rescue 0
+puts n + Integer("0x1f") + Integer("17", 8)
+["4", "x"].each do |s|
+  m = Integer(s) rescue next
+  puts m * 2
+end
+
+tries = 0
+begin
+  tries += 1
+  halve(tries)
+rescue ArgumentError
+  retry
+end
+
+z = 0
+while z < 3
+  z += 1
+  redo if z == 2
+  puts z
+end
@@ -0,0 +1,21 @@
+def port
+  ENV.fetch("PORT", "8080").to_i
+end
+
+home = ENV["HOME"]
+puts home if home == "/root"
+level = ENV["LOG_LEVEL"] || "info"
+token = ENV.fetch("TOKEN") { |name| "missing #{name}" }
+secret = ENV.fetch("SECRET")
+ENV["LOG_LEVEL"] = level
+ENV["TOKEN"] = nil
+puts port, token, secret if ENV.key?("DEBUG")
+ENV.each do |name, value|
+  puts "#{name}=#{value}"
+end
+n = 0
+ENV.each { |k, v| n += 1 if k == "X" }
+ENV.each_pair { |k, v| puts v }
+ENV.each { |k, v| n += 1 }
+puts n
+puts ENV.to_h.size
@@ -0,0 +1,25 @@
+def check_config(path)
+  abort("no config given") if path.empty?
+  begin
+    puts File.read(path).size
+  rescue IOError => e
+    abort "could not read #{path}: #{e.message}"
+  rescue
+    exit 2
+  ensure
+    puts "checked #{path}"
+  end
+end
+
+at_exit { puts "shutting down" }
+at_exit do
+  puts "flushing"
+end
+check_config(ARGV.join)
+puts "pid #{Process.pid}"
+abort("git exited #{$?.exitstatus}") unless system("git", "status") && $?.success?
+retries = ARGV.length
+sleep 1
+sleep(0.5)
+exit!(false) if retries > 3
+exit retries.zero?
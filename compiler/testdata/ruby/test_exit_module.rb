@@ -0,0 +1,14 @@
+module Cli
+  def self.run(args)
+    begin
+      exit 3 if args.empty?
+      puts "args: #{args.length}"
+    rescue => e
+      puts "rescued #{e.message}"
+    ensure
+      puts "done"
+    end
+  end
+end
+
+Cli.run(ARGV)
@@ -5,3 +5,7 @@ x = h.delete(:foo)
 y = h.delete(:baz) do |k|
   "default for #{k}"
 end
+
+i = 2
+h[:"item_#{i}"] = "z"
+found = h[:"item_#{i}"] == h[:item_2]
@@ -0,0 +1,19 @@
+def count_words(io)
+  words = 0
+  io.each_line { |line| words += line.split.size }
+  words
+end
+
+$stdout.sync = true
+header = $stdin.gets
+STDERR.puts "header: #{header}"
+lines = 0
+while line = gets
+  lines += 1
+  $stdout.write(line.upcase)
+end
+$stderr.print "lines: ", lines, "\n"
+STDOUT.puts count_words(STDIN)
+ARGF.each_line do |line|
+  print line
+end
@@ -1,4 +1,6 @@
 module Geometry
+  PI = 3.14
+
   def self.pi
     3.14
   end
@@ -16,3 +18,4 @@ end
 
 c = Geometry::Circle.new(10)
 puts c.area
+puts ::Geometry::PI
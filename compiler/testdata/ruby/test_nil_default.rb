@@ -15,3 +15,11 @@ end
 
 puts find_index([10, 20, 30], 20)
 puts find_index([10, 20, 30], 99).nil?
+
+def pad(width)
+  width ||= 4
+  "x" * width
+end
+
+puts pad(nil)
+puts pad(2)
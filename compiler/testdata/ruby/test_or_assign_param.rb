@@ -0,0 +1,7 @@
+def pad(w)
+  w ||= 4
+  "x" * w
+end
+
+puts pad(nil)
+puts pad(2)
@@ -0,0 +1,24 @@
+def work(i)
+  raise ArgumentError, "bad #{i}" if i == 13
+  i * i
+end
+
+items = [3, 1, 4, 1, 5]
+results = items.map { |i| Thread.new { work(i) } }.map(&:value)
+puts results.join(" ")
+
+names = %w[a b c]
+upper = names.map { |n| Thread.new { n.upcase + "!" } }.map { |t| t.value }
+puts upper.join(",")
+
+begin
+  [12, 13, 14].map { |i| Thread.new { work(i) } }.map(&:value)
+rescue ArgumentError => e
+  puts "raised: #{e.message}"
+end
+
+def squares(xs)
+  xs.map { |x| Thread.new { work(x) } }.map(&:value)
+end
+puts squares([2, 3]).sum
+[7, 8].map { Thread.new { work(2) } }.map(&:value)
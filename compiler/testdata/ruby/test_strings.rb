@@ -35,4 +35,7 @@ matches_interp(10, "foofoo")
 extract_third_octet("127.0.0.1")
 terms = %w{foo bar baz}
 interp_terms = %W{foo #{"BAR BAZ QUUX"} bar}
+prefixed_terms = %W[#{greeting}-a b c#{38}d]
+syms = %i[foo bar]
+interp_syms = %I[#{greeting}_sym baz]
 puts `man -P cat #{"date"}`
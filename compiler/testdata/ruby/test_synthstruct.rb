@@ -20,3 +20,12 @@ end
 
 result = build_entries(["alice", "bob"])
 chain = build_links(5)
+
+config = {name: "api", port: 80, tls: true}
+config[:port] = 8443
+puts "#{config[:name]}:#{config[:port]}" if config[:tls]
+
+servers = [{host: "a", weight: 2}, {weight: 5, host: "b"}]
+servers.each do |server|
+  puts server[:host] if server[:weight] > 3
+end
@@ -0,0 +1,27 @@
+count = 0
+m = Mutex.new
+threads = []
+5.times do |i|
+  threads << Thread.new do
+    m.synchronize { count += i }
+  end
+end
+threads.each(&:join)
+puts count
+
+q = Queue.new
+producer = Thread.new do
+  [1, 2, 3].each { |n| q.push(n * 10) }
+  q.close
+end
+total = 0
+while (v = q.pop)
+  total += v
+end
+producer.join
+puts total
+
+t = Thread.new(4) { |x| x * x }
+puts t.value
+sq = m.synchronize { count * 2 }
+puts sq
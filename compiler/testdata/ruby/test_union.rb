@@ -0,0 +1,35 @@
+def pick(flag)
+  if flag
+    x = 42
+  else
+    x = "forty-two"
+  end
+  x
+end
+
+def describe(flag)
+  v = pick(flag)
+  case v
+  when Integer
+    n = v + 1
+    "int #{n}"
+  when String
+    "str #{v.upcase}"
+  end
+end
+
+y = pick(false)
+puts y.to_s.length
+puts describe(true)
+puts describe(false)
+
+[1, 2].each do |i|
+  w = i * 2
+  puts w.abs
+end
+%w[a b].each do |s|
+  w = s + "!"
+  puts w.upcase
+end
+puts y == "forty-two"
+puts y == 42
@@ -0,0 +1,212 @@
+package compiler
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/redneckbeard/thanos/bst"
+	"github.com/redneckbeard/thanos/parser"
+	"github.com/redneckbeard/thanos/types"
+)
+
+// compileUnion emits the sealed interface for a union type, and for each
+// member a wrapper struct that implements it:
+//
+//	type IntegerOrString interface{ isIntegerOrString() }
+//	type IntegerOrStringInteger struct{ Val int }
+//	func (IntegerOrStringInteger) isIntegerOrString() {}
+//	func (w IntegerOrStringInteger) String() string { return strconv.Itoa(w.Val) }
+//
+// The String method lets wrapped values print the way Ruby would print the
+// underlying value.
+func (g *GoProgram) compileUnion(u *types.Union) []ast.Decl {
+	marker := &ast.Field{
+		Names: []*ast.Ident{g.it.Get(u.MarkerMethod())},
+		Type:  &ast.FuncType{Params: &ast.FieldList{}},
+	}
+	decls := []ast.Decl{&ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{&ast.TypeSpec{
+			Name: g.it.Get(u.Name()),
+			Type: &ast.InterfaceType{Methods: &ast.FieldList{List: []*ast.Field{marker}}},
+		}},
+	}}
+	for _, m := range u.Members {
+		wrapper := g.it.Get(u.WrapperName(m))
+		decls = append(decls, &ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{&ast.TypeSpec{
+				Name: wrapper,
+				Type: &ast.StructType{Fields: &ast.FieldList{List: []*ast.Field{{
+					Names: []*ast.Ident{g.it.Get("Val")},
+					Type:  g.it.Get(m.GoType()),
+				}}}},
+			}},
+		})
+		decls = append(decls, &ast.FuncDecl{
+			Recv: &ast.FieldList{List: []*ast.Field{{Type: wrapper}}},
+			Name: g.it.Get(u.MarkerMethod()),
+			Type: &ast.FuncType{Params: &ast.FieldList{}},
+			Body: &ast.BlockStmt{},
+		})
+		decls = append(decls, &ast.FuncDecl{
+			Recv: &ast.FieldList{List: []*ast.Field{{Names: []*ast.Ident{g.it.Get("w")}, Type: wrapper}}},
+			Name: g.it.Get("String"),
+			Type: &ast.FuncType{
+				Params:  &ast.FieldList{},
+				Results: &ast.FieldList{List: []*ast.Field{{Type: g.it.Get("string")}}},
+			},
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ReturnStmt{Results: []ast.Expr{g.unionMemberString(m, bst.Dot("w", "Val"))}},
+			}},
+		})
+	}
+	return decls
+}
+
+// unionMemberString converts a wrapped value to a string using the member's
+// own to_s where it has one.
+func (g *GoProgram) unionMemberString(m types.Type, val ast.Expr) ast.Expr {
+	if m == types.StringType {
+		return val
+	}
+	if m.HasMethod("to_s") {
+		if ret, err := m.MethodReturnType("to_s", nil, nil); err == nil && ret == types.StringType {
+			transform := m.TransformAST("to_s", val, nil, nil, g.it)
+			if transform.Expr != nil && len(transform.Stmts) == 0 {
+				g.AddImports(transform.Imports...)
+				return transform.Expr
+			}
+		}
+	}
+	g.AddImports("fmt")
+	return bst.Call("fmt", "Sprint", val)
+}
+
+// compileUnions emits declarations for every union inferred for the program.
+func (g *GoProgram) compileUnions() []ast.Decl {
+	var decls []ast.Decl
+	for _, u := range parser.Unions {
+		decls = append(decls, g.compileUnion(u)...)
+	}
+	return decls
+}
+
+// unionLocal returns the union type of a local variable, or nil if the
+// variable doesn't hold a union.
+func (g *GoProgram) unionLocal(name string) *types.Union {
+	local := g.ScopeChain.ResolveVar(name)
+	if local == nil || local == parser.BadLocal {
+		return nil
+	}
+	u, _ := local.Type().(*types.Union)
+	return u
+}
+
+// wrapUnionValue puts a value of one of a union's member types in the
+// member's wrapper struct. Values that are already of the union type pass
+// through.
+func (g *GoProgram) wrapUnionValue(u *types.Union, expr ast.Expr, t types.Type) ast.Expr {
+	if t != nil && u.Has(t) {
+		return u.Wrap(expr, t, g.it)
+	}
+	return expr
+}
+
+// hoistUnionLocals declares union variables first assigned inside the
+// branches of a conditional ahead of it, so that the value assigned on either
+// branch is visible after the conditional.
+func (g *GoProgram) hoistUnionLocals(n *parser.Condition) {
+	var walk func(stmts parser.Statements)
+	walk = func(stmts parser.Statements) {
+		for _, stmt := range stmts {
+			switch s := stmt.(type) {
+			case *parser.AssignmentNode:
+				if s.Reassignment || s.OpAssignment || len(s.Left) != 1 {
+					continue
+				}
+				if ident, ok := s.Left[0].(*parser.IdentNode); ok {
+					if u := g.unionLocal(ident.Val); u != nil {
+						g.appendToCurrentBlock(&ast.DeclStmt{
+							Decl: bst.Declare(token.VAR, g.it.Get(ident.Val), g.it.Get(u.GoType())),
+						})
+						s.Reassignment = true
+					}
+				}
+			case *parser.Condition:
+				g.hoistUnionLocals(s)
+			}
+		}
+	}
+	walk(n.True)
+	if n.False != nil {
+		switch f := n.False.(type) {
+		case *parser.Condition:
+			g.hoistUnionLocals(f)
+		case parser.Statements:
+			walk(f)
+		}
+	}
+}
+
+// compileUnionCase compiles `case x when Integer ... when String ... end` on
+// a union variable to a type switch over the member wrappers. Inside a clause
+// naming a single class, reads of x compile to the wrapper's Val.
+func (g *GoProgram) compileUnionCase(n *parser.CaseNode) {
+	name := n.Value.(*parser.IdentNode).Val
+	u := g.unionLocal(name)
+	v := g.it.New("v")
+	if g.unionCases == nil {
+		g.unionCases = map[string]*ast.Ident{}
+	}
+	g.newBlockStmt()
+	covered := map[string]bool{}
+	hasElse := false
+	for _, when := range n.Whens {
+		var list []ast.Expr
+		for _, cond := range when.Conditions {
+			cls, _ := types.ClassRegistry.Get(cond.(*parser.ConstantNode).Val)
+			for _, m := range u.Members {
+				if m.Equals(cls.Instance.(types.Type)) {
+					list = append(list, g.it.Get(u.WrapperName(m)))
+					covered[u.WrapperName(m)] = true
+				}
+			}
+		}
+		if len(list) == 0 {
+			hasElse = true
+		}
+		if len(list) == 1 {
+			g.unionCases[name] = v
+		}
+		g.appendToCurrentBlock(&ast.CaseClause{
+			List: list,
+			Body: g.CompileBlockStmt(when.Statements).List,
+		})
+		delete(g.unionCases, name)
+	}
+	// The interface is sealed, so a switch naming every member is exhaustive;
+	// say so for the benefit of functions returning from every clause.
+	if !hasElse && len(covered) == len(u.Members) {
+		g.appendToCurrentBlock(&ast.CaseClause{
+			Body: []ast.Stmt{&ast.ExprStmt{X: bst.Call(nil, "panic", bst.String("unreachable"))}},
+		})
+	}
+	body := g.BlockStack.Peek()
+	g.BlockStack.Pop()
+	stmt := &ast.TypeSwitchStmt{
+		Assign: &ast.ExprStmt{X: &ast.TypeAssertExpr{X: g.it.Get(name)}},
+		Body:   body,
+	}
+	bound := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		if node == v {
+			bound = true
+		}
+		return !bound
+	})
+	if bound {
+		stmt.Assign = bst.Define(v, &ast.TypeAssertExpr{X: g.it.Get(name)})
+	}
+	g.appendToCurrentBlock(stmt)
+}
@@ -50,22 +50,51 @@ func rubyPath() string {
 // Special value "diff" uses diff(1) instead of comm.
 var CommFlags = "-23"
 
-func CompareThanosToMRI(program, label string) (string, string, error) {
+// RunMRI runs program with system Ruby and returns what it printed to
+// stdout.
+func RunMRI(program string) (string, error) {
 	cmd := exec.Command(rubyPath())
 	cmd.Stdin = strings.NewReader(program)
 	var out, rubyErr bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &rubyErr
-	err := cmd.Run()
-	if err != nil {
-		return "", "", fmt.Errorf(`Failed to execute Ruby script:
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf(`Failed to execute Ruby script:
 %s
 
 Error: %s`, program, rubyErr.String())
 	}
+	return out.String(), nil
+}
+
+// DiffOutputs compares what Ruby printed with what the compiled Go printed,
+// using CommFlags, and returns the differences, if any.
+func DiffOutputs(rubyOut, goOut string) (string, error) {
+	rubyTmp, err := os.CreateTemp("", "ruby.results")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(rubyTmp.Name())
+	rubyTmp.WriteString(rubyOut)
+	rubyTmp.Close()
+	goTmp, err := os.CreateTemp("", "go.results")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(goTmp.Name())
+	goTmp.WriteString(goOut)
+	goTmp.Close()
+	return compareOutputs(rubyTmp.Name(), goTmp.Name())
+}
+
+func CompareThanosToMRI(program, label string) (string, string, error) {
+	rubyOut, err := RunMRI(program)
+	if err != nil {
+		return "", "", err
+	}
 	rubyTmp, _ := os.CreateTemp("", "ruby.results")
 	defer os.Remove(rubyTmp.Name())
-	rubyTmp.Write(out.Bytes())
+	rubyTmp.WriteString(rubyOut)
 
 	prog, err := parser.ParseString(program)
 	if err != nil {
@@ -87,8 +116,8 @@ Error: %s`, program, rubyErr.String())
 	defer os.Remove(goTmp.Name())
 	goTmp.WriteString(mainSrc)
 
-	cmd = exec.Command("go", "run", goTmp.Name())
-	out.Reset()
+	cmd := exec.Command("go", "run", goTmp.Name())
+	var out bytes.Buffer
 	cmd.Stdout = &out
 	var errBuf bytes.Buffer
 	cmd.Stderr = &errBuf
@@ -0,0 +1,522 @@
+// Package inventory surveys a Ruby codebase for what stands between it and
+// compiling with thanos: grammar thanos can't parse, methods it can't
+// resolve, requires it can't satisfy and metaprogramming it won't support.
+package inventory
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redneckbeard/thanos/compiler"
+	"github.com/redneckbeard/thanos/parser"
+)
+
+// A Report is the inventory of a project, ranked so that what blocks the
+// most code comes first.
+type Report struct {
+	Project         string     `json:"project"`
+	Generated       time.Time  `json:"generated"`
+	Files           int        `json:"files"`
+	Methods         Methods    `json:"methods"`
+	Unsupported     []Finding  `json:"unsupportedConstructs"`
+	Unresolved      []Receiver `json:"unresolvedMethods"`
+	Requires        []Finding  `json:"missingRequires"`
+	Metaprogramming []Finding  `json:"metaprogramming"`
+	// Other holds errors that fall into none of the categories above, by
+	// diagnostic code.
+	Other []Finding `json:"otherErrors"`
+}
+
+// Methods estimates how much of the project compiles: a method is counted
+// as clean when some program that loads it analyzed it without errors and,
+// if that program got that far, compiled it. Methods no program calls can't
+// be typed, so they are counted apart and left out of the percentage.
+type Methods struct {
+	Total    int           `json:"total"`
+	Clean    int           `json:"clean"`
+	Uncalled int           `json:"uncalled"`
+	Percent  float64       `json:"percent"`
+	Files    []FileMethods `json:"files"`
+}
+
+// FileMethods is the method count for one file, along with those that
+// don't compile and those that nothing calls.
+type FileMethods struct {
+	File     string   `json:"file"`
+	Total    int      `json:"total"`
+	Clean    int      `json:"clean"`
+	Failing  []string `json:"failing,omitempty"`
+	Uncalled []string `json:"uncalled,omitempty"`
+}
+
+// A Finding is one thing that stands in the way — a construct, a method, a
+// require — with everywhere it occurs.
+type Finding struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	// Example is one of the messages reported, for findings named by
+	// diagnostic code.
+	Example   string     `json:"example,omitempty"`
+	Locations []Location `json:"locations"`
+}
+
+// A Receiver groups the unresolved methods called on one type.
+type Receiver struct {
+	Type    string    `json:"type"`
+	Count   int       `json:"count"`
+	Methods []Finding `json:"methods"`
+}
+
+type Location struct {
+	File string `json:"file"`
+	Line int    `json:"line,omitempty"`
+}
+
+func (l Location) String() string {
+	if l.Line == 0 {
+		return l.File
+	}
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+type source struct {
+	path     string
+	lines    []string
+	defs     int      // methods defined, counted from the text for files that don't parse
+	requires []string // absolute paths of require_relative targets
+}
+
+var (
+	defPattern        = regexp.MustCompile(`^\s*def\s`)
+	endlessDefPattern = regexp.MustCompile(`^\s*def\s+[\w.]+[?!]?\s*(\([^)]*\))?\s*=[^=~>]`)
+	requireRelPattern = regexp.MustCompile(`^\s*require_relative\s*\(?\s*['"]([^'"]+)['"]`)
+)
+
+// Scan parses every Ruby file under dir in tolerant mode and inventories
+// what keeps it from compiling. Each file that no other requires is parsed
+// as the entry point of a program, so that library methods are typed by
+// their callers.
+func Scan(dir string) (*Report, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := readSources(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := &scan{
+		dir:         dir,
+		sources:     sources,
+		loaded:      map[string]bool{},
+		parsed:      map[string]bool{},
+		methods:     map[methodKey]*methodResult{},
+		seen:        map[string]bool{},
+		unsupported: map[string]*Finding{},
+		unresolved:  map[string]map[string]*Finding{},
+		requires:    map[string]*Finding{},
+		other:       map[string]*Finding{},
+	}
+
+	required := map[string]bool{}
+	var paths []string
+	for path, src := range sources {
+		paths = append(paths, path)
+		for _, dep := range src.requires {
+			if dep != path {
+				required[dep] = true
+			}
+		}
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if !required[path] {
+			s.program(path)
+		}
+	}
+	// Files only reachable through a require cycle are parsed on their own.
+	for _, path := range paths {
+		if !s.loaded[path] {
+			s.program(path)
+		}
+	}
+
+	report := &Report{
+		Project:         dir,
+		Generated:       time.Now(),
+		Files:           len(sources),
+		Unsupported:     ranked(s.unsupported),
+		Requires:        ranked(s.requires),
+		Metaprogramming: metaprogramming(sources, dir),
+		Other:           ranked(s.other),
+	}
+	for typ, methods := range s.unresolved {
+		r := Receiver{Type: typ, Methods: ranked(methods)}
+		for _, m := range r.Methods {
+			r.Count += m.Count
+		}
+		report.Unresolved = append(report.Unresolved, r)
+	}
+	sort.Slice(report.Unresolved, func(i, j int) bool {
+		a, b := report.Unresolved[i], report.Unresolved[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Type < b.Type
+	})
+	files := s.fileMethods()
+	for _, path := range paths {
+		fm := files[path]
+		if fm == nil {
+			continue
+		}
+		report.Methods.Total += fm.Total
+		report.Methods.Clean += fm.Clean
+		report.Methods.Uncalled += len(fm.Uncalled)
+		report.Methods.Files = append(report.Methods.Files, *fm)
+	}
+	// Files with the most methods that don't compile come first.
+	sort.SliceStable(report.Methods.Files, func(i, j int) bool {
+		a, b := report.Methods.Files[i], report.Methods.Files[j]
+		return len(a.Failing) > len(b.Failing)
+	})
+	if called := report.Methods.Total - report.Methods.Uncalled; called > 0 {
+		report.Methods.Percent = float64(report.Methods.Clean) * 100 / float64(called)
+	}
+	return report, nil
+}
+
+func readSources(dir string) (map[string]*source, error) {
+	sources := map[string]*source{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".rb" {
+			return nil
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		src := &source{path: path, lines: strings.Split(string(b), "\n")}
+		for _, line := range src.lines {
+			if defPattern.MatchString(line) {
+				src.defs++
+			}
+			if m := requireRelPattern.FindStringSubmatch(line); m != nil {
+				rel := m[1]
+				if !strings.HasSuffix(rel, ".rb") {
+					rel += ".rb"
+				}
+				src.requires = append(src.requires, filepath.Join(filepath.Dir(path), rel))
+			}
+		}
+		sources[path] = src
+		return nil
+	})
+	return sources, err
+}
+
+type scan struct {
+	dir     string
+	sources map[string]*source
+	loaded  map[string]bool // files some program has loaded
+	parsed  map[string]bool // files some program has parsed without syntax errors
+	// methods merges the results for each method across every program that
+	// loads its file.
+	methods     map[methodKey]*methodResult
+	seen        map[string]bool // diagnostics already counted
+	unsupported map[string]*Finding
+	unresolved  map[string]map[string]*Finding
+	requires    map[string]*Finding
+	other       map[string]*Finding
+}
+
+// A methodKey identifies a method across programs, each of which parses the
+// files it loads afresh.
+type methodKey struct {
+	file string
+	line int
+	name string
+}
+
+// methodResult is what the programs that load a method made of it: whether
+// any of them calls it, and whether any of them analyzed and compiled it.
+type methodResult struct {
+	called, clean bool
+}
+
+// program parses and, where analysis succeeds, compiles the program entered
+// at path, recording its errors and the state of the methods in the files
+// it loads.
+func (s *scan) program(path string) {
+	var (
+		root   *parser.Root
+		result *compiler.CompileResult
+		err    error
+	)
+	tolerant := parser.Tolerant
+	parser.Tolerant = true
+	diagnostics := parser.CollectDiagnostics(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("thanos crashed analyzing this program: %v", r)
+			}
+		}()
+		root, err = parser.ParseProgram(path)
+		if root != nil && err == nil && len(root.Errors) == 0 {
+			result, err = compiler.Compile(root)
+		}
+	})
+	parser.Tolerant = tolerant
+
+	var errs []error
+	if root != nil {
+		errs = append(errs, root.Errors...)
+	}
+	if err != nil && (len(errs) == 0 || errs[0] != err) {
+		errs = append(errs, err)
+	}
+	for _, e := range errs {
+		d := parser.DiagnosticFromError(e)
+		if d.File == "" {
+			d.File = path
+		}
+		diagnostics = append(diagnostics, d)
+	}
+
+	var errors []parser.Diagnostic
+	unparsed := map[string]bool{}
+	for _, d := range diagnostics {
+		if d.Severity != parser.SeverityError {
+			continue
+		}
+		errors = append(errors, d)
+		if d.Code == "syntax-error" {
+			unparsed[d.File] = true
+		}
+		// A method one program never calls may be called by another, so
+		// these are counted with the methods rather than as errors.
+		if d.Code == "uncalled-method" {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d:%s:%s", d.File, d.Line, d.Code, d.Message)
+		if !s.seen[key] {
+			s.seen[key] = true
+			s.record(d)
+		}
+	}
+
+	files := []string{path}
+	if root != nil {
+		files = root.Sources
+	}
+	for _, file := range files {
+		if _, ok := s.sources[file]; ok {
+			s.loaded[file] = true
+			if root != nil && !unparsed[file] {
+				s.parsed[file] = true
+			}
+		}
+	}
+	if root == nil {
+		return
+	}
+
+	failed := map[*parser.Method]bool{}
+	uncalled := map[*parser.Method]bool{}
+	for _, d := range errors {
+		if m, _ := root.MethodAt(d.File, d.Line); m != nil && d.Line <= s.methodEnd(m) {
+			failed[m] = true
+			if d.Code == "uncalled-method" {
+				uncalled[m] = true
+			}
+		}
+	}
+	for m, name := range root.Methods() {
+		if _, ok := s.sources[m.File()]; !ok || unparsed[m.File()] {
+			continue
+		}
+		key := methodKey{m.File(), m.LineNo(), name}
+		r := s.methods[key]
+		if r == nil {
+			r = &methodResult{}
+			s.methods[key] = r
+		}
+		if m.IsUncallable() || uncalled[m] {
+			continue
+		}
+		r.called = true
+		clean := m.Analyzed() && !failed[m]
+		if result != nil {
+			_, compiled := result.Methods[m]
+			clean = clean && compiled
+		}
+		r.clean = r.clean || clean
+	}
+}
+
+// fileMethods counts the methods in each file that a program loaded. A file
+// no program could parse is counted from its text, with none of its methods
+// clean.
+func (s *scan) fileMethods() map[string]*FileMethods {
+	files := map[string]*FileMethods{}
+	for file := range s.loaded {
+		files[file] = &FileMethods{File: s.relative(file)}
+		if !s.parsed[file] {
+			files[file].Total = s.sources[file].defs
+		}
+	}
+	for key, r := range s.methods {
+		fm := files[key.file]
+		if fm == nil || !s.parsed[key.file] {
+			continue
+		}
+		fm.Total++
+		desc := fmt.Sprintf("%s (line %d)", key.name, key.line)
+		switch {
+		case r.clean:
+			fm.Clean++
+		case r.called:
+			fm.Failing = append(fm.Failing, desc)
+		default:
+			fm.Uncalled = append(fm.Uncalled, desc)
+		}
+	}
+	for _, fm := range files {
+		sort.Strings(fm.Failing)
+		sort.Strings(fm.Uncalled)
+	}
+	return files
+}
+
+// methodEnd returns the line of the end of m, found as the first line after
+// its def indented no further that starts with "end". Errors after it are
+// in whatever follows the method.
+func (s *scan) methodEnd(m *parser.Method) int {
+	src, ok := s.sources[m.File()]
+	if !ok || m.LineNo() < 1 || m.LineNo() > len(src.lines) {
+		return m.LineNo()
+	}
+	def := src.lines[m.LineNo()-1]
+	indent := len(def) - len(strings.TrimLeft(def, " \t"))
+	if endlessDefPattern.MatchString(def) || strings.HasSuffix(strings.TrimSpace(def), "end") {
+		return m.LineNo()
+	}
+	for i := m.LineNo(); i < len(src.lines); i++ {
+		line := src.lines[i]
+		trimmed := strings.TrimLeft(line, " \t")
+		if len(line)-len(trimmed) <= indent && strings.HasPrefix(trimmed, "end") {
+			return i + 1
+		}
+	}
+	return len(src.lines)
+}
+
+var (
+	// The messages thanos reports for unresolved methods, from which the
+	// receiver and method name are recovered.
+	unknownMethodPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`^No known method '(.+)' on (.+)$`),
+		regexp.MustCompile("^No method `(.+)` on type (.+)$"),
+	}
+	selfMethodPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`^Attempted to call undefined method '(.+)'$`),
+		regexp.MustCompile(`^Tried calling method '(.+)' inside but no such method exists$`),
+	}
+	requireNamePattern = regexp.MustCompile(`require '([^']+)'`)
+)
+
+func (s *scan) record(d parser.Diagnostic) {
+	loc := Location{s.relative(d.File), d.Line}
+	switch d.Code {
+	case "syntax-error", "unsupported-construct":
+		add(s.unsupported, d.Message, loc)
+	case "missing-require":
+		name := d.Message
+		if m := requireNamePattern.FindStringSubmatch(d.Message); m != nil {
+			name = m[1]
+		}
+		add(s.requires, name, loc)
+	case "unknown-method":
+		typ, method := "unknown", d.Message
+		for _, p := range unknownMethodPatterns {
+			if m := p.FindStringSubmatch(d.Message); m != nil {
+				typ, method = m[2], m[1]
+			}
+		}
+		for _, p := range selfMethodPatterns {
+			if m := p.FindStringSubmatch(d.Message); m != nil {
+				typ, method = "self", m[1]
+			}
+		}
+		if s.unresolved[typ] == nil {
+			s.unresolved[typ] = map[string]*Finding{}
+		}
+		add(s.unresolved[typ], method, loc)
+	default:
+		add(s.other, d.Code, loc)
+		if f := s.other[d.Code]; f.Example == "" {
+			f.Example = d.Message
+		}
+	}
+}
+
+func (s *scan) relative(path string) string {
+	return relativeTo(s.dir, path)
+}
+
+// relativeTo shows path relative to the project directory when it is inside
+// it.
+func relativeTo(dir, path string) string {
+	if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+func add(findings map[string]*Finding, name string, loc Location) {
+	f, ok := findings[name]
+	if !ok {
+		f = &Finding{Name: name}
+		findings[name] = f
+	}
+	f.Count++
+	f.Locations = append(f.Locations, loc)
+}
+
+// ranked orders findings by how often they occur, and their locations by
+// file and line.
+func ranked(findings map[string]*Finding) []Finding {
+	var list []Finding
+	for _, f := range findings {
+		sort.Slice(f.Locations, func(i, j int) bool {
+			a, b := f.Locations[i], f.Locations[j]
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return a.Line < b.Line
+		})
+		list = append(list, *f)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
@@ -0,0 +1,227 @@
+package inventory
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeProject(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestScan(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"main.rb": `require_relative "lib/shapes"
+require_relative "lib/util"
+require "nokogiri"
+sq = Square.new(3)
+puts sq.area
+puts sq.describe
+puts double(4)
+`,
+		"lib/shapes.rb": `class Square
+  def initialize(side)
+    @side = side
+  end
+
+  def area
+    @side * @side
+  end
+
+  def describe
+    "square of #{@side.frobnicate}"
+  end
+
+  def method_missing(name, *args)
+    send(:area)
+  end
+end
+`,
+		"lib/util.rb": `def double(x)
+  x * 2
+end
+
+def never_called(y)
+  y + 1
+end
+`,
+		"other.rb": `def greet(name)
+  "hi #{name}"
+end
+puts greet("bob")
+x = [1, 2].zork
+`,
+		"broken.rb": `BEGIN { puts 1 }
+def foo
+  1
+end
+`,
+	})
+
+	report, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if report.Files != 5 {
+		t.Errorf("expected 5 files, got %d", report.Files)
+	}
+	// initialize, area, double and greet compile; describe and broken.rb's
+	// foo don't, and nothing calls method_missing or never_called.
+	if report.Methods.Total != 8 || report.Methods.Clean != 4 || report.Methods.Uncalled != 2 || report.Methods.Percent != 400.0/6 {
+		t.Errorf("expected 4 of 6 called methods clean with 2 uncalled, got %d of %d with %d uncalled (%.0f%%)", report.Methods.Clean, report.Methods.Total, report.Methods.Uncalled, report.Methods.Percent)
+	}
+
+	finding := func(category string, findings []Finding, name string) Finding {
+		t.Helper()
+		for _, f := range findings {
+			if f.Name == name {
+				return f
+			}
+		}
+		t.Fatalf("no %s finding %q in %+v", category, name, findings)
+		return Finding{}
+	}
+	location := func(f Finding, want string) {
+		t.Helper()
+		if len(f.Locations) != 1 || f.Locations[0].String() != want {
+			t.Errorf("expected %s to be found at %s, got %v", f.Name, want, f.Locations)
+		}
+	}
+
+	if len(report.Unsupported) != 1 {
+		t.Fatalf("expected 1 unsupported construct, got %+v", report.Unsupported)
+	}
+	location(report.Unsupported[0], "broken.rb:1")
+
+	if len(report.Unresolved) != 1 || report.Unresolved[0].Type != "Array(IntType)" {
+		t.Fatalf("expected unresolved methods on Array(IntType), got %+v", report.Unresolved)
+	}
+	location(finding("unresolved", report.Unresolved[0].Methods, "zork"), "other.rb:5")
+
+	location(finding("require", report.Requires, "nokogiri"), "main.rb:3")
+
+	location(finding("metaprogramming", report.Metaprogramming, "method_missing"), "lib/shapes.rb:14")
+	location(finding("metaprogramming", report.Metaprogramming, "send"), "lib/shapes.rb:15")
+
+	for _, f := range report.Other {
+		if f.Name == "uncalled-method" {
+			t.Errorf("expected uncalled methods to be counted with the methods, not as errors, got %+v", f)
+		}
+	}
+
+	for _, f := range report.Methods.Files {
+		if f.File == "other.rb" && len(f.Failing) > 0 {
+			t.Errorf("errors after greet's end should not count against it, got %v", f.Failing)
+		}
+		if f.File == "lib/util.rb" && (len(f.Failing) > 0 || strings.Join(f.Uncalled, ",") != "never_called (line 5)") {
+			t.Errorf("expected never_called to be reported as uncalled rather than failing, got %+v", f)
+		}
+	}
+
+	var zork bool
+	for _, d := range report.Diagnostics() {
+		if d.Code == "unknown-method" && d.File == "other.rb" && d.Line == 5 && d.Message == "No known method 'zork' on Array(IntType)" {
+			zork = true
+		}
+	}
+	if !zork {
+		t.Errorf("expected a diagnostic for zork at other.rb:5, got %+v", report.Diagnostics())
+	}
+
+	var buf bytes.Buffer
+	report.WriteMarkdown(&buf)
+	for _, want := range []string{
+		"**An estimated 67% of methods compile cleanly** (4 of 6, not counting 2 that nothing calls).",
+		"| lib/util.rb | 2 | 1 |  | `never_called (line 5)` |",
+		"| Array(IntType) | `zork` | 1 | other.rb:5 |",
+		"| nokogiri | 1 | main.rb:3 |",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected Markdown report to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+// A method is clean if any program that loads its file compiles it, not just
+// the first one scanned.
+func TestScanMergesPrograms(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"lib/calc.rb": `class Calc
+  def add(a, b)
+    a + b
+  end
+
+  def div(a, b)
+    a / b
+  end
+
+  def mod(a, b)
+    a % b
+  end
+end
+`,
+		"test/calc_spec.rb": `require_relative "../lib/calc"
+puts Calc.new.add(1, 2)
+`,
+		"test/calc_test.rb": `require_relative "../lib/calc"
+puts Calc.new.add(1, 2)
+puts Calc.new.div(6, 3)
+`,
+	})
+
+	report, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	for _, f := range report.Methods.Files {
+		if f.File != "lib/calc.rb" {
+			continue
+		}
+		if f.Total != 3 || f.Clean != 2 || len(f.Failing) > 0 || strings.Join(f.Uncalled, ",") != "Calc#mod (line 10)" {
+			t.Errorf("expected add and div clean and mod uncalled, got %+v", f)
+		}
+		return
+	}
+	t.Errorf("expected methods for lib/calc.rb, got %+v", report.Methods.Files)
+}
+
+func TestMetaprogramming(t *testing.T) {
+	src := &source{lines: strings.Split(`obj.send(:foo)
+puts "call send here" # or define_method
+x = :define_method
+=begin
+instance_eval
+=end
+klass = Class.new(Base)
+def self.inherited(sub)
+end`, "\n")}
+	findings := metaprogramming(map[string]*source{"/p/a.rb": src}, "/p")
+
+	got := map[string]string{}
+	for _, f := range findings {
+		got[f.Name] = f.Locations[0].String()
+	}
+	want := map[string]string{"send": "a.rb:1", "Class.new": "a.rb:7", "inherited": "a.rb:8"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for name, loc := range want {
+		if got[name] != loc {
+			t.Errorf("expected %s at %s, got %q", name, loc, got[name])
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package inventory
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxLocations is how many locations a Markdown table cell lists before
+// summarizing the rest; the JSON report has them all.
+const maxLocations = 5
+
+// WriteMarkdown writes the report as a Markdown document.
+func (r *Report) WriteMarkdown(w io.Writer) {
+	fmt.Fprintf(w, "# Porting readiness: %s\n\n", r.Project)
+	fmt.Fprintf(w, "Generated %s from %d Ruby files.\n\n", r.Generated.Format("2006-01-02 15:04"), r.Files)
+	fmt.Fprintf(w, "**An estimated %.0f%% of methods compile cleanly** (%d of %d", r.Methods.Percent, r.Methods.Clean, r.Methods.Total-r.Methods.Uncalled)
+	if r.Methods.Uncalled > 0 {
+		fmt.Fprintf(w, ", not counting %d that nothing calls", r.Methods.Uncalled)
+	}
+	fmt.Fprint(w, ").\n")
+
+	section(w, "Unsupported constructs", "Construct", r.Unsupported)
+
+	fmt.Fprint(w, "\n## Unresolved methods\n\n")
+	if len(r.Unresolved) == 0 {
+		fmt.Fprint(w, "None.\n")
+	} else {
+		fmt.Fprint(w, "| Receiver | Method | Count | Locations |\n|---|---|---|---|\n")
+		for _, rcvr := range r.Unresolved {
+			for _, m := range rcvr.Methods {
+				fmt.Fprintf(w, "| %s | `%s` | %d | %s |\n", cell(rcvr.Type), m.Name, m.Count, locations(m.Locations))
+			}
+		}
+	}
+
+	section(w, "Requires with no facade or gem source", "Require", r.Requires)
+	section(w, "Metaprogramming", "Use", r.Metaprogramming)
+	section(w, "Other errors", "Code", r.Other)
+
+	fmt.Fprint(w, "\n## Methods by file\n\n")
+	if len(r.Methods.Files) == 0 {
+		fmt.Fprint(w, "None.\n")
+		return
+	}
+	fmt.Fprint(w, "| File | Methods | Clean | Not compiling | Never called |\n|---|---|---|---|---|\n")
+	for _, f := range r.Methods.Files {
+		fmt.Fprintf(w, "| %s | %d | %d | %s | %s |\n", f.File, f.Total, f.Clean, methodList(f.Failing), methodList(f.Uncalled))
+	}
+}
+
+func methodList(methods []string) string {
+	quoted := make([]string, len(methods))
+	for i, m := range methods {
+		quoted[i] = "`" + m + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func section(w io.Writer, title, column string, findings []Finding) {
+	fmt.Fprintf(w, "\n## %s\n\n", title)
+	if len(findings) == 0 {
+		fmt.Fprint(w, "None.\n")
+		return
+	}
+	fmt.Fprintf(w, "| %s | Count | Locations |\n|---|---|---|\n", column)
+	for _, f := range findings {
+		name := cell(f.Name)
+		if f.Example != "" {
+			name = fmt.Sprintf("%s (e.g. %s)", name, cell(f.Example))
+		}
+		fmt.Fprintf(w, "| %s | %d | %s |\n", name, f.Count, locations(f.Locations))
+	}
+}
+
+func locations(locs []Location) string {
+	var shown []string
+	for i, loc := range locs {
+		if i == maxLocations {
+			shown = append(shown, fmt.Sprintf("and %d more", len(locs)-maxLocations))
+			break
+		}
+		shown = append(shown, loc.String())
+	}
+	return strings.Join(shown, ", ")
+}
+
+// cell escapes text for a Markdown table cell.
+func cell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,62 @@
+package inventory
+
+import (
+	"regexp"
+	"strings"
+)
+
+// metaprogrammingPatterns match the reflective and code-generating parts of
+// Ruby that a static translation can't follow.
+var metaprogrammingPatterns = map[string]*regexp.Regexp{}
+
+func init() {
+	for _, name := range []string{
+		"define_method", "define_singleton_method", "method_missing", "respond_to_missing?",
+		"send", "__send__", "public_send",
+		"instance_variable_get", "instance_variable_set", "instance_variables",
+		"instance_eval", "instance_exec", "class_eval", "class_exec", "module_eval", "module_exec", "eval",
+		"const_get", "const_set", "const_missing", "remove_method", "undef_method", "ObjectSpace",
+	} {
+		// Not a symbol, instance variable or part of a longer name.
+		metaprogrammingPatterns[name] = regexp.MustCompile(`(?:^|[^\w:@$])` + regexp.QuoteMeta(name) + `(?:[^\w?!:]|$)`)
+	}
+	metaprogrammingPatterns["Class.new"] = regexp.MustCompile(`\bClass\.new\b`)
+	metaprogrammingPatterns["Module.new"] = regexp.MustCompile(`\bModule\.new\b`)
+	for _, hook := range []string{"inherited", "included", "extended", "prepended", "method_added"} {
+		metaprogrammingPatterns[hook] = regexp.MustCompile(`^\s*def\s+self\.` + hook + `\b`)
+	}
+}
+
+var (
+	stringLiteral = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`)
+	comment       = regexp.MustCompile(`#[^{].*$|#$`)
+)
+
+// metaprogramming finds uses of metaprogramming in the text of each source,
+// so that files thanos can't parse are covered too. String literals and
+// comments are skipped.
+func metaprogramming(sources map[string]*source, dir string) []Finding {
+	findings := map[string]*Finding{}
+	for path, src := range sources {
+		inDoc := false
+		for i, line := range src.lines {
+			switch {
+			case strings.HasPrefix(line, "=begin"):
+				inDoc = true
+				continue
+			case strings.HasPrefix(line, "=end"):
+				inDoc = false
+				continue
+			case inDoc:
+				continue
+			}
+			code := comment.ReplaceAllString(stringLiteral.ReplaceAllString(line, `""`), "")
+			for name, pattern := range metaprogrammingPatterns {
+				if pattern.MatchString(code) {
+					add(findings, name, Location{relativeTo(dir, path), i + 1})
+				}
+			}
+		}
+	}
+	return ranked(findings)
+}
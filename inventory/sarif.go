@@ -0,0 +1,41 @@
+package inventory
+
+import (
+	"fmt"
+
+	"github.com/redneckbeard/thanos/parser"
+)
+
+// Diagnostics turns every location of every finding into a diagnostic, so
+// that the report can be written as SARIF for code scanning to annotate.
+func (r *Report) Diagnostics() []parser.Diagnostic {
+	var diagnostics []parser.Diagnostic
+	each := func(f Finding, severity parser.Severity, code, message string) {
+		for _, l := range f.Locations {
+			diagnostics = append(diagnostics, parser.Diagnostic{
+				Location: parser.Location{File: l.File, Line: l.Line},
+				Severity: severity,
+				Code:     code,
+				Message:  message,
+			})
+		}
+	}
+	for _, f := range r.Unsupported {
+		each(f, parser.SeverityError, "unsupported-construct", f.Name)
+	}
+	for _, rcvr := range r.Unresolved {
+		for _, m := range rcvr.Methods {
+			each(m, parser.SeverityError, "unknown-method", fmt.Sprintf("No known method '%s' on %s", m.Name, rcvr.Type))
+		}
+	}
+	for _, f := range r.Requires {
+		each(f, parser.SeverityError, "missing-require", fmt.Sprintf("cannot locate source for require '%s' (no facade and no gem source found)", f.Name))
+	}
+	for _, f := range r.Metaprogramming {
+		each(f, parser.SeverityWarning, "metaprogramming", fmt.Sprintf("Metaprogramming with %s", f.Name))
+	}
+	for _, f := range r.Other {
+		each(f, parser.SeverityError, f.Name, f.Example)
+	}
+	return diagnostics
+}
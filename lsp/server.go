@@ -0,0 +1,475 @@
+// Package lsp implements a language server that reports what thanos infers
+// about Ruby as it is written: the types of locals, instance variables and
+// methods on hover, diagnostics for whatever won't compile, and the Go that
+// a method compiles to.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redneckbeard/thanos/compiler"
+	"github.com/redneckbeard/thanos/parser"
+)
+
+// GeneratedGoCommand is the workspace command that returns the Go for the
+// method at a position. It takes a single TextDocumentPositionParams
+// argument and returns a GeneratedGo, or null outside of any method.
+const GeneratedGoCommand = "thanos.generatedGo"
+
+// Server is a language server speaking JSON-RPC over a pair of streams.
+// Everything runs on the goroutine that calls Run, since analysis relies on
+// package-level state in the parser.
+type Server struct {
+	// Delay is how long to wait after a document changes before analyzing
+	// it again, so that typing doesn't start an analysis on every keystroke.
+	// Requests about a changed document analyze it immediately.
+	Delay time.Duration
+
+	in      *bufio.Reader
+	out     io.Writer
+	docs    map[string]*document
+	changed bool
+}
+
+// document is an open text document and the results of its last analysis.
+type document struct {
+	uri, path string
+	text      string
+	dirty     bool
+	// root is the most recent analysis that can describe the source. When
+	// an edit leaves the program unparseable, the previous one is kept so
+	// that hovers keep working while the edit is finished.
+	root      *parser.Root
+	result    *compiler.CompileResult // nil unless the current text compiled
+	err       error
+	published map[string]bool // URIs that last received diagnostics for this document
+}
+
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{
+		Delay: 300 * time.Millisecond,
+		in:    bufio.NewReader(in),
+		out:   out,
+		docs:  map[string]*document{},
+	}
+}
+
+// Run serves requests until the client sends exit or closes the input.
+func (s *Server) Run() error {
+	msgs := make(chan *message)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := s.read()
+			if err != nil {
+				errs <- err
+				return
+			}
+			msgs <- msg
+		}
+	}()
+	var analyze <-chan time.Time
+	for {
+		select {
+		case msg := <-msgs:
+			if msg.Method == "exit" {
+				return nil
+			}
+			s.handle(msg)
+			if s.changed {
+				s.changed = false
+				analyze = time.After(s.Delay)
+			}
+		case <-analyze:
+			analyze = nil
+			for _, uri := range s.uris() {
+				if doc := s.docs[uri]; doc.dirty {
+					s.analyze(doc)
+				}
+			}
+		case err := <-errs:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (s *Server) read() (*message, error) {
+	length := -1
+	for {
+		line, err := s.in.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if v, ok := strings.CutPrefix(line, "Content-Length:"); ok {
+			if length, err = strconv.Atoi(strings.TrimSpace(v)); err != nil {
+				return nil, fmt.Errorf("bad Content-Length header %q", line)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("message without a Content-Length header")
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.in, body); err != nil {
+		return nil, err
+	}
+	msg := &message{}
+	if err := json.Unmarshal(body, msg); err != nil {
+		return nil, fmt.Errorf("malformed message: %w", err)
+	}
+	return msg, nil
+}
+
+func (s *Server) write(msg *message) {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lsp: %v\n", err)
+		return
+	}
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func (s *Server) reply(req *message, result interface{}) {
+	b, err := json.Marshal(result)
+	if err != nil {
+		s.replyError(req, codeInternalError, err.Error())
+		return
+	}
+	s.write(&message{ID: req.ID, Result: b})
+}
+
+func (s *Server) replyError(req *message, code int, format string, args ...interface{}) {
+	s.write(&message{ID: req.ID, Error: &responseError{Code: code, Message: fmt.Sprintf(format, args...)}})
+}
+
+func (s *Server) notify(method string, params interface{}) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lsp: %v\n", err)
+		return
+	}
+	s.write(&message{Method: method, Params: b})
+}
+
+func (s *Server) handle(msg *message) {
+	switch msg.Method {
+	case "initialize":
+		s.reply(msg, map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync": map[string]interface{}{
+					"openClose": true,
+					"change":    2, // incremental
+				},
+				"hoverProvider": true,
+				"executeCommandProvider": map[string]interface{}{
+					"commands": []string{GeneratedGoCommand},
+				},
+			},
+			"serverInfo": map[string]string{"name": "thanos"},
+		})
+	case "shutdown":
+		s.reply(msg, nil)
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			uri := params.TextDocument.URI
+			s.docs[uri] = &document{uri: uri, path: uriToPath(uri), text: params.TextDocument.Text, dirty: true}
+			s.changed = true
+		}
+	case "textDocument/didChange":
+		var params didChangeParams
+		if json.Unmarshal(msg.Params, &params) != nil {
+			return
+		}
+		doc, ok := s.docs[params.TextDocument.URI]
+		if !ok {
+			return
+		}
+		for _, change := range params.ContentChanges {
+			if change.Range == nil {
+				doc.text = change.Text
+			} else {
+				start, end := offsetOf(doc.text, change.Range.Start), offsetOf(doc.text, change.Range.End)
+				doc.text = doc.text[:start] + change.Text + doc.text[end:]
+			}
+		}
+		doc.dirty = true
+		s.changed = true
+	case "textDocument/didClose":
+		var params didCloseParams
+		if json.Unmarshal(msg.Params, &params) != nil {
+			return
+		}
+		if doc, ok := s.docs[params.TextDocument.URI]; ok {
+			delete(s.docs, doc.uri)
+			s.publish(doc, nil)
+		}
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			s.replyError(msg, codeInvalidParams, "%v", err)
+			return
+		}
+		s.reply(msg, s.hover(params))
+	case "workspace/executeCommand":
+		var params executeCommandParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			s.replyError(msg, codeInvalidParams, "%v", err)
+			return
+		}
+		if params.Command != GeneratedGoCommand {
+			s.replyError(msg, codeInvalidParams, "unknown command %q", params.Command)
+			return
+		}
+		var pos TextDocumentPositionParams
+		if len(params.Arguments) != 1 || json.Unmarshal(params.Arguments[0], &pos) != nil {
+			s.replyError(msg, codeInvalidParams, "%s takes a single text document position", GeneratedGoCommand)
+			return
+		}
+		generated, err := s.generatedGo(pos)
+		if err != nil {
+			s.replyError(msg, codeInternalError, "%v", err)
+			return
+		}
+		s.reply(msg, generated)
+	default:
+		// Notifications we don't handle are ignored; requests get an error.
+		if msg.ID != nil {
+			s.replyError(msg, codeMethodNotFound, "method %q not supported", msg.Method)
+		}
+	}
+}
+
+// uris returns the URIs of open documents in a stable order.
+func (s *Server) uris() []string {
+	var uris []string
+	for uri := range s.docs {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// analyze parses, analyzes and compiles the program rooted at doc, reading
+// open documents from their buffers rather than from disk, and publishes the
+// resulting diagnostics.
+func (s *Server) analyze(doc *document) {
+	doc.dirty = false
+	parser.Overlay = map[string][]byte{}
+	for _, d := range s.docs {
+		parser.Overlay[d.path] = []byte(d.text)
+	}
+	defer func() { parser.Overlay = nil }()
+
+	var (
+		root   *parser.Root
+		result *compiler.CompileResult
+		err    error
+	)
+	diagnostics := parser.CollectDiagnostics(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("thanos crashed analyzing this program: %v", r)
+			}
+		}()
+		root, err = parser.ParseProgram(doc.path)
+		if err == nil {
+			result, err = compiler.Compile(root)
+		}
+	})
+	var errs []error
+	if root != nil {
+		errs = append(errs, root.Errors...)
+	}
+	if err != nil && (len(errs) == 0 || errs[0] != err) {
+		errs = append(errs, err)
+	}
+	for _, e := range errs {
+		diagnostics = append(diagnostics, parser.DiagnosticFromError(e))
+	}
+
+	if root != nil && (err == nil || doc.root == nil) {
+		doc.root = root
+	}
+	doc.result, doc.err = result, err
+	s.publish(doc, diagnostics)
+}
+
+// publish sends diagnostics for each file they concern, and clears those
+// previously sent to files that no longer have any.
+func (s *Server) publish(doc *document, diagnostics []parser.Diagnostic) {
+	byURI := map[string][]Diagnostic{}
+	if diagnostics != nil {
+		byURI[doc.uri] = []Diagnostic{}
+	}
+	seen := map[string]bool{}
+	for _, d := range diagnostics {
+		if d.File == "" {
+			d.File = doc.path
+		}
+		key := d.File + "\x00" + d.String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		uri := pathToURI(d.File)
+		byURI[uri] = append(byURI[uri], s.convert(d))
+	}
+	for uri := range doc.published {
+		if _, ok := byURI[uri]; !ok {
+			byURI[uri] = []Diagnostic{}
+		}
+	}
+	var uris []string
+	for uri := range byURI {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	doc.published = map[string]bool{}
+	for _, uri := range uris {
+		s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: byURI[uri]})
+		if len(byURI[uri]) > 0 {
+			doc.published[uri] = true
+		}
+	}
+}
+
+func (s *Server) convert(d parser.Diagnostic) Diagnostic {
+	diag := Diagnostic{
+		Range:    s.rangeOf(d.Location),
+		Severity: severityError,
+		Code:     d.Code,
+		Source:   "thanos",
+		Message:  d.Message,
+	}
+	switch d.Severity {
+	case parser.SeverityWarning:
+		diag.Severity = severityWarning
+	case parser.SeverityNote:
+		diag.Severity = severityInformation
+	}
+	for _, rel := range d.Related {
+		diag.RelatedInformation = append(diag.RelatedInformation, DiagnosticRelatedInformation{
+			Location: Location{URI: pathToURI(rel.File), Range: s.rangeOf(rel.Location)},
+			Message:  rel.Message,
+		})
+	}
+	return diag
+}
+
+// rangeOf covers the word a diagnostic points at, or the rest of its line if
+// it points at something else.
+func (s *Server) rangeOf(loc parser.Location) Range {
+	if loc.Line <= 0 {
+		return Range{}
+	}
+	line := lineOf(s.source(loc.File), loc.Line-1)
+	col := 0
+	if loc.Column > 0 {
+		col = loc.Column - 1
+	}
+	_, start, end := wordAt(line, col)
+	if start != col || end == col {
+		end = len([]rune(line))
+	}
+	return Range{
+		Start: Position{Line: loc.Line - 1, Character: utf16Column(line, col)},
+		End:   Position{Line: loc.Line - 1, Character: utf16Column(line, end)},
+	}
+}
+
+// source returns the text of path, from its buffer if it's open.
+func (s *Server) source(path string) string {
+	if doc, ok := s.docs[pathToURI(path)]; ok {
+		return doc.text
+	}
+	b, _ := os.ReadFile(path)
+	return string(b)
+}
+
+func (s *Server) hover(params TextDocumentPositionParams) *Hover {
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+	if doc.dirty {
+		s.analyze(doc)
+	}
+	if doc.root == nil {
+		return nil
+	}
+	line := lineOf(doc.text, params.Position.Line)
+	word, start, end := wordAt(line, runeColumn(line, params.Position.Character))
+	if word == "" {
+		return nil
+	}
+	sym, ok := doc.root.SymbolAt(doc.path, params.Position.Line+1, word)
+	if !ok {
+		return nil
+	}
+	value := fmt.Sprintf("```ruby\n%s\n```", sym.Detail)
+	if goType := s.goSignature(doc, sym); goType != "" {
+		value += fmt.Sprintf("\n```go\n%s\n```", goType)
+	}
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: value},
+		Range: &Range{
+			Start: Position{Line: params.Position.Line, Character: utf16Column(line, start)},
+			End:   Position{Line: params.Position.Line, Character: utf16Column(line, end)},
+		},
+	}
+}
+
+// goSignature is the Go counterpart of sym: the signature a method compiled
+// to, or the Go type of a variable.
+func (s *Server) goSignature(doc *document, sym parser.Symbol) string {
+	if sym.Method != nil && doc.result != nil {
+		for _, line := range strings.Split(doc.result.Methods[sym.Method], "\n") {
+			if strings.HasPrefix(line, "func ") {
+				return strings.TrimSuffix(line, " {")
+			}
+		}
+	}
+	if sym.Type == nil {
+		return ""
+	}
+	return sym.Type.GoType()
+}
+
+func (s *Server) generatedGo(params TextDocumentPositionParams) (*GeneratedGo, error) {
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return nil, fmt.Errorf("%s is not open", params.TextDocument.URI)
+	}
+	if doc.dirty {
+		s.analyze(doc)
+	}
+	if doc.result == nil {
+		return nil, fmt.Errorf("the program doesn't compile yet: %v", doc.err)
+	}
+	m, name := doc.root.MethodAt(doc.path, params.Position.Line+1)
+	if m == nil {
+		return nil, nil
+	}
+	src, ok := doc.result.Methods[m]
+	if !ok {
+		return nil, fmt.Errorf("%s isn't compiled because nothing calls it", name)
+	}
+	return &GeneratedGo{Method: name, Go: src}, nil
+}
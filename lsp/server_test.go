@@ -0,0 +1,167 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/redneckbeard/thanos/parser"
+)
+
+type client struct {
+	t   *testing.T
+	w   io.Writer
+	r   *bufio.Reader
+	ids int
+}
+
+func (c *client) send(msg map[string]interface{}) {
+	msg["jsonrpc"] = "2.0"
+	b, _ := json.Marshal(msg)
+	if _, err := io.WriteString(c.w, "Content-Length: "+strconv.Itoa(len(b))+"\r\n\r\n"+string(b)); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// next reads messages until one satisfies match.
+func (c *client) next(match func(*message) bool) *message {
+	s := &Server{in: c.r}
+	for {
+		msg, err := s.read()
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		if match(msg) {
+			return msg
+		}
+	}
+}
+
+func (c *client) call(method string, params interface{}, result interface{}) *responseError {
+	c.ids++
+	id := c.ids
+	c.send(map[string]interface{}{"id": id, "method": method, "params": params})
+	msg := c.next(func(m *message) bool { return m.ID != nil && string(*m.ID) == strconv.Itoa(id) })
+	if msg.Error == nil && result != nil {
+		json.Unmarshal(msg.Result, result)
+	}
+	return msg.Error
+}
+
+func (c *client) diagnostics() publishDiagnosticsParams {
+	msg := c.next(func(m *message) bool { return m.Method == "textDocument/publishDiagnostics" })
+	var params publishDiagnosticsParams
+	json.Unmarshal(msg.Params, &params)
+	return params
+}
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.rb")
+	uri := pathToURI(path)
+	src := `def add(a, b)
+  c = a + b
+  c
+end
+
+puts add(1, 2)
+puts x.foo
+`
+	os.WriteFile(path, []byte(src), 0644)
+	verbosity := parser.Verbosity
+	parser.Verbosity = 0
+	defer func() { parser.Verbosity = verbosity }()
+
+	clientIn, serverOut := io.Pipe()
+	serverIn, clientOut := io.Pipe()
+	server := NewServer(serverIn, serverOut)
+	server.Delay = 0
+	done := make(chan error)
+	go func() { done <- server.Run() }()
+	c := &client{t: t, w: clientOut, r: bufio.NewReader(clientIn)}
+
+	var init struct {
+		Capabilities struct {
+			HoverProvider          bool
+			ExecuteCommandProvider struct{ Commands []string }
+		}
+	}
+	c.call("initialize", map[string]interface{}{}, &init)
+	if !init.Capabilities.HoverProvider || len(init.Capabilities.ExecuteCommandProvider.Commands) != 1 {
+		t.Fatalf("unexpected capabilities %+v", init.Capabilities)
+	}
+
+	c.send(map[string]interface{}{"method": "textDocument/didOpen", "params": didOpenParams{TextDocument: TextDocumentItem{URI: uri, Version: 1, Text: src}}})
+	diags := c.diagnostics()
+	if diags.URI != uri || len(diags.Diagnostics) != 1 {
+		t.Fatalf("expected one diagnostic for %s, got %+v", uri, diags)
+	}
+	if d := diags.Diagnostics[0]; d.Code != "cannot-infer" || d.Range.Start != (Position{6, 5}) || d.Range.End != (Position{6, 6}) || d.Severity != severityError {
+		t.Errorf("expected cannot-infer error on x at 6:5, got %+v", d)
+	}
+
+	// Delete the last line.
+	c.send(map[string]interface{}{"method": "textDocument/didChange", "params": map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": uri, "version": 2},
+		"contentChanges": []interface{}{map[string]interface{}{"range": Range{Start: Position{6, 0}, End: Position{7, 0}}, "text": ""}},
+	}})
+	if diags := c.diagnostics(); len(diags.Diagnostics) != 0 {
+		t.Fatalf("expected diagnostics to clear, got %+v", diags)
+	}
+
+	for _, tt := range []struct {
+		pos      Position
+		expected []string
+	}{
+		{Position{2, 2}, []string{"c: IntType", "int"}},
+		{Position{5, 6}, []string{"add(a: IntType, b: IntType) -> IntType", "func Add(a, b int) int"}},
+	} {
+		var hover Hover
+		c.call("textDocument/hover", TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{uri}, Position: tt.pos}, &hover)
+		for _, s := range tt.expected {
+			if !strings.Contains(hover.Contents.Value, s) {
+				t.Errorf("expected hover at %+v to contain %q, got %q", tt.pos, s, hover.Contents.Value)
+			}
+		}
+	}
+
+	var generated GeneratedGo
+	if err := c.call("workspace/executeCommand", map[string]interface{}{
+		"command":   GeneratedGoCommand,
+		"arguments": []interface{}{TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{uri}, Position: Position{1, 0}}},
+	}, &generated); err != nil {
+		t.Fatal(err.Message)
+	}
+	if generated.Method != "add" || !strings.HasPrefix(generated.Go, "func Add(a, b int) int {") {
+		t.Errorf("expected the Go for add, got %+v", generated)
+	}
+
+	c.call("shutdown", nil, nil)
+	c.send(map[string]interface{}{"method": "exit"})
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOffsetOf(t *testing.T) {
+	text := "ab\nx😀y\n"
+	for _, tt := range []struct {
+		pos      Position
+		expected int
+	}{
+		{Position{0, 1}, 1},
+		{Position{1, 1}, 4},
+		{Position{1, 3}, 8}, // after the two UTF-16 units of the emoji
+		{Position{1, 10}, 9},
+		{Position{5, 0}, len(text)},
+	} {
+		if got := offsetOf(text, tt.pos); got != tt.expected {
+			t.Errorf("offsetOf(%+v) = %d, expected %d", tt.pos, got, tt.expected)
+		}
+	}
+}
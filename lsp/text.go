@@ -0,0 +1,103 @@
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+)
+
+// Positions in the protocol count UTF-16 code units, while thanos counts
+// runes; these helpers translate between the two.
+
+// offsetOf returns the byte offset of pos in text, clamped to the end of its
+// line.
+func offsetOf(text string, pos Position) int {
+	start := 0
+	for i := 0; i < pos.Line; i++ {
+		next := strings.IndexByte(text[start:], '\n')
+		if next < 0 {
+			return len(text)
+		}
+		start += next + 1
+	}
+	line := text[start:]
+	if end := strings.IndexByte(line, '\n'); end >= 0 {
+		line = line[:end]
+	}
+	units := 0
+	for i, r := range line {
+		if units >= pos.Character {
+			return start + i
+		}
+		units += utf16.RuneLen(r)
+	}
+	return start + len(line)
+}
+
+// lineOf returns the given 0-based line of text.
+func lineOf(text string, line int) string {
+	lines := strings.Split(text, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	return strings.TrimSuffix(lines[line], "\r")
+}
+
+// runeColumn converts a UTF-16 column on line to a rune index.
+func runeColumn(line string, character int) int {
+	units := 0
+	for i, r := range []rune(line) {
+		if units >= character {
+			return i
+		}
+		units += utf16.RuneLen(r)
+	}
+	return len([]rune(line))
+}
+
+// utf16Column converts a rune index on line to a UTF-16 column.
+func utf16Column(line string, col int) int {
+	runes := []rune(line)
+	if col > len(runes) {
+		col = len(runes)
+	}
+	return len(utf16.Encode(runes[:col]))
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r == '@' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r > 0x7f
+}
+
+// wordAt returns the identifier, instance variable or method name on line
+// that spans rune index col, with its start and end rune indexes.
+func wordAt(line string, col int) (string, int, int) {
+	runes := []rune(line)
+	if col > len(runes) {
+		col = len(runes)
+	}
+	start, end := col, col
+	for start > 0 && isWordRune(runes[start-1]) {
+		start--
+	}
+	for end < len(runes) && isWordRune(runes[end]) {
+		end++
+	}
+	// Predicate and bang methods keep their suffix.
+	if end < len(runes) && end > start && (runes[end] == '?' || runes[end] == '!') {
+		end++
+	}
+	return string(runes[start:end]), start, end
+}
+
+func pathToURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
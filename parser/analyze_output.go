@@ -5,6 +5,8 @@ import (
 	"io"
 	"sort"
 	"strings"
+
+	"github.com/redneckbeard/thanos/types"
 )
 
 // WriteAnnotations outputs the analyzed Ruby source with type annotations
@@ -64,9 +66,12 @@ func collectAnnotations(root *Root) map[int][]string {
 }
 
 func describeMethod(m *Method, name, prefix string, add func(int, string)) {
+	add(m.LineNo(), methodAnnotation(m, name, prefix))
+}
+
+func methodAnnotation(m *Method, name, prefix string) string {
 	if m.uncallable {
-		add(m.LineNo(), fmt.Sprintf("%s%s: uncallable (never called)", prefix, name))
-		return
+		return fmt.Sprintf("%s%s: uncallable (never called)", prefix, name)
 	}
 	var params []string
 	for _, p := range m.Params {
@@ -80,7 +85,7 @@ func describeMethod(m *Method, name, prefix string, add func(int, string)) {
 	if m.ReturnType() != nil {
 		ret = m.ReturnType().String()
 	}
-	add(m.LineNo(), fmt.Sprintf("%s%s(%s) -> %s", prefix, name, strings.Join(params, ", "), ret))
+	return fmt.Sprintf("%s%s(%s) -> %s", prefix, name, strings.Join(params, ", "), ret)
 }
 
 func describeClass(cls *Class, add func(int, string)) {
@@ -171,6 +176,140 @@ func describeNode(n Node, context string, add func(int, string)) {
 	}
 }
 
+// Symbol is what analysis inferred about a name in the source, for editor
+// hovers.
+type Symbol struct {
+	Kind   string     // "local", "ivar" or "method"
+	Detail string     // the name and its type, as 'thanos analyze' annotates it
+	Type   types.Type // the type of the local or ivar, or the method's return type
+	Method *Method    // the method, for methods
+}
+
+// definedMethod is a method along with the prefix that qualifies its name in
+// annotations, e.g. "Point#" or "Point.".
+type definedMethod struct {
+	*Method
+	prefix string
+	class  *Class
+}
+
+func (r *Root) definedMethods() []definedMethod {
+	var methods []definedMethod
+	for _, m := range r.MethodSetStack.Peek().Methods {
+		methods = append(methods, definedMethod{m, "", nil})
+	}
+	var addClass func(cls *Class)
+	addClass = func(cls *Class) {
+		for _, m := range cls.MethodSet.Methods {
+			methods = append(methods, definedMethod{m, cls.name + "#", cls})
+		}
+		for _, m := range cls.ClassMethods {
+			methods = append(methods, definedMethod{m, cls.name + ".", cls})
+		}
+	}
+	var addModule func(mod *Module)
+	addModule = func(mod *Module) {
+		for _, m := range mod.ClassMethods {
+			methods = append(methods, definedMethod{m, mod.name + ".", nil})
+		}
+		for _, cls := range mod.Classes {
+			addClass(cls)
+		}
+		for _, sub := range mod.Modules {
+			addModule(sub)
+		}
+	}
+	for _, cls := range r.Classes {
+		addClass(cls)
+	}
+	for _, mod := range r.TopLevelModules {
+		addModule(mod)
+	}
+	return methods
+}
+
+// Methods returns every method the program defines outside of gems, mapped
+// to its name qualified by class or module, e.g. "Point#x" or "Geo.area".
+func (r *Root) Methods() map[*Method]string {
+	methods := map[*Method]string{}
+	for _, m := range r.definedMethods() {
+		if !m.FromGem {
+			methods[m.Method] = m.prefix + m.Name
+		}
+	}
+	return methods
+}
+
+// MethodAt returns the method defined in file whose definition most closely
+// precedes lineNo, with its qualified name. Since nodes only record the line
+// they start on, a line after the end of a method still belongs to it.
+func (r *Root) MethodAt(file string, lineNo int) (*Method, string) {
+	if m := r.enclosingMethod(file, lineNo); m != nil {
+		return m.Method, m.prefix + m.Name
+	}
+	return nil, ""
+}
+
+func (r *Root) enclosingMethod(file string, lineNo int) *definedMethod {
+	var best *definedMethod
+	for _, m := range r.definedMethods() {
+		if m.File() != file || m.LineNo() > lineNo || m.FromGem {
+			continue
+		}
+		if best == nil || m.LineNo() > best.LineNo() {
+			best = &m
+		}
+	}
+	return best
+}
+
+// SymbolAt describes the local variable, instance variable or method called
+// name as seen from line lineNo of file.
+func (r *Root) SymbolAt(file string, lineNo int, name string) (Symbol, bool) {
+	enclosing := r.enclosingMethod(file, lineNo)
+	if ivar := strings.TrimPrefix(name, "@"); ivar != name {
+		if enclosing == nil || enclosing.class == nil {
+			return Symbol{}, false
+		}
+		if iv, ok := enclosing.class.IVarMap()[ivar]; ok && iv.Type() != nil {
+			return Symbol{Kind: "ivar", Detail: fmt.Sprintf("@%s: %s", ivar, iv.Type()), Type: iv.Type()}, true
+		}
+		return Symbol{}, false
+	}
+	scopes := []Scope{r.ScopeChain[0]}
+	if enclosing != nil {
+		scopes = []Scope{enclosing.Locals, r.ScopeChain[0]}
+	}
+	for _, scope := range scopes {
+		if local, ok := scope.Get(name); ok {
+			if _, isLocal := local.(*RubyLocal); isLocal && local.Type() != nil {
+				return Symbol{Kind: "local", Detail: fmt.Sprintf("%s: %s", name, local.Type()), Type: local.Type()}, true
+			}
+		}
+	}
+	// A method of the enclosing class wins over a top-level method, which
+	// wins over a method of any other class.
+	var found *definedMethod
+	rank := func(m *definedMethod) int {
+		switch {
+		case enclosing != nil && enclosing.class != nil && m.class == enclosing.class:
+			return 2
+		case m.prefix == "":
+			return 1
+		}
+		return 0
+	}
+	for _, m := range r.definedMethods() {
+		if m.Name == name && (found == nil || rank(&m) > rank(found)) {
+			found = &m
+		}
+	}
+	if found == nil {
+		return Symbol{}, false
+	}
+	return Symbol{Kind: "method", Detail: methodAnnotation(found.Method, found.Name, found.prefix), Type: found.ReturnType(), Method: found.Method}, true
+}
+
 // IVarMap returns the instance variable map for annotation output.
 func (cls *Class) IVarMap() map[string]*IVar {
 	return cls.ivars
@@ -34,6 +34,22 @@ func (n *AssignmentNode) String() string {
 func (n *AssignmentNode) Type() types.Type     { return n._type }
 func (n *AssignmentNode) SetType(t types.Type) { n._type = t }
 
+// nameSynthHashes records the variable a hash literal (or an array of hash
+// literals) is assigned to, so that a SynthStruct synthesized for it is named
+// after the variable.
+func nameSynthHashes(rhs Node, varName string) {
+	switch r := rhs.(type) {
+	case *HashNode:
+		r.structName = varName
+	case *ArrayNode:
+		for _, arg := range r.Args {
+			if h, ok := arg.(*HashNode); ok {
+				h.structName = varName
+			}
+		}
+	}
+}
+
 func (n *AssignmentNode) TargetType(scope ScopeChain, class *Class) (types.Type, error) {
 	var typelist []types.Type
 	for i, left := range n.Left {
@@ -42,6 +58,9 @@ func (n *AssignmentNode) TargetType(scope ScopeChain, class *Class) (types.Type,
 		case *IdentNode:
 			localName = lhs.Val
 			GetType(lhs, scope, class)
+			if i < len(n.Right) {
+				nameSynthHashes(n.Right[i], lhs.Val)
+			}
 		case *BracketAssignmentNode:
 			// Bracket assignments modify an element, not the variable itself.
 			// Resolve the composite type and trigger key refinement if needed.
@@ -81,6 +100,11 @@ func (n *AssignmentNode) TargetType(scope ScopeChain, class *Class) (types.Type,
 							return nil, NewParseError(n, "Attempted to assign %s member to %s", valType, arr)
 						}
 					}
+					if ss, ok := lhs.Type().(*types.SynthStruct); ok && ss.Keyed() {
+						if f, _ := synthField(lhs, ss, lhs.Args); !f.Type.Equals(valType) {
+							return nil, NewParseError(n, "Attempted to assign %s to %s, which holds %s", valType, lhs, f.Type)
+						}
+					}
 				}
 			}
 			// Bracket assignment returns the assigned value, not the collection.
@@ -269,11 +293,20 @@ func (n *AssignmentNode) TargetType(scope ScopeChain, class *Class) (types.Type,
 								break
 							}
 						}
-						if !foundInMethodScope {
+						if !foundInMethodScope && !inTopLevelBlock(scope) {
 							local = BadLocal
 						}
 					}
 				}
+				// A name that was only ever a receiver when the file was
+				// parsed, and that no enclosing scope has assigned, belongs to
+				// the block that assigns it, so sibling blocks that each
+				// assign it get separate variables, as in Ruby.
+				if rl, ok := local.(*RubyLocal); ok && rl.placeholder && rl.Type() == nil && isBlockScope(scope.Current()) {
+					if _, inCurrent := scope.Get(localName); !inCurrent {
+						local = BadLocal
+					}
+				}
 			}
 			if _, ok := local.(*IVar); ok || local == BadLocal || isMethodCallLocal(local) {
 				localType := assignedType
@@ -297,10 +330,13 @@ func (n *AssignmentNode) TargetType(scope ScopeChain, class *Class) (types.Type,
 				} else if assignedType != nil && assignedType != types.AnyType {
 					newLocal.AddConstraint(TypeConstraint{Kind: AssignedType, Type: assignedType})
 				}
-				// Mark empty arrays and default hashes as refinable for type inference
+				// Mark empty arrays, queues and default hashes as refinable for type inference
 				if arrayType, ok := assignedType.(types.Array); ok && arrayType.Element == types.AnyType {
 					newLocal.MarkAsRefinable()
 				}
+				if queueType, ok := assignedType.(types.Queue); ok && queueType.Element == types.AnyType {
+					newLocal.MarkAsRefinable()
+				}
 				if hashType, ok := assignedType.(types.Hash); ok && hashType.HasDefault && hashType.Key == types.AnyType {
 					newLocal.MarkAsRefinable()
 				}
@@ -315,6 +351,9 @@ func (n *AssignmentNode) TargetType(scope ScopeChain, class *Class) (types.Type,
 					if arrayType, ok := assignedType.(types.Array); ok && arrayType.Element == types.AnyType {
 						loc.MarkAsRefinable()
 					}
+					if queueType, ok := assignedType.(types.Queue); ok && queueType.Element == types.AnyType {
+						loc.MarkAsRefinable()
+					}
 					if hashType, ok := assignedType.(types.Hash); ok && hashType.HasDefault && hashType.Key == types.AnyType {
 						loc.MarkAsRefinable()
 					}
@@ -351,6 +390,11 @@ func (n *AssignmentNode) TargetType(scope ScopeChain, class *Class) (types.Type,
 						// Variable keeps its Optional type
 					} else if _, ok := assignedType.(types.Optional); ok {
 						// Allow upgrading to Optional
+					} else if u, ok := local.Type().(*types.Union); ok && u.Has(assignedType) {
+						// Assigning one of its member types to a union variable
+					} else if rl, ok := local.(*RubyLocal); ok && widenToUnion(rl, assignedType) {
+						// A different type on another path widens the variable
+						// to a union of both.
 					} else {
 							return nil, NewParseError(n, "tried assigning type %s to local %s in scope %s but had previously assigned type %s", assignedType, localName, scope.Name(), local.Type())
 					}
@@ -403,6 +447,15 @@ func resolveNamespaceScope(namespace string, scope ScopeChain) Scope {
 	return nil
 }
 
+// isBlockScope reports whether s is the scope of a block or lambda body.
+func isBlockScope(s Scope) bool {
+	switch s.Name() {
+	case "block", "lambda":
+		return true
+	}
+	return false
+}
+
 // isMethodCallLocal returns true if the local is a *MethodCall (e.g., an
 // ivar accessor returned by Class.Get). Assigning to a name that shadows
 // an accessor should create a new local variable, not update the accessor.
@@ -421,3 +474,18 @@ func (n *AssignmentNode) Copy() Node {
 		_type:        n._type,
 	}
 }
+
+// inTopLevelBlock reports whether scope is a block at the top level of the
+// program, rather than in a method body. Such a block closes over the
+// top-level locals, so assigning to one of them inside it is a reassignment,
+// as it is for a thread's block.
+func inTopLevelBlock(scope ScopeChain) bool {
+	for _, s := range scope[1:] {
+		switch s.Name() {
+		case "block", "lambda", "pattern":
+		default:
+			return false
+		}
+	}
+	return true
+}
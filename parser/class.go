@@ -157,6 +157,18 @@ func (constant *Constant) QualifiedName() string {
 	return constant.name
 }
 
+// Module returns the module the constant is defined in, either directly or
+// through an enclosing class, or nil for top-level constants.
+func (constant *Constant) Module() *Module {
+	switch ns := constant.Namespace.(type) {
+	case *Module:
+		return ns
+	case *Class:
+		return ns.Module
+	}
+	return nil
+}
+
 func (constant *Constant) String() string {
 	return fmt.Sprintf("%s = %s", constant.name, constant.Val)
 }
@@ -183,6 +195,7 @@ type Module struct {
 	Classes      []*Class
 	ClassMethods []*Method
 	fromGem      bool // true if this module was loaded from a gem source file
+	topLevel     bool // defined with a root-anchored cpath (`module ::Foo`)
 }
 
 func (mod *Module) String() string {
@@ -288,14 +301,22 @@ type Class struct {
 	Includes         []string
 	ClassMethods     []*Method
 	DataDefine       bool // true if created via Data.define or Struct.new
+	topLevel         bool // defined with a root-anchored cpath (`class ::Foo`)
 }
 
 // IsUsed reports whether the class was ever instantiated (has calls to
-// initialize) or had instance methods called on it from outside the class.
+// initialize), had instance methods called on it from outside the class, or
+// declares instance methods in an RBS signature for callers outside the
+// program.
 func (cls *Class) IsUsed() bool {
 	if len(cls.MethodSet.Calls["initialize"]) > 0 {
 		return true
 	}
+	for _, m := range cls.MethodSet.Methods {
+		if m.Signature != nil {
+			return true
+		}
+	}
 	// Check if any instance method has external calls (not just internal
 	// Kernel calls like puts). A method with calls registered means
 	// someone called it on an instance of this class.
@@ -936,11 +957,15 @@ func (cls *Class) ConstGet(name string) (Const, error) {
 type ScopeAccessNode struct {
 	Receiver Node
 	Constant string
+	Resolved *Constant // the user-defined constant this node refers to, if any
 	_type    types.Type
 	Pos
 }
 
 func (n *ScopeAccessNode) String() string {
+	if rcvr, ok := n.Receiver.(*ConstantNode); ok && rcvr.TopLevel {
+		return fmt.Sprintf("(::%s::%s)", rcvr.Val, n.Constant)
+	}
 	return fmt.Sprintf("(%s::%s)", n.ReceiverName(), n.Constant)
 }
 func (n *ScopeAccessNode) Type() types.Type     { return n._type }
@@ -950,6 +975,7 @@ func (n *ScopeAccessNode) TargetType(locals ScopeChain, class *Class) (types.Typ
 	if constant, err := n.Walk(locals); err != nil {
 		return nil, err
 	} else {
+		n.Resolved, _ = constant.(*Constant)
 		return constant.Type(), nil
 	}
 }
@@ -969,6 +995,10 @@ func (n *ScopeAccessNode) Lookup(scope ScopeChain, outer, inner string) (Const,
 func (n *ScopeAccessNode) Walk(scope ScopeChain) (Const, error) {
 	//base case -- not a scope chain
 	if node, ok := n.Receiver.(*ScopeAccessNode); !ok {
+		// ::Foo::Bar looks Foo up from the root namespace
+		if rcvr, ok := n.Receiver.(*ConstantNode); ok && rcvr.TopLevel && len(scope) > 0 {
+			scope = scope[:1]
+		}
 		return n.Lookup(scope, n.ReceiverName(), n.Constant)
 	} else {
 		outerConstant, err := node.Walk(scope)
@@ -984,7 +1014,7 @@ func (n *ScopeAccessNode) Walk(scope ScopeChain) (Const, error) {
 }
 
 func (n *ScopeAccessNode) Copy() Node {
-	return &ScopeAccessNode{n.Receiver.Copy(), n.Constant, n._type, n.Pos}
+	return &ScopeAccessNode{n.Receiver.Copy(), n.Constant, n.Resolved, n._type, n.Pos}
 }
 
 func (n *ScopeAccessNode) ReceiverName() string {
@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/redneckbeard/thanos/stdlib"
 	"github.com/redneckbeard/thanos/types"
@@ -83,6 +84,27 @@ type KeyValuePair struct {
 	Pos
 }
 
+// staticKey returns the symbol key of a `key: value` or `:key => value` pair.
+func (n *KeyValuePair) staticKey() (string, bool) {
+	if n.Label != "" {
+		return n.Label, true
+	}
+	if sym, ok := n.Key.(*SymbolNode); ok {
+		return sym.Val[1:], true
+	}
+	return "", false
+}
+
+func (n *KeyValuePair) keyString() string {
+	if n.DoubleSplat {
+		return "**" + n.Value.String()
+	}
+	if n.Label != "" {
+		return ":" + n.Label
+	}
+	return n.Key.String()
+}
+
 func (n *KeyValuePair) String() string {
 	if n.DoubleSplat {
 		return fmt.Sprintf("**%s", n.Value)
@@ -111,8 +133,9 @@ func (n KeyValuePair) Copy() Node {
 }
 
 type HashNode struct {
-	Pairs  []*KeyValuePair
-	_type  types.Type
+	Pairs      []*KeyValuePair
+	_type      types.Type
+	structName string // variable the literal is assigned to, for naming a SynthStruct
 	Pos
 }
 
@@ -127,6 +150,7 @@ func (n *HashNode) TargetType(locals ScopeChain, class *Class) (types.Type, erro
 		return types.NewHash(types.AnyType, types.AnyType), nil
 	}
 	var keyType, valueType types.Type
+	heterogeneous := false
 	for _, kv := range n.Pairs {
 		if kv.Label != "" {
 			keyType = types.SymbolType
@@ -140,16 +164,39 @@ func (n *HashNode) TargetType(locals ScopeChain, class *Class) (types.Type, erro
 		}
 		tv, _ := GetType(kv.Value, locals, class)
 		if valueType != nil && valueType != tv {
-			return nil, fmt.Errorf("Heterogenous hash value membership detected adding %s", tv)
-		} else {
+			heterogeneous = true
+		} else if valueType == nil {
 			valueType = tv
 		}
 	}
+	if heterogeneous {
+		return n.synthStruct(locals)
+	}
 	return types.NewHash(keyType, valueType), nil
 }
 
+// synthStruct types a hash literal with mixed value types as a SynthStruct,
+// which requires every key to be a symbol written out in the literal and
+// every value to have a type a struct field can be declared with.
+func (n *HashNode) synthStruct(locals ScopeChain) (types.Type, error) {
+	var keys []string
+	var values []types.Type
+	for _, kv := range n.Pairs {
+		key, ok := kv.staticKey()
+		if !ok {
+			return nil, NewParseError(n, "Hash with heterogeneous values has non-static key %s; only symbol keys can be compiled to struct fields", kv.keyString())
+		}
+		if t := kv.Value.Type(); t == types.NilType || t == types.AnyType {
+			return nil, NewParseError(n, "Hash with heterogeneous values has %s for key :%s; its type can't be inferred for a struct field", kv.Value, key)
+		}
+		keys = append(keys, key)
+		values = append(values, kv.Value.Type())
+	}
+	return promoteHashToSynthStruct(n.structName, keys, values, locals), nil
+}
+
 func (n *HashNode) Copy() Node {
-	hash := &HashNode{_type: n._type, Pos: Pos{lineNo: n.lineNo}}
+	hash := &HashNode{_type: n._type, structName: n.structName, Pos: Pos{lineNo: n.lineNo}}
 	var pairs []*KeyValuePair
 	for _, pair := range n.Pairs {
 		pairs = append(pairs, pair.Copy().(*KeyValuePair))
@@ -200,9 +247,33 @@ func (n *BracketAssignmentNode) TargetType(locals ScopeChain, class *Class) (typ
 			}
 		}
 	}
+	if ss, ok := t.(*types.SynthStruct); ok && ss.Keyed() {
+		if _, err := synthField(n, ss, n.Args); err != nil {
+			return nil, err
+		}
+	}
 	return t, nil
 }
 
+// synthField resolves the key in `h[:key]` or `h[:key] = v` to a field of a
+// struct synthesized from a hash literal. The struct has a fixed set of
+// fields, so the key has to be a symbol literal naming one of them.
+func synthField(n Node, ss *types.SynthStruct, args ArgsNode) (types.SynthField, error) {
+	if len(args) == 1 {
+		if sym, ok := args[0].(*SymbolNode); ok {
+			if f, ok := ss.FieldByKey(sym.Val[1:]); ok {
+				return f, nil
+			}
+			var keys []string
+			for _, f := range ss.Fields {
+				keys = append(keys, ":"+f.Key)
+			}
+			return types.SynthField{}, NewParseError(n, "Key %s is not one of the static keys (%s) of a hash with heterogeneous values", sym.Val, strings.Join(keys, ", "))
+		}
+	}
+	return types.SynthField{}, NewParseError(n, "Hash with heterogeneous values accessed with non-static key %s; only the symbol keys it was created with can be compiled to struct fields", args)
+}
+
 func (n *BracketAssignmentNode) Copy() Node {
 	return &BracketAssignmentNode{n.Composite.Copy(), n.Args.Copy().(ArgsNode), n.Pos, n._type}
 }
@@ -255,6 +326,13 @@ func (n *BracketAccessNode) TargetType(locals ScopeChain, class *Class) (types.T
 	case types.String:
 		return types.StringType, nil
 	case *types.SynthStruct:
+		if comp.Keyed() {
+			f, err := synthField(n, comp, n.Args)
+			if err != nil {
+				return nil, err
+			}
+			return f.Type, nil
+		}
 		if len(n.Args) > 0 {
 			if _, err := GetType(n.Args[0], locals, class); err != nil {
 				return nil, err
@@ -42,11 +42,27 @@ func ResolveConstraints(constraints []TypeConstraint, currentType types.Type) ty
 			hasElemNilCheck = true
 		case AssignedType:
 			if c.Type != nil && c.Type != types.AnyType {
+				if concreteType != nil && !concreteType.Equals(c.Type) {
+					// Values of different types on different paths widen the
+					// variable to a union of those types.
+					if u, err := unionOf(concreteType, c.Type); err == nil {
+						concreteType = u
+						continue
+					}
+				}
 				concreteType = c.Type
 			}
 		}
 	}
 
+	// A union can't be made Optional; nil isn't one of its members.
+	if _, isUnion := concreteType.(*types.Union); isUnion {
+		if concreteType.Equals(currentType) {
+			return nil
+		}
+		return concreteType
+	}
+
 	// AssignedNil + AssignedType(T) → Optional(T)
 	if hasNil && concreteType != nil {
 		if _, alreadyOpt := concreteType.(types.Optional); !alreadyOpt {
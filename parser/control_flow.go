@@ -205,6 +205,9 @@ func (n *Condition) TargetType(locals ScopeChain, class *Class) (types.Type, err
 		if t2 == types.NilType {
 			return t1, nil
 		}
+		if u, err := unionOf(t1, t2); err == nil {
+			return u, nil
+		}
 	}
 	return nil, NewParseError(n.Condition, "Different branches of conditional returned different types: %s", n)
 }
@@ -224,6 +227,7 @@ type CaseNode struct {
 	Value             Node
 	Whens             []*WhenNode
 	RequiresExpansion bool
+	TypeSwitch        bool // matches a union variable against its member classes
 	_type             types.Type
 	Pos
 }
@@ -255,7 +259,24 @@ func (n *CaseNode) TargetType(locals ScopeChain, class *Class) (types.Type, erro
 				n.RequiresExpansion = true
 			}
 		}
-		tw, err := GetType(w, locals, class)
+	}
+	subject, members := n.unionMembers(locals)
+	n.TypeSwitch = subject != nil
+	for i, w := range n.Whens {
+		var (
+			tw  types.Type
+			err error
+		)
+		if n.TypeSwitch && members[i] != nil {
+			// Within `when Integer`, the union variable is known to hold an
+			// Integer.
+			u := subject.Type()
+			subject.SetType(members[i])
+			tw, err = GetType(w, locals, class)
+			subject.SetType(u)
+		} else {
+			tw, err = GetType(w, locals, class)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -280,8 +301,60 @@ func (n *CaseNode) TargetType(locals ScopeChain, class *Class) (types.Type, erro
 	return t, nil
 }
 
+// unionMembers checks whether the case matches a union variable against the
+// classes of its members, e.g. `case x when Integer ... when String ... end`
+// where x is a union of Integer and String. If so it returns the variable and,
+// for each when clause, the member it narrows the variable to (nil for else
+// clauses and clauses naming several classes).
+func (n *CaseNode) unionMembers(locals ScopeChain) (*RubyLocal, []types.Type) {
+	ident, ok := n.Value.(*IdentNode)
+	if !ok {
+		return nil, nil
+	}
+	rl, ok := locals.ResolveVar(ident.Val).(*RubyLocal)
+	if !ok {
+		return nil, nil
+	}
+	u, ok := rl.Type().(*types.Union)
+	if !ok {
+		return nil, nil
+	}
+	members := make([]types.Type, len(n.Whens))
+	for i, w := range n.Whens {
+		for _, cond := range w.Conditions {
+			member := unionMemberForClass(u, cond)
+			if member == nil {
+				return nil, nil
+			}
+			if len(w.Conditions) == 1 {
+				members[i] = member
+			}
+		}
+	}
+	return rl, members
+}
+
+// unionMemberForClass returns the member of u that is an instance of the
+// class named by cond, or nil if cond doesn't name one.
+func unionMemberForClass(u *types.Union, cond Node) types.Type {
+	constNode, ok := cond.(*ConstantNode)
+	if !ok {
+		return nil
+	}
+	cls, err := types.ClassRegistry.Get(constNode.Val)
+	if err != nil {
+		return nil
+	}
+	for _, m := range u.Members {
+		if m.Equals(cls.Instance.(types.Type)) {
+			return m
+		}
+	}
+	return nil
+}
+
 func (n *CaseNode) Copy() Node {
-	caseNode := &CaseNode{Value: n.Value.Copy(), RequiresExpansion: n.RequiresExpansion, _type: n._type, Pos: Pos{lineNo: n.lineNo}}
+	caseNode := &CaseNode{Value: n.Value.Copy(), RequiresExpansion: n.RequiresExpansion, TypeSwitch: n.TypeSwitch, _type: n._type, Pos: Pos{lineNo: n.lineNo}}
 	for _, when := range n.Whens {
 		caseNode.Whens = append(caseNode.Whens, when.Copy().(*WhenNode))
 	}
@@ -416,6 +489,42 @@ func (n *BreakNode) Copy() Node {
 	return n
 }
 
+// RedoNode restarts the current iteration of the innermost loop or block
+// without re-checking the loop condition or advancing to the next element.
+type RedoNode struct {
+	Pos
+}
+
+func (n *RedoNode) String() string       { return "(redo)" }
+func (n *RedoNode) Type() types.Type     { return types.NilType }
+func (n *RedoNode) SetType(t types.Type) {}
+
+func (n *RedoNode) TargetType(locals ScopeChain, class *Class) (types.Type, error) {
+	return types.NilType, nil
+}
+
+func (n *RedoNode) Copy() Node {
+	return n
+}
+
+// RetryNode re-runs the body of the begin block whose rescue clause it
+// appears in.
+type RetryNode struct {
+	Pos
+}
+
+func (n *RetryNode) String() string       { return "(retry)" }
+func (n *RetryNode) Type() types.Type     { return types.NilType }
+func (n *RetryNode) SetType(t types.Type) {}
+
+func (n *RetryNode) TargetType(locals ScopeChain, class *Class) (types.Type, error) {
+	return types.NilType, nil
+}
+
+func (n *RetryNode) Copy() Node {
+	return n
+}
+
 type NextNode struct {
 	Val    Node
 	Pos
@@ -508,6 +617,68 @@ func (n *BeginNode) Copy() Node {
 	return copy
 }
 
+// RescueModNode is the rescue modifier, `expr rescue fallback`. If evaluating
+// Expr raises, the value of the whole expression is Fallback instead, unless
+// Fallback jumps: `Integer(s) rescue next` has the type of Expr.
+type RescueModNode struct {
+	Expr     Node
+	Fallback Node
+	_type    types.Type
+	Pos
+}
+
+func (n *RescueModNode) String() string {
+	return fmt.Sprintf("(%s rescue %s)", n.Expr, n.Fallback)
+}
+func (n *RescueModNode) Type() types.Type     { return n._type }
+func (n *RescueModNode) SetType(t types.Type) { n._type = t }
+
+func (n *RescueModNode) TargetType(locals ScopeChain, class *Class) (types.Type, error) {
+	t1, err := GetType(n.Expr, locals, class)
+	if err != nil {
+		return nil, err
+	}
+	t2, err := GetType(n.Fallback, locals, class)
+	if err != nil {
+		return nil, err
+	}
+	if n.FallbackJumps() {
+		return t1, nil
+	}
+	switch {
+	case t1.Equals(t2):
+		return t1, nil
+	case t1 == types.AnyType || t2 == types.AnyType:
+		return types.AnyType, nil
+	case t2 == types.NilType:
+		// `x = foo rescue nil` — the result may be nil, so it's Optional
+		if _, alreadyOpt := t1.(types.Optional); alreadyOpt {
+			return t1, nil
+		}
+		return types.NewOptional(t1), nil
+	case t1 == types.NilType:
+		return t2, nil
+	}
+	if opt, ok := t1.(types.Optional); ok && opt.Element.Equals(t2) {
+		return t1, nil
+	}
+	return nil, NewParseError(n, "Rescue modifier fallback returned %s but the rescued expression returned %s: %s", t2, t1, n)
+}
+
+// FallbackJumps reports whether the fallback is next, break, redo or return,
+// which leave the statement rather than giving it a value.
+func (n *RescueModNode) FallbackJumps() bool {
+	switch n.Fallback.(type) {
+	case *NextNode, *BreakNode, *RedoNode, *ReturnNode:
+		return true
+	}
+	return false
+}
+
+func (n *RescueModNode) Copy() Node {
+	return &RescueModNode{Expr: n.Expr.Copy(), Fallback: n.Fallback.Copy(), _type: n._type, Pos: n.Pos}
+}
+
 type LambdaNode struct {
 	Block  *Block
 	_type  types.Type
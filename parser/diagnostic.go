@@ -0,0 +1,443 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Severity of a diagnostic. The values double as SARIF result levels.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Location is a position in a Ruby source file. Line and Column are 1-based;
+// zero means unknown.
+type Location struct {
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+// RelatedLocation points at another place that explains a diagnostic, such
+// as the method whose type conflicts with a call site.
+type RelatedLocation struct {
+	Location
+	Message string `json:"message"`
+}
+
+// Diagnostic is a problem found while parsing, analyzing or compiling Ruby.
+// Code is a stable identifier for the kind of problem (e.g. "cannot-infer" or
+// "unsupported-construct") that tools can filter on.
+type Diagnostic struct {
+	Location
+	Severity Severity          `json:"severity"`
+	Code     string            `json:"code"`
+	Message  string            `json:"message"`
+	Related  []RelatedLocation `json:"related,omitempty"`
+}
+
+// String renders the diagnostic the way thanos has always printed warnings.
+func (d Diagnostic) String() string {
+	if d.Line > 0 {
+		return fmt.Sprintf("%s: line %d: %s", d.Severity, d.Line, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+}
+
+// NewDiagnostic builds a diagnostic located at node.
+func NewDiagnostic(node Node, severity Severity, code, format string, args ...interface{}) Diagnostic {
+	return Diagnostic{
+		Location: nodeLocation(node),
+		Severity: severity,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+func nodeLocation(node Node) Location {
+	if node == nil {
+		return Location{}
+	}
+	loc := Location{File: node.File(), Line: node.LineNo()}
+	snippet := strings.SplitN(nodeString(node), "\n", 2)[0]
+	loc.Column = sourceColumn(loc.File, loc.Line, snippet)
+	return loc
+}
+
+// nodeString renders node, or returns "" for nodes whose String panics
+// because analysis stopped before their types were known.
+func nodeString(node Node) (s string) {
+	defer func() {
+		if recover() != nil {
+			s = ""
+		}
+	}()
+	return node.String()
+}
+
+// DiagnosticFormat, set with --format, selects how diagnostics are reported:
+// "text" prints them to DiagnosticOutput as they are found, "json" prints
+// each as a JSON object on its own line, and "sarif" collects them for
+// FlushDiagnostics to write as a single SARIF log.
+var DiagnosticFormat = "text"
+
+// DiagnosticOutput is where diagnostics are written. Defaults to stderr.
+var DiagnosticOutput io.Writer = os.Stderr
+
+var (
+	reported   []Diagnostic
+	collecting bool
+)
+
+// CollectDiagnostics runs f and returns everything reported while it ran,
+// regardless of format or Verbosity, instead of writing it out. File paths
+// are left absolute.
+func CollectDiagnostics(f func()) []Diagnostic {
+	saved := reported
+	reported, collecting = nil, true
+	sourceLines = map[string][]string{}
+	defer func() {
+		reported, collecting = saved, false
+	}()
+	f()
+	return reported
+}
+
+// Report emits a diagnostic in the selected format, subject to Verbosity:
+// warnings need a verbosity of at least 1 and notes at least 2.
+func Report(d Diagnostic) {
+	if collecting {
+		reported = append(reported, d)
+		return
+	}
+	switch {
+	case d.Severity == SeverityWarning && Verbosity < 1, d.Severity == SeverityNote && Verbosity < 2:
+		return
+	}
+	if d.File != "" {
+		d.File = DisplayPath(d.File)
+	}
+	for i, rel := range d.Related {
+		if rel.File != "" {
+			d.Related[i].File = DisplayPath(rel.File)
+		}
+	}
+	switch DiagnosticFormat {
+	case "json":
+		b, _ := json.Marshal(d)
+		fmt.Fprintf(DiagnosticOutput, "%s\n", b)
+	case "sarif":
+		reported = append(reported, d)
+	default:
+		fmt.Fprintf(DiagnosticOutput, "%s\n", d)
+	}
+}
+
+// ReportError reports an error that stopped a command as a diagnostic. In
+// text mode it does nothing and returns false, leaving the command to print
+// the error as it always has.
+func ReportError(err error) bool {
+	if DiagnosticFormat == "text" || err == nil {
+		return false
+	}
+	Report(DiagnosticFromError(err))
+	return true
+}
+
+// DiagnosticFromError converts an error into a diagnostic, keeping the
+// location and code of parse and syntax errors.
+func DiagnosticFromError(err error) Diagnostic {
+	var (
+		parseErr  *ParseError
+		syntaxErr *SyntaxError
+	)
+	switch {
+	case errors.As(err, &parseErr):
+		return Diagnostic{
+			Location: nodeLocation(parseErr.node),
+			Severity: SeverityError,
+			Code:     parseErr.Code(),
+			Message:  parseErr.msg,
+		}
+	case errors.As(err, &syntaxErr):
+		return Diagnostic{
+			Location: Location{
+				File:   syntaxErr.file,
+				Line:   syntaxErr.lineNo,
+				Column: sourceColumn(syntaxErr.file, syntaxErr.lineNo, syntaxErr.token),
+			},
+			Severity: SeverityError,
+			Code:     "syntax-error",
+			Message:  syntaxErr.msg,
+		}
+	}
+	return Diagnostic{Severity: SeverityError, Code: "error", Message: err.Error()}
+}
+
+// FlushDiagnostics writes the SARIF log of everything reported so far when
+// the SARIF format is selected. Other formats write diagnostics as they go.
+func FlushDiagnostics() error {
+	if DiagnosticFormat != "sarif" {
+		return nil
+	}
+	diagnostics := reported
+	reported = nil
+	return WriteSARIF(DiagnosticOutput, diagnostics)
+}
+
+// WriteSARIF writes diagnostics to w as a single SARIF log.
+func WriteSARIF(w io.Writer, diagnostics []Diagnostic) error {
+	b, err := json.MarshalIndent(sarifLog(diagnostics), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation struct {
+		URI string `json:"uri"`
+	} `json:"artifactLocation"`
+	Region *sarifRegion `json:"region,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               *int                   `json:"id,omitempty"`
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	Message          *sarifMessage          `json:"message,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID           string          `json:"ruleId"`
+	Level            Severity        `json:"level"`
+	Message          sarifMessage    `json:"message"`
+	Locations        []sarifLocation `json:"locations,omitempty"`
+	RelatedLocations []sarifLocation `json:"relatedLocations,omitempty"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name           string      `json:"name"`
+			InformationURI string      `json:"informationUri"`
+			Rules          []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifDocument struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+func sarifPhysical(loc Location) *sarifPhysicalLocation {
+	if loc.File == "" {
+		return nil
+	}
+	pl := &sarifPhysicalLocation{}
+	pl.ArtifactLocation.URI = filepath.ToSlash(loc.File)
+	if loc.Line > 0 {
+		pl.Region = &sarifRegion{StartLine: loc.Line, StartColumn: loc.Column}
+	}
+	return pl
+}
+
+func sarifLog(diagnostics []Diagnostic) sarifDocument {
+	run := sarifRun{Results: []sarifResult{}}
+	run.Tool.Driver.Name = "thanos"
+	run.Tool.Driver.InformationURI = "https://github.com/redneckbeard/thanos"
+	rules := map[string]bool{}
+	for _, d := range diagnostics {
+		rules[d.Code] = true
+		result := sarifResult{RuleID: d.Code, Level: d.Severity, Message: sarifMessage{d.Message}}
+		if pl := sarifPhysical(d.Location); pl != nil {
+			result.Locations = []sarifLocation{{PhysicalLocation: pl}}
+		}
+		for i, rel := range d.Related {
+			id := i
+			result.RelatedLocations = append(result.RelatedLocations, sarifLocation{
+				ID:               &id,
+				PhysicalLocation: sarifPhysical(rel.Location),
+				Message:          &sarifMessage{rel.Message},
+			})
+		}
+		run.Results = append(run.Results, result)
+	}
+	run.Tool.Driver.Rules = []sarifRule{}
+	for id := range rules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: id})
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+		return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+	})
+	return sarifDocument{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+}
+
+// SyntaxError is an error reported by the grammar, at the line and token
+// where parsing stopped.
+type SyntaxError struct {
+	msg    string // the grammar's message, without location or context
+	text   string // the full message as printed in text mode
+	file   string
+	lineNo int
+	token  string
+}
+
+func (e *SyntaxError) Error() string { return e.text }
+
+var syntaxErrorPrefix = regexp.MustCompile(`^syntax error, (?:.* )?line \d+: `)
+
+// newSyntaxError wraps an error reported to the lexer. text is the message as
+// printed, msg the message as reported, and context the source excerpt
+// appended to syntax errors.
+func newSyntaxError(text, msg, context, file string, lineNo int, token string) *SyntaxError {
+	return &SyntaxError{
+		msg:    syntaxErrorPrefix.ReplaceAllString(msg, ""),
+		text:   text + context,
+		file:   file,
+		lineNo: lineNo,
+		token:  token,
+	}
+}
+
+// parseErrorCodes classifies analysis errors by the format string they were
+// created with. Errors built from another error's message, which can't be
+// listed here, get the code "analysis-error"; TestParseErrorCodes checks
+// that every literal format string has an entry.
+var parseErrorCodes = map[string]string{
+	"unable to detect type signature of method '%s' because it is never called": "uncalled-method",
+
+	"local variable or method '%s' did not have discoverable type":                                     "cannot-infer",
+	"No type inferred for local variable '%s'":                                                         "cannot-infer",
+	"Could not infer type for interpolated value '%s'":                                                 "cannot-infer",
+	"Array of only nil values — cannot infer element type":                                             "cannot-infer",
+	"Method '%s' called on '%s' but type of '%s' is not inferred":                                      "cannot-infer",
+	"No inner array type detected":                                                                     "cannot-infer",
+	"Hash with heterogeneous values has %s for key :%s; its type can't be inferred for a struct field": "cannot-infer",
+
+	"No known method '%s' on %s":                                         "unknown-method",
+	"No method `%s` on type %s":                                          "unknown-method",
+	"Attempted to call undefined method '%s'":                            "unknown-method",
+	"Tried calling method '%s' inside but no such method exists":         "unknown-method",
+	"Called super inside %s#%s but no ancestors have instance method %s": "unknown-method",
+	"No such class or module '%s'":                                       "unknown-constant",
+
+	"Attempted to assign %s member to %s":                                                               "type-mismatch",
+	"Attempted to assign %s to %s, which holds %s":                                                      "type-mismatch",
+	"tried assigning type %s to local %s in scope %s but had previously assigned type %s":               "type-mismatch",
+	"Different branches of conditional returned different types: %s":                                    "type-mismatch",
+	"Detected conflicting return types %s and %s in method '%s'":                                        "type-mismatch",
+	"Rescue modifier fallback returned %s but the rescued expression returned %s: %s":                   "type-mismatch",
+	"tried to splat '%s' but is not an array":                                                           "type-mismatch",
+	"Tried to construct range from disparate types %s and %s":                                           "type-mismatch",
+	"method '%s' called with %s for parameter '%s' but '%s' was previously seen as %s":                  "type-mismatch",
+	"method '%s' called with double splat argument for parameter '%s' but hash value %s does not match": "type-mismatch",
+
+	"method '%s' called with %d positional arguments but %d expected":                           "argument-error",
+	"method '%s' called with %d arguments but %d expected":                                      "argument-error",
+	"method '%s' called with keyword argument '%s' but '%s' has no such parameter":              "argument-error",
+	"Detected mismatch in signatures of %s#%s and %s#%s, so cannot use bare super":              "argument-error",
+	"Gave keyword argument '%s' to super but %s#%s has no corresponding keyword argument":       "argument-error",
+	"Gave positional argument '%s' to super but %s#%s has no corresponding positional argument": "argument-error",
+
+	"Top-level constant '%s' is not visible from module package %s":                                                                                                  "unsupported-construct",
+	"%s not yet supported in LHS of assignments":                                                                                                                     "unsupported-construct",
+	"For loops over %s not supported":                                                                                                                                "unsupported-construct",
+	"For loops over hashes must unpack one key and one value":                                                                                                        "unsupported-construct",
+	"Destructuring subarrays in for loops not supported":                                                                                                             "unsupported-construct",
+	"%s is not a supported type for bracket access":                                                                                                                  "unsupported-construct",
+	"method '%s' called with %s and %s for splat parameter '%s' but heterogenous splat arguments are not yet supported":                                              "unsupported-construct",
+	"Hash with heterogeneous values has non-static key %s; only symbol keys can be compiled to struct fields":                                                        "unsupported-construct",
+	"Hash with heterogeneous values accessed with non-static key %s; only the symbol keys it was created with can be compiled to struct fields":                      "unsupported-construct",
+	"Key %s is not one of the static keys (%s) of a hash with heterogeneous values":                                                                                  "unsupported-construct",
+	"Tried to modify constant '%s'. In Ruby this only warns, but thanos forbids it.":                                                                                 "unsupported-construct",
+	"Scope operator (::) used on type other than a possible class/module. While technically valid Ruby, nobody really does this and the grammar shouldn't allow it.": "unsupported-construct",
+	"Not sure how this even successfully parsed":                                                                                                                     "unsupported-construct",
+	`\%c is not a valid escape sequence in Go strings`:                                                                                                               "unsupported-construct",
+	`\M-x, \M-\C-x, and \M-\cx are not valid escape sequences in Go strings`:                                                                                         "unsupported-construct",
+	`\c\M-x, \c?, and \C? are not valid escape sequences in Go strings`:                                                                                              "unsupported-construct",
+	"Class variable used outside of a class":                                                                                                                         "unsupported-construct",
+
+	"cannot locate source for require '%s' (no facade and no gem source found)": "missing-require",
+
+	"Unable to connect to the mothership": "analysis-error",
+}
+
+// sourceLines caches the lines of files that diagnostics point into.
+var sourceLines = map[string][]string{}
+
+// sourceColumn finds the 1-based column of snippet on the given line of file,
+// falling back to the first non-blank character of the line. It returns 0 if
+// the line can't be read.
+func sourceColumn(file string, lineNo int, snippet string) int {
+	if file == "" || lineNo <= 0 {
+		return 0
+	}
+	lines, ok := sourceLines[file]
+	if !ok {
+		if b, err := readSource(file); err == nil {
+			lines = strings.Split(string(b), "\n")
+		}
+		sourceLines[file] = lines
+	}
+	if lineNo > len(lines) {
+		return 0
+	}
+	line := lines[lineNo-1]
+	// Nodes print in a normalized form, so if the whole snippet isn't on the
+	// line, look for its leading identifier or literal instead.
+	snippet = strings.Trim(snippet, " ()")
+	candidates := []string{snippet}
+	if lead := strings.FieldsFunc(snippet, func(r rune) bool { return strings.ContainsRune(" ()[]{}.,", r) }); len(lead) > 0 {
+		candidates = append(candidates, lead[0])
+	}
+	for _, c := range candidates {
+		if idx := strings.Index(line, c); c != "" && idx >= 0 {
+			return len([]rune(line[:idx])) + 1
+		}
+	}
+	trimmed := strings.TrimLeft(line, " \t")
+	return len([]rune(line)) - len([]rune(trimmed)) + 1
+}
+
+// DisplayPath shows path relative to the working directory when it is beneath
+// it, and as given otherwise.
+func DisplayPath(path string) string {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return path
+}
@@ -0,0 +1,180 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func reportTo(format string) *bytes.Buffer {
+	var buf bytes.Buffer
+	DiagnosticFormat, DiagnosticOutput = format, &buf
+	return &buf
+}
+
+func TestDiagnosticFromError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.rb")
+	os.WriteFile(path, []byte(`def add(a, b)
+  a + b
+end
+
+puts add(1, "two")
+puts x.foo
+`), 0644)
+	defer func() { DiagnosticFormat, DiagnosticOutput = "text", os.Stderr }()
+
+	_, err := ParseProgram(path)
+	if err == nil {
+		t.Fatal("expected an analysis error")
+	}
+	buf := reportTo("json")
+	if !ReportError(err) {
+		t.Fatal("expected ReportError to report in json mode")
+	}
+	var d Diagnostic
+	if err := json.Unmarshal(buf.Bytes(), &d); err != nil {
+		t.Fatalf("expected a JSON diagnostic, got %q: %v", buf.String(), err)
+	}
+	if d.File != path || d.Line == 0 || d.Column == 0 || d.Severity != SeverityError || d.Code == "" || d.Code == "error" {
+		t.Errorf("expected a located, classified error, got %+v", d)
+	}
+
+	reportTo("text")
+	if ReportError(err) {
+		t.Error("expected ReportError to leave text mode to the caller")
+	}
+}
+
+func TestDiagnosticSyntaxError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.rb")
+	os.WriteFile(path, []byte("x = 1\nputs x +\n  ) 2\n"), 0644)
+
+	_, err := ParseProgram(path)
+	if err == nil {
+		t.Fatal("expected a syntax error")
+	}
+	d := DiagnosticFromError(err)
+	if d.Code != "syntax-error" || d.Line != 3 || d.Column != 3 || d.File != path {
+		t.Errorf("expected syntax-error at %s:3:3, got %+v", path, d)
+	}
+}
+
+func TestDiagnosticSARIF(t *testing.T) {
+	defer func() { DiagnosticFormat, DiagnosticOutput = "text", os.Stderr }()
+	buf := reportTo("sarif")
+	Report(Diagnostic{
+		Location: Location{File: "lib.rb", Line: 4, Column: 3},
+		Severity: SeverityWarning,
+		Code:     "rbs-mismatch",
+		Message:  "declares return type",
+		Related:  []RelatedLocation{{Location: Location{File: "sig/lib.rbs", Line: 2}, Message: "RBS signature"}},
+	})
+	Report(Diagnostic{Severity: SeverityNote, Code: "type-widened", Message: "filtered by verbosity"})
+	if buf.Len() != 0 {
+		t.Fatalf("expected SARIF output to wait for a flush, got %q", buf.String())
+	}
+	if err := FlushDiagnostics(); err != nil {
+		t.Fatal(err)
+	}
+
+	var log struct {
+		Version string
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string
+					Rules []struct{ ID string }
+				}
+			}
+			Results []struct {
+				RuleID    string
+				Level     string
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct{ URI string }
+						Region           struct{ StartLine, StartColumn int }
+					}
+				}
+				RelatedLocations []struct {
+					Message struct{ Text string }
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("expected a SARIF log, got %q: %v", buf.String(), err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("expected one run with one result, got %s", buf.String())
+	}
+	run := log.Runs[0]
+	result := run.Results[0]
+	loc := result.Locations[0].PhysicalLocation
+	switch {
+	case run.Tool.Driver.Name != "thanos", len(run.Tool.Driver.Rules) != 1, run.Tool.Driver.Rules[0].ID != "rbs-mismatch":
+		t.Errorf("unexpected driver %+v", run.Tool.Driver)
+	case result.RuleID != "rbs-mismatch", result.Level != "warning":
+		t.Errorf("unexpected result %+v", result)
+	case loc.ArtifactLocation.URI != "lib.rb", loc.Region.StartLine != 4, loc.Region.StartColumn != 3:
+		t.Errorf("unexpected location %+v", loc)
+	case len(result.RelatedLocations) != 1 || result.RelatedLocations[0].Message.Text != "RBS signature":
+		t.Errorf("unexpected related locations %+v", result.RelatedLocations)
+	}
+}
+
+// Every literal format string handed to NewParseError should be classified in
+// parseErrorCodes, or the error is reported with the catch-all code.
+func TestParseErrorCodes(t *testing.T) {
+	for _, dir := range []string{".", "../compiler", "../types"} {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, path := range paths {
+			if strings.HasSuffix(path, "_test.go") {
+				continue
+			}
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ast.Inspect(file, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) < 2 {
+					return true
+				}
+				switch fn := call.Fun.(type) {
+				case *ast.Ident:
+					if fn.Name != "NewParseError" {
+						return true
+					}
+				case *ast.SelectorExpr:
+					if fn.Sel.Name != "NewParseError" {
+						return true
+					}
+				default:
+					return true
+				}
+				lit, ok := call.Args[1].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return true
+				}
+				format, _ := strconv.Unquote(lit.Value)
+				if _, ok := parseErrorCodes[format]; !ok {
+					t.Errorf("%s: no code in parseErrorCodes for %q", fset.Position(lit.Pos()), format)
+				}
+				return true
+			})
+		}
+	}
+}
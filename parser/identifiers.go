@@ -90,11 +90,16 @@ func (n *GVarNode) String() string       { return n.Val }
 func (n *GVarNode) Type() types.Type     { return n._type }
 func (n *GVarNode) SetType(t types.Type) {
 	n._type = t
-	globalVarRegistry[n.NormalizedVal()] = t
+	if _, ok := types.PredefinedGlobals[n.NormalizedVal()]; !ok {
+		globalVarRegistry[n.NormalizedVal()] = t
+	}
 }
 
 func (n *GVarNode) TargetType(locals ScopeChain, class *Class) (types.Type, error) {
 	name := n.NormalizedVal()
+	if predefined, ok := types.PredefinedGlobals[name]; ok {
+		return predefined.Type, nil
+	}
 	if t, ok := globalVarRegistry[name]; ok {
 		return t, nil
 	}
@@ -124,15 +129,25 @@ func ResetGlobalVars() {
 type ConstantNode struct {
 	Val       string
 	Namespace string
+	TopLevel  bool      // `::Foo`, resolved from the root namespace
+	Resolved  *Constant // the user-defined constant this node refers to, if any
 	_type     types.Type
 	Pos
 }
 
-func (n *ConstantNode) String() string       { return n.Val }
+func (n *ConstantNode) String() string {
+	if n.TopLevel {
+		return "::" + n.Val
+	}
+	return n.Val
+}
 func (n *ConstantNode) Type() types.Type     { return n._type }
 func (n *ConstantNode) SetType(t types.Type) { n._type = t }
 
 func (n *ConstantNode) TargetType(locals ScopeChain, class *Class) (types.Type, error) {
+	if n.TopLevel && len(locals) > 0 {
+		locals = locals[:1]
+	}
 	if local := locals.ResolveVar(n.Val); local == BadLocal {
 		if t, err := types.ClassRegistry.Get(n.Val); err != nil {
 			if t, ok := types.PredefinedConstants[n.Val]; ok {
@@ -145,6 +160,7 @@ func (n *ConstantNode) TargetType(locals ScopeChain, class *Class) (types.Type,
 	} else {
 		if constant, ok := local.(*Constant); ok {
 			n.Namespace = constant.Namespace.QualifiedName()
+			n.Resolved = constant
 		}
 		return local.Type(), nil
 	}
@@ -2,7 +2,6 @@ package parser
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -103,7 +102,9 @@ var keywords = map[string]int{
 	// `private`/`protected` method calls.
 	"private":   PRIVATE,
 	"protected": PROTECTED,
+	"redo":      REDO,
 	"rescue":    RESCUE,
+	"retry":     RETRY,
 	"return":    RETURN,
 	"self":      SELF,
 	"super":     SUPER,
@@ -228,14 +229,30 @@ func (l *Lexer) Lex(lval *yySymType) int {
 func (l *Lexer) Error(e string) {
 	// Yacc-generated syntax errors already include the file path via
 	// formatSyntaxError, so only prepend for other error messages.
+	msg, context := e, ""
 	if l.filePath != "" && !strings.HasPrefix(e, "syntax error") {
 		e = l.filePath + ": " + e
 	}
 	// Append source context and token history for syntax errors.
 	if strings.HasPrefix(e, "syntax error") {
-		e += l.errorContext()
+		context = l.errorContext()
 	}
-	l.Root.AddError(errors.New(e))
+	l.Root.AddError(newSyntaxError(e, msg, context, l.filePath, l.errorLine(), l.lastParsedToken.Literal))
+}
+
+// errorLine is the line a syntax error should be reported at. At the end of
+// input the current token has no line, so fall back to the last one that did.
+func (l *Lexer) errorLine() int {
+	if currentLineNo != 0 {
+		return currentLineNo
+	}
+	for i := 1; i <= l.tokenHistoryCount; i++ {
+		tok := l.tokenHistory[(l.tokenHistoryPos-i+len(l.tokenHistory))%len(l.tokenHistory)]
+		if tok.LineNo != 0 {
+			return tok.LineNo
+		}
+	}
+	return 0
 }
 
 // errorContext returns a string with the source line and recent token history
@@ -388,7 +405,7 @@ func (l *Lexer) AtExprStart() bool {
 	midExprTokens := []int{
 		NIL, SYMBOL, STRING, INT, FLOAT, TRUE, FALSE, DEF, END, SELF, CONSTANT,
 		IVAR, CVAR, GVAR, METHODIDENT, IDENT, DO,
-		RBRACE, STRINGEND, RBRACKET, RPAREN, BREAK, NEXT, RETURN, YIELD,
+		RBRACE, STRINGEND, RBRACKET, RPAREN, BREAK, NEXT, REDO, RETRY, RETURN, YIELD,
 	}
 
 	for _, tok := range midExprTokens {
@@ -561,6 +578,24 @@ func (l *Lexer) lexPunct() error {
 		if next == '@' {
 			return l.lexAttribute()
 		}
+	case '$':
+		// $? is the only punctuation global: the last command's status.
+		if next == '?' {
+			l.Advance()
+			l.Emit(GVAR)
+			return err
+		}
+	case ':':
+		// `:"..."` starts a dynamic symbol at the start of an expression or as
+		// a command argument (`puts :"a#{b}"`), but not in `{"a":"b"}` or
+		// `x ? "y" :"z"`.
+		if next == '"' && (l.AtExprStart() || (l.spaceConsumed && l.lastToken == IDENT)) {
+			l.Advance()
+			l.Emit(DSYMBEG)
+			l.pushStringDelim('"')
+			l.State.Push(InInterpString)
+			return l.lexString()
+		}
 	case '"', '`':
 		if l.State.Peek() == InInterpString {
 			l.State.Pop()
@@ -615,7 +650,7 @@ func (l *Lexer) lexPunct() error {
 			return err
 		}
 		switch l.lastToken {
-		case RBRACKET, RBRACE, RPAREN, INT, FLOAT, IDENT, CONSTANT, METHODIDENT:
+		case RBRACKET, RBRACE, RPAREN, INT, FLOAT, IDENT, CONSTANT, METHODIDENT, IVAR, CVAR, GVAR:
 			// keep going
 		default:
 			return l.lexRegex()
@@ -639,6 +674,11 @@ func (l *Lexer) lexPunct() error {
 					l.Emit(DOUBLESPLAT)
 					return err
 				}
+			case SCOPE:
+				if l.scopeStart() {
+					l.Emit(SCOPESTART)
+					return err
+				}
 			case LPAREN, LBRACKET:
 				if l.spaceConsumed {
 					l.Emit(exprStartTokens[tok])
@@ -674,6 +714,11 @@ func (l *Lexer) lexPunct() error {
 			l.Emit(DOUBLESPLAT)
 			return err
 		}
+	case SCOPE:
+		if l.scopeStart() {
+			l.Emit(SCOPESTART)
+			return err
+		}
 	case LPAREN, LBRACKET:
 		if DebugLevel() >= 5 {
 			log.Printf("DEBUG LPAREN check: spaceConsumed=%v literal=%q lastToken=%d", l.spaceConsumed, string(l.read), l.lastToken)
@@ -691,6 +736,12 @@ func (l *Lexer) lexPunct() error {
 	return err
 }
 
+// scopeStart reports whether a `::` begins a top-level constant reference
+// (`::Foo`) rather than a scope access on the preceding expression.
+func (l *Lexer) scopeStart() bool {
+	return l.AtExprStart() || (l.spaceConsumed && l.lastToken == IDENT)
+}
+
 func (l *Lexer) emitOpenMatching(tok int) {
 	switch l.lastToken {
 	case RPAREN, RBRACKET, RBRACE, IDENT, CONSTANT, METHODIDENT, YIELD, IVAR, STRINGEND, RAWSTRINGEND, SUPER:
@@ -804,9 +855,10 @@ func (l *Lexer) lexWord() error {
 }
 
 func (l *Lexer) lexAttribute() error {
-	curr, _, err := l.Advance()
-	if curr == '@' {
-		if err != nil {
+	// The leading '@' has been read, and lexWord reads the first character of
+	// the name itself, so only a second '@' should be consumed here.
+	if next, _ := l.Peek(); next == '@' {
+		if _, _, err := l.Advance(); err != nil {
 			return err
 		}
 		l.State.Push(InCVar)
@@ -1127,11 +1179,11 @@ func (l *Lexer) lexPercentLiteral() error {
 	l.pushStringDelim(next)
 	l.Advance()
 	switch curr {
-	case 'w':
+	case 'w', 'i':
 		l.Emit(RAWWORDSBEG)
 		l.State.Push(InRawString)
 		return l.lexRawString()
-	case 'W':
+	case 'W', 'I':
 		l.Emit(WORDSBEG)
 		l.State.Push(InInterpString)
 		return l.lexString()
@@ -1143,7 +1195,7 @@ func (l *Lexer) lexPercentLiteral() error {
 		l.Emit(XSTRINGBEG)
 		l.State.Push(InInterpString)
 		return l.lexString()
-	case 'q', 'Q', 'r', 'R', 'i', 'I', 's', 'S':
+	case 'q', 'Q', 'r', 'R', 's', 'S':
 		return fmt.Errorf("'%%%c' literals are not supported", curr)
 	default:
 		return fmt.Errorf("'%c' is not a valid type of percent literal", curr)
@@ -10,7 +10,7 @@ func TestLexer(t *testing.T) {
   < > <= >= << <=> ,;.)+{}-]?
   definitely def self end then else unless true false 
   return nil module class do yield begin rescue while
-  ensure elsif case when until for break next super alias 
+  ensure elsif case when until for break next redo retry super alias 
   @foo @@bar != ** =~ !~ >> :baz? mutate!( under_score[ | -10 key: [ ( foo2
   `
 
@@ -92,6 +92,8 @@ func TestLexer(t *testing.T) {
 		{FOR, "for"},
 		{BREAK, "break"},
 		{NEXT, "next"},
+		{REDO, "redo"},
+		{RETRY, "retry"},
 		{SUPER, "super"},
 		{ALIAS, "alias"},
 		{NEWLINE, "\n"},
@@ -216,6 +218,16 @@ func TestStatefulLexing(t *testing.T) {
 			[]int{INT, SLASH, IDENT, SLASH},
 			[]string{`10`, `/`, "foo", "/"},
 		},
+		{
+			`@base / x / $y`,
+			[]int{IVAR, SLASH, IDENT, SLASH, GVAR},
+			[]string{`@base`, `/`, "x", "/", "$y"},
+		},
+		{
+			`$?.success? ? 0 : $?.exitstatus`,
+			[]int{GVAR, DOT, METHODIDENT, QMARK, INT, COLON, GVAR, DOT, IDENT},
+			[]string{`$?`, ".", "success?", "?", "0", ":", "$?", ".", "exitstatus"},
+		},
 		{
 			`/foo#{bar}/`,
 			[]int{REGEXBEG, STRINGBODY, INTERPBEG, IDENT, INTERPEND, REGEXEND},
@@ -231,6 +243,26 @@ func TestStatefulLexing(t *testing.T) {
 			[]int{RAWSTRINGBEG, STRINGBODY, RAWSTRINGEND},
 			[]string{`'`, "foo#{bar}", `'`},
 		},
+		{
+			`:"foo_#{bar}"`,
+			[]int{DSYMBEG, STRINGBODY, INTERPBEG, IDENT, INTERPEND, STRINGEND},
+			[]string{`:"`, "foo_", "#{", "bar", "}", `"`},
+		},
+		{
+			`puts ::Foo::Bar`,
+			[]int{IDENT, SCOPESTART, CONSTANT, SCOPE, CONSTANT},
+			[]string{"puts", "::", "Foo", "::", "Bar"},
+		},
+		{
+			`class Foo < ::Bar`,
+			[]int{CLASS, CONSTANT, LT, SCOPESTART, CONSTANT},
+			[]string{"class", "Foo", "<", "::", "Bar"},
+		},
+		{
+			`x ? "y" :"z"`,
+			[]int{IDENT, QMARK, STRINGBEG, STRINGBODY, STRINGEND, COLON, STRINGBEG, STRINGBODY, STRINGEND},
+			[]string{"x", "?", `"`, "y", `"`, ":", `"`, "z", `"`},
+		},
 		{
 			`%w{foo bar baz}`,
 			[]int{RAWWORDSBEG, STRINGBODY, RAWSTRINGEND},
@@ -256,6 +288,16 @@ func TestStatefulLexing(t *testing.T) {
 			[]int{WORDSBEG, STRINGBODY, INTERPBEG, RAWWORDSBEG, STRINGBODY, RAWSTRINGEND, INTERPEND, STRINGBODY, STRINGEND},
 			[]string{`%W{`, "foo ", "#{", "%w{", "b a r", "}", "}", " baz", `}`},
 		},
+		{
+			`%i[foo bar]`,
+			[]int{RAWWORDSBEG, STRINGBODY, RAWSTRINGEND},
+			[]string{`%i[`, "foo bar", `]`},
+		},
+		{
+			`%I[foo_#{bar} baz]`,
+			[]int{WORDSBEG, STRINGBODY, INTERPBEG, IDENT, INTERPEND, STRINGBODY, STRINGEND},
+			[]string{`%I[`, "foo_", "#{", "bar", "}", " baz", `]`},
+		},
 		{
 			`5.even?`,
 			[]int{INT, DOT, METHODIDENT},
@@ -306,6 +348,11 @@ func TestStatefulLexing(t *testing.T) {
 			[]int{IVAR, LBRACKET, IDENT, RBRACKET},
 			[]string{"@foo", "[", "x", "]"},
 		},
+		{
+			"[@x, @@y]\n@z",
+			[]int{LBRACKETSTART, IVAR, COMMA, CVAR, RBRACKET, NEWLINE, IVAR},
+			[]string{"[", "@x", ",", "@@y", "]", "\n", "@z"},
+		},
 		{
 			"`man -P cat #{\"date\"}`",
 			[]int{XSTRINGBEG, STRINGBODY, INTERPBEG, STRINGBEG, STRINGBODY, STRINGEND, INTERPEND, STRINGEND},
@@ -70,6 +70,7 @@ const (
 	Splat
 	DoubleSplat
 	Destructured
+	BlockLocal
 )
 
 type Param struct {
@@ -80,6 +81,7 @@ type Param struct {
 	Default  Node
 	Required bool
 	Nested   []*Param
+	nilArg   bool // call sites pass both nil and a concrete type
 }
 
 func (p *Param) Type() types.Type {
@@ -104,6 +106,47 @@ func (p *Param) HasNilDefault() bool {
 	return isNil
 }
 
+// AcceptsNil returns true if nil may be passed for the parameter, either
+// through an explicit nil default or because call sites pass both nil and a
+// concrete type. Such params compile to *T.
+func (p *Param) AcceptsNil() bool {
+	return p.HasNilDefault() || p.nilArg
+}
+
+// unifyNilArg reconciles a call-site argument type with the type previously
+// seen for the parameter when exactly one of them is nil. Both are fed to
+// ResolveConstraints as assignment evidence, so `test(nil); test(3)` settles
+// on Optional(IntType) rather than a type conflict. Returns false if the
+// types cannot be unified this way.
+func (p *Param) unifyNilArg(t types.Type) bool {
+	if p.HasNilDefault() || (p.Kind != Positional && p.Kind != Named) {
+		return false
+	}
+	prev := p.Type()
+	if opt, ok := prev.(types.Optional); ok && p.nilArg {
+		return t == types.NilType || t.Equals(opt.Element) || t.Equals(opt)
+	}
+	var constraints []TypeConstraint
+	switch {
+	case t == types.NilType && prev != types.NilType:
+		constraints = []TypeConstraint{{Kind: AssignedType, Type: prev}, {Kind: AssignedNil}}
+	case prev == types.NilType && t != types.NilType:
+		constraints = []TypeConstraint{{Kind: AssignedNil}, {Kind: AssignedType, Type: t}}
+	default:
+		return false
+	}
+	resolved := ResolveConstraints(constraints, prev)
+	if resolved == nil {
+		return false
+	}
+	if _, ok := resolved.(types.Optional); !ok {
+		return false
+	}
+	p._type = resolved
+	p.nilArg = true
+	return true
+}
+
 func (p *Param) String() string {
 	switch p.Kind {
 	case Positional:
@@ -121,6 +164,9 @@ func (p *Param) String() string {
 type ParamList struct {
 	Params   []*Param
 	ParamMap map[string]*Param
+	// Locals are the block-local variables declared after a semicolon in a
+	// block's parameter list (`|x; tmp|`). They take no arguments.
+	Locals []*Param
 }
 
 func NewParamList() *ParamList {
@@ -137,6 +183,19 @@ func (list *ParamList) AddParam(p *Param) error {
 	return nil
 }
 
+func (list *ParamList) AddLocal(p *Param) error {
+	if _, found := list.ParamMap[p.Name]; found {
+		return fmt.Errorf("block-local variable '%s' shadows a parameter", p.Name)
+	}
+	for _, local := range list.Locals {
+		if local.Name == p.Name {
+			return fmt.Errorf("block-local variable '%s' declared twice", p.Name)
+		}
+	}
+	list.Locals = append(list.Locals, p)
+	return nil
+}
+
 func (list *ParamList) GetParam(i int) (*Param, error) {
 	if i < len(list.Params) {
 		return list.Params[i], nil
@@ -198,6 +257,8 @@ type Method struct {
 	uncallable     bool
 	MutatedSliceParams []int              // indices of params that are slices mutated via append
 	GenericParams      map[int]types.GenericParam // param index → generic type param
+	Signature          *Signature                 // from an RBS file, if one declares the method
+	signatureReturn    types.Type                 // the return type Signature declares
 }
 
 func NewMethod(name string, r *Root) *Method {
@@ -233,6 +294,7 @@ func (n *Method) String() string {
 }
 
 func (n *Method) IsUncallable() bool    { return n.uncallable }
+func (n *Method) Analyzed() bool       { return n.analyzed }
 func (n *Method) Type() types.Type     { return types.FuncType }
 func (n *Method) SetType(t types.Type) {}
 func (n *Method) TargetType(locals ScopeChain, class *Class) (types.Type, error) {
@@ -313,6 +375,7 @@ func (m *Method) Analyze(ms *MethodSet) error {
 			return err
 		}
 	}
+	m.applySignature(ms.Class)
 	for _, param := range m.Params {
 		if DebugLevel() >= 5 {
 			fmt.Fprintf(os.Stderr, "DEBUG   param %s type=%v\n", param.Name, param.Type())
@@ -355,15 +418,17 @@ func (m *Method) Analyze(ms *MethodSet) error {
 			} else {
 				return NewParseError(m, "unable to detect type signature of method '%s' because it is never called", name)
 			}
-		} else if !param.HasNilDefault() {
+		} else if !param.AcceptsNil() {
 			// For nil-default params, AnalyzeArguments already set the
 			// local to AnyType (refinable). Don't overwrite with NilType.
+			// Likewise for params unified to Optional from call sites.
 			m.Locals.Set(param.Name, &RubyLocal{_type: param.Type()})
 		}
 	}
 	if err := m.analyzeMethodBody(ms.Class, nil, nil); err != nil {
 		return err
 	}
+	m.checkSignatureReturn(ms.Class)
 	// After body analysis, wrap nil-default params in Optional.
 	// The body refined the local to a concrete type (e.g., IntType via ||=);
 	// the param signature should be Optional(concrete) since callers can pass nil.
@@ -811,7 +876,14 @@ func (method *Method) AnalyzeArguments(class *Class, c *MethodCall, scope ScopeC
 			}
 		} else {
 			t, err := GetType(arg, scope, class)
-			if err == nil && t != param.Type() && param.Type() == types.NilType && !param.HasNilDefault() {
+			if err == nil && param.unifyNilArg(t) {
+				// nil at one call site, T at another. As with a nil default,
+				// the body sees T so that `x ||= val` refines it, while the
+				// signature takes *T.
+				rl := &RubyLocal{_type: param.Type().(types.Optional).Element}
+				rl.MarkAsRefinable()
+				method.Scope.Set(param.Name, rl)
+			} else if err == nil && t != param.Type() && param.Type() == types.NilType && !param.HasNilDefault() {
 				// Default was nil — adopt the actual call-site type
 				param._type = t
 				method.Scope.Set(param.Name, &RubyLocal{_type: t})
@@ -982,8 +1054,25 @@ func (b *Block) String() string {
 	for _, p := range b.Params {
 		strs = append(strs, p.Name)
 	}
+	params := strings.Join(strs, ", ")
+	if len(b.Locals) > 0 {
+		locals := []string{}
+		for _, p := range b.Locals {
+			locals = append(locals, p.Name)
+		}
+		params += "; " + strings.Join(locals, ", ")
+	}
 
-	return fmt.Sprintf("(|%s| %s)", strings.Join(strs, ", "), b.Body)
+	return fmt.Sprintf("(|%s| %s)", params, b.Body)
+}
+
+// declareLocals gives each block-local variable an untyped local in the
+// block's own scope, so that assignments inside the block never resolve to
+// an outer variable of the same name.
+func (b *Block) declareLocals(scope ScopeChain) {
+	for _, p := range b.Locals {
+		scope.Set(p.Name, &RubyLocal{})
+	}
 }
 
 func (b *Block) Type() types.Type {
@@ -1151,6 +1240,7 @@ func (c *MethodCall) TargetType(scope ScopeChain, class *Class) (types.Type, err
 				// Synthetic blocks (from analyzeMethodBody) have ReturnType
 				// pre-set with no Statements — skip InferReturnType.
 				if blk.Body.ReturnType == nil {
+					blk.declareLocals(blk.Scope)
 					err := blk.Body.InferReturnType(blk.Scope, nil)
 					if err != nil {
 						return nil, err
@@ -1171,7 +1261,7 @@ func (c *MethodCall) TargetType(scope ScopeChain, class *Class) (types.Type, err
 	}
 	if c.Receiver != nil {
 		if receiverType == nil {
-			return nil, fmt.Errorf("Method '%s' called on '%s' but type of '%s' is not inferred", c.MethodName, c.Receiver, c.Receiver)
+			return nil, NewParseError(c, "Method '%s' called on '%s' but type of '%s' is not inferred", c.MethodName, c.Receiver, c.Receiver)
 		}
 		if !receiverType.HasMethod(c.MethodName) {
 			if ms, ok := classMethodSets[receiverType]; ok && ms.Class != nil {
@@ -1366,6 +1456,9 @@ func (c *MethodCall) TargetType(scope ScopeChain, class *Class) (types.Type, err
 			if rt := method.ReturnType(); rt != nil {
 				return rt, nil
 			}
+			if method.signatureReturn != nil {
+				return method.signatureReturn, nil
+			}
 			return types.NilType, nil
 		}
 		// Type block params from the method's BlockParam (yield arg types).
@@ -1384,6 +1477,7 @@ func (c *MethodCall) TargetType(scope ScopeChain, class *Class) (types.Type, err
 					}
 				}
 				if c.Block.Body != nil {
+					c.Block.declareLocals(c.Block.Scope)
 					c.Block.Body.InferReturnType(c.Block.Scope, nil)
 				}
 			}
@@ -1492,7 +1586,10 @@ func (c *MethodCall) TargetType(scope ScopeChain, class *Class) (types.Type, err
 					blockScope.Set(p.Name, local)
 				}
 			}
-			err := c.Block.Body.InferReturnType(scope.Extend(blockScope), nil)
+			blockChain := scope.Extend(blockScope)
+			c.Block.Scope = blockChain
+			c.Block.declareLocals(blockChain)
+			err := c.Block.Body.InferReturnType(blockChain, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -51,7 +51,16 @@ func TestPrecedence(t *testing.T) {
 		{"def x(n); return n + 1; end; x(5)", `(def x(n) (return (n + 1)))
 (x(5))`},
 		{"x > 5 ? true : false", "(if (x > 5) true (else false))"},
+		{"x = foo rescue 0", "(x = (foo rescue 0))"},
+		{"x = foo(1) + 2 rescue bar", "(x = (((foo(1)) + 2) rescue bar))"},
+		{"foo rescue bar if baz", "(if baz (foo rescue bar))"},
+		{"x = foo rescue next", "(x = (foo rescue (next)))"},
+		{"foo rescue return 0", "(foo rescue (return 0))"},
 		{"[1,2,3].each do |x|; x + 1; end", "([1, 2, 3].each(block = (|x| (return (x + 1)))))"},
+		{"[1,2,3].each do |x; y, z| y = x; end", `([1, 2, 3].each(block = (|x; y, z| (y = x)
+(return x))))`},
+		{"[1,2,3].each { |; y| y = 1 }", `([1, 2, 3].each(block = (|; y| (y = 1)
+(return 1))))`},
 		{"[1,2,3].reduce(0) {|acc, n| acc + n }", "([1, 2, 3].reduce(0, block = (|acc, n| (return (acc + n)))))"},
 		{"def x(); [1,2,3].reduce(0) {|acc, n| acc + n }; end", "(def x() (return ([1, 2, 3].reduce(0, block = (|acc, n| (return (acc + n)))))))"},
 		{`foo = "string"`, `(foo = "string")`},
@@ -103,6 +112,7 @@ end.length`, `(((foo([1, 2, 3, 4])).select(block = (|x| ((x % 2) == 0)))).length
 		{`puts (x + y) / 4`, `(Kernel.puts(((x + y) / 4)))`},
 		{`Pi = 3.14`, `(Pi = 3.14)`},
 		{`Math::Pi`, `(Math::Pi)`},
+		{`::Math::Pi`, `(::Math::Pi)`},
 		{`while x > 2
 		x -= 1
 		end`, `(while (x > 2) ((x = (x - 1))))`},
@@ -133,9 +143,13 @@ end.length`, `(((foo([1, 2, 3, 4])).select(block = (|x| ((x % 2) == 0)))).length
 
 		{`%w$foo bar baz$`, `%w['foo bar baz']`},
 		{`%W$foo #{5} baz$`, `%w["foo %d baz" % (5)]`},
+		{`%i$foo bar$`, `%i['foo bar']`},
+		{`%I$foo_#{5} bar$`, `%i["foo_%d bar" % (5)]`},
+		{`h[:"foo_#{5}"]`, `h[:("foo_%d" % (5))]`},
+		{`x = :"foo bar"`, `(x = :"foo bar")`},
 
 		{`class Foo; def bar(x); super; end; end`, `Foo((def bar(x) super(x)))`},
-		{`class Foo; def bar(x, y); @y = y; super(x); end; end`, `Foo({@y}; (def bar(x, y) (@y  = y); super(x)))`},
+		{`class Foo; def bar(x, y); @y = y; super(x); end; end`, `Foo({@y}; (def bar(x, y) (@y = y); super(x)))`},
 		{`module Foo; Bar = "bar"; class Baz; def quux; 100; end; end; end`, `Foo([Bar = "bar"]; Baz((def quux() (return 100))))`},
 		{`[1,
 	2,
@@ -158,6 +172,9 @@ end.length`, `(((foo([1, 2, 3, 4])).select(block = (|x| ((x % 2) == 0)))).length
 		{"def x(*n); return n[0]; end; x(5)", `(def x(*n) (return n[0]))
 (x(5))`},
 		{`def each; @items.each do |x| yield x; end; end`, `(def each(&blk) (@items.each(block = (|x| (blk.call(x))))))`},
+		{"at_exit do\n  puts 1\nend", "(Kernel.at_exit(block = (|| (Kernel.puts(1)))))"},
+		{"at_exit { puts 1 }", "(Kernel.at_exit(block = (|| (Kernel.puts(1)))))"},
+		{"foo = true\nwhile foo do\n  foo = false\nend", "(foo = true)\n(while foo ((foo = false)))"},
 	}
 
 	for i, tt := range tests {
@@ -503,6 +520,30 @@ foo("quux")`,
 			argumentTypes: map[string]types.Type{"x": types.IntType},
 			ReturnType:    types.IntType,
 		},
+		{
+			input: `
+			module Foo
+			  Bar = 10
+			end
+
+			module Outer
+			  module Foo
+				  Bar = "shadowed"
+				end
+
+			  class ::Baz
+				  Quux = ::Foo::Bar
+				end
+			end
+
+			def foo(x)
+				Baz::Quux + x
+			end
+			foo(1)
+			`,
+			argumentTypes: map[string]types.Type{"x": types.IntType},
+			ReturnType:    types.IntType,
+		},
 		{
 			input: `
 			def log(x)
@@ -572,6 +613,61 @@ foo("quux")`,
 			argumentTypes: map[string]types.Type{"items": types.NewArray(types.IntType)},
 			ReturnType:    types.NewArray(types.IntType),
 		},
+		{
+			input: `def foo(x)
+			  x ||= 10
+			  x + 1
+			end
+			foo(nil)
+			foo(3)`,
+			argumentTypes: map[string]types.Type{"x": types.NewOptional(types.IntType)},
+			ReturnType:    types.IntType,
+		},
+		{
+			input: `def foo(x)
+			  x ||= "default"
+			  x
+			end
+			foo("given")
+			foo(nil)`,
+			argumentTypes: map[string]types.Type{"x": types.NewOptional(types.StringType)},
+			ReturnType:    types.StringType,
+		},
+		{
+			input: `def foo(flag)
+			  if flag
+			    x = 42
+			  else
+			    x = "forty-two"
+			  end
+			  x
+			end
+			foo(true)`,
+			argumentTypes: map[string]types.Type{"flag": types.BoolType},
+			ReturnType:    types.NewUnion(types.IntType, types.StringType),
+		},
+		{
+			input: `def foo(flag)
+			  flag ? 1.5 : 2
+			end
+			foo(true)`,
+			argumentTypes: map[string]types.Type{"flag": types.BoolType},
+			ReturnType:    types.NewUnion(types.FloatType, types.IntType),
+		},
+		{
+			input: `def foo(flag)
+			  x = flag ? 1 : "one"
+			  case x
+			  when Integer
+			    x + 1
+			  when String
+			    x.size
+			  end
+			end
+			foo(true)`,
+			argumentTypes: map[string]types.Type{"flag": types.BoolType},
+			ReturnType:    types.IntType,
+		},
 	}
 
 	for i, tt := range tests {
@@ -1401,13 +1497,20 @@ func TestMethodParamInferenceErrors(t *testing.T) {
 		    bar - baz
 			end`, "line 1: unable to detect type signature of method 'foo' because it is never called"},
 		{`def foo(bar, baz)
-		    if bar == baz
+		    x = if bar == baz
 				  true
 				else
 				  7
 				end
+				x.even?
 			end
-			foo(1, 2)`, "line 2: Different branches of conditional returned different types: (if (bar == baz) true (else 7))"},
+			foo(1, 2)`, "line 7: No known method 'even?' on Union(BoolType, IntType)"},
+		{`def foo(bar)
+		    x = 1
+				x = "one" if bar
+				x + 1
+			end
+			foo(true)`, "line 4: Method '+' on Union(IntType, StringType) returns IntType for IntType but StringType for StringType"},
 		// Heterogeneous array literals now produce Tuple types (valid at parse time, may fail at compile time)
 		// {`def foo(bar, baz)
 		//     [bar, baz]
@@ -1469,6 +1572,24 @@ func TestMethodParamInferenceErrors(t *testing.T) {
 			bar = true
 			foo(1, *bar)
 			`, "line 5: tried to splat 'bar' but is not an array"},
+		{`def foo(config, key)
+			  config[key]
+			end
+			foo({name: "api", port: 80}, :port)`, "line 2: Hash with heterogeneous values accessed with non-static key key; only the symbol keys it was created with can be compiled to struct fields"},
+		{`def foo(config)
+			  config[:host]
+			end
+			foo({name: "api", port: 80})`, "line 2: Key :host is not one of the static keys (:name, :port) of a hash with heterogeneous values"},
+		{`def foo(config)
+			  config[:port] = "8080"
+			end
+			foo({name: "api", port: 80})`, "line 2: Attempted to assign StringType to config[:port], which holds IntType"},
+		{`def foo
+			  {"name" => "api", "port" => 80}
+			end
+			foo`, "line 2: Hash with heterogeneous values has non-static key \"name\"; only symbol keys can be compiled to struct fields"},
+		{`h = {a: 1, b: nil}
+			puts h[:a]`, "line 1: Hash with heterogeneous values has nil for key :b; its type can't be inferred for a struct field"},
 	}
 
 	for i, tt := range tests {
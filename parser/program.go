@@ -18,21 +18,15 @@ import (
 // NoGems disables gem source resolution via system Ruby. Facades still work.
 var NoGems bool
 
-// Verbosity controls the level of warning output. Default is 1 (show warnings).
-// Set to 0 to suppress all warnings and notes.
+// Verbosity controls which diagnostics are reported. Default is 1 (show
+// warnings); 2 adds notes, and 0 suppresses everything but errors.
 var Verbosity int = 1
 
-// Warn prints a warning to stderr if verbosity is >= the given level.
-func Warn(level int, format string, args ...interface{}) {
-	if Verbosity >= level {
-		fmt.Fprintf(os.Stderr, format, args...)
-	}
-}
-
-// Note prints a note to stderr if verbosity is >= 2.
-func Note(format string, args ...interface{}) {
-	Warn(2, format, args...)
-}
+// Tolerant makes ParseProgram press on past errors that would otherwise stop
+// it. Files that fail to parse, requires that can't be resolved and methods
+// that fail analysis are all recorded in Root.Errors, for surveying code that
+// isn't expected to compile yet.
+var Tolerant bool
 
 // builtinRequires lists require names that thanos handles natively via its
 // type system. These are silently stripped without needing a facade or gem source.
@@ -57,7 +51,8 @@ func ParseProgram(entryPath string) (*Root, error) {
 	}
 
 	// Overlay project-local facades (can override builtins)
-	if projectFacades := findFacadesConfig(filepath.Dir(absPath)); projectFacades != nil {
+	projectFacades, facadesPath := findFacadesConfig(filepath.Dir(absPath))
+	if projectFacades != nil {
 		for k, v := range projectFacades {
 			allFacades[k] = v
 		}
@@ -74,6 +69,9 @@ func ParseProgram(entryPath string) (*Root, error) {
 	}
 
 	root := NewRoot()
+	if facadesPath != "" {
+		root.Sources = append(root.Sources, facadesPath)
+	}
 	root.facades = allFacades
 	root.loadPaths = resolveLoadPaths(filepath.Dir(absPath))
 
@@ -95,12 +93,13 @@ func ParseProgram(entryPath string) (*Root, error) {
 	return root, nil
 }
 
-// findFacadesConfig walks up from dir looking for .thanos/facades.json.
-func findFacadesConfig(dir string) types.FacadeConfig {
+// findFacadesConfig walks up from dir looking for .thanos/facades.json,
+// returning the config and the path it was read from.
+func findFacadesConfig(dir string) (types.FacadeConfig, string) {
 	for {
 		path := filepath.Join(dir, ".thanos", "facades.json")
 		if config, err := types.LoadFacades(path); err == nil {
-			return config
+			return config, path
 		}
 		parent := filepath.Dir(dir)
 		if parent == dir {
@@ -108,7 +107,7 @@ func findFacadesConfig(dir string) types.FacadeConfig {
 		}
 		dir = parent
 	}
-	return nil
+	return nil, ""
 }
 
 // findThanosConfig walks up from dir looking for .thanos/config.yaml and
@@ -276,10 +275,7 @@ func resolveGemRequire(name string, loadPaths []string) string {
 // that may trigger panics due to unsupported Ruby constructs.
 func safeLoadFile(absPath string, root *Root, loaded map[string]bool) (err error) {
 	// Save parser state that might be corrupted by gem parsing
-	savedScopeLen := len(root.ScopeChain)
-	savedModuleStack := root.moduleStack.Size()
-	savedMethodSetStack := root.MethodSetStack.Size()
-	savedStateStack := root.State.Size()
+	restore := root.saveParserState()
 
 	defer func() {
 		root.loadingGem = false
@@ -288,24 +284,58 @@ func safeLoadFile(absPath string, root *Root, loaded map[string]bool) (err error
 			n := runtime.Stack(buf[:], false)
 			err = fmt.Errorf("panic: %v\n%s", r, buf[:n])
 		}
-		// Restore parser state if gem loading left stacks unbalanced
-		for root.moduleStack.Size() > savedModuleStack {
-			root.moduleStack.Pop()
+		restore()
+	}()
+	root.loadingGem = true
+	return loadFile(absPath, root, loaded)
+}
+
+// saveParserState returns a function that unwinds whatever a file that failed
+// to parse left on the parser's stacks.
+func (r *Root) saveParserState() (restore func()) {
+	savedScopeLen := len(r.ScopeChain)
+	savedModuleStack := r.moduleStack.Size()
+	savedMethodSetStack := r.MethodSetStack.Size()
+	savedStateStack := r.State.Size()
+	return func() {
+		for r.moduleStack.Size() > savedModuleStack {
+			r.moduleStack.Pop()
 		}
-		for root.MethodSetStack.Size() > savedMethodSetStack {
-			root.MethodSetStack.Pop()
+		for r.MethodSetStack.Size() > savedMethodSetStack {
+			r.MethodSetStack.Pop()
 		}
-		for root.State.Size() > savedStateStack {
-			root.State.Pop()
+		for r.State.Size() > savedStateStack {
+			r.State.Pop()
 		}
-		if len(root.ScopeChain) > savedScopeLen {
-			root.ScopeChain = root.ScopeChain[:savedScopeLen]
+		if len(r.ScopeChain) > savedScopeLen {
+			r.ScopeChain = r.ScopeChain[:savedScopeLen]
 		}
-		root.currentClass = nil
-		root.currentMethod = nil
-	}()
-	root.loadingGem = true
-	return loadFile(absPath, root, loaded)
+		r.currentClass = nil
+		r.currentMethod = nil
+	}
+}
+
+// Overlay maps absolute paths to source that takes the place of what's on
+// disk, such as the unsaved buffers of an editor.
+var Overlay map[string][]byte
+
+// readSource returns the contents of the Ruby file at absPath, preferring
+// the Overlay.
+func readSource(absPath string) ([]byte, error) {
+	if b, ok := Overlay[absPath]; ok {
+		return b, nil
+	}
+	f, err := os.Open(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open %s: %w", absPath, err)
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", absPath, err)
+	}
+	return b, nil
 }
 
 // loadFile reads and parses a single Ruby file into the shared Root, then
@@ -316,24 +346,31 @@ func loadFile(absPath string, root *Root, loaded map[string]bool) error {
 		return nil
 	}
 	loaded[absPath] = true
+	root.Sources = append(root.Sources, absPath)
 
-	f, err := os.Open(absPath)
+	b, err := readSource(absPath)
 	if err != nil {
-		return fmt.Errorf("could not open %s: %w", absPath, err)
+		return err
 	}
-	defer f.Close()
-
-	b, err := io.ReadAll(f)
-	if err != nil {
-		return fmt.Errorf("could not read %s: %w", absPath, err)
+	if len(loaded) == 1 {
+		var suite bool
+		if b, suite = normalizeTestSuite(absPath, b); suite {
+			root.TestSuite = absPath
+			types.CompileTests(true)
+		}
 	}
 
+	errCount, stmtCount := len(root.Errors), len(root.Statements)
 	parser := yyNewParser()
 	l := NewLexerWithRoot(b, root, absPath)
 	parser.Parse(l)
 
-	if pErr := root.ParseError(); pErr != nil {
-		return pErr
+	if len(root.Errors) > errCount {
+		return root.Errors[errCount]
+	}
+
+	if !root.loadingGem {
+		root.loadSignatureDir(filepath.Join(filepath.Dir(absPath), "sig"))
 	}
 
 	// Record Data.define / Struct.new assignments before require-stripping
@@ -343,9 +380,12 @@ func loadFile(absPath string, root *Root, loaded map[string]bool) error {
 	// Scan statements for require/require_relative calls, resolve and load them,
 	// then strip them from the Root. Gem files that fail to load are deferred and
 	// retried after all other files are processed.
+	// Only this file's statements are scanned: those before them belong to
+	// the file that required it, and their paths are relative to its
+	// directory.
 	dir := filepath.Dir(absPath)
 	var remaining []Node
-	for _, stmt := range root.Statements {
+	for _, stmt := range root.Statements[stmtCount:] {
 		if call, ok := stmt.(*MethodCall); ok && call.Receiver == nil {
 			switch call.MethodName {
 			case "require_relative":
@@ -359,8 +399,13 @@ func loadFile(absPath string, root *Root, loaded map[string]bool) error {
 						if err != nil {
 							return fmt.Errorf("could not resolve require_relative %q: %w", rel, err)
 						}
+						restore := root.saveParserState()
 						if err := loadFile(depAbs, root, loaded); err != nil {
-							return err
+							if !Tolerant {
+								return err
+							}
+							restore()
+							root.tolerate(err)
 						}
 						continue // strip
 					}
@@ -372,7 +417,7 @@ func loadFile(absPath string, root *Root, loaded map[string]bool) error {
 							if lib, hasBind := root.facades[name]; hasBind {
 								// Warn if the facade is marked incomplete
 								if lib.Coverage != "" {
-									Warn(1, "warning: require '%s': facade coverage is %s — some methods may not be available\n", name, lib.Coverage)
+									Report(NewDiagnostic(call, SeverityWarning, "facade-incomplete", "require '%s': facade coverage is %s — some methods may not be available", name, lib.Coverage))
 								}
 								// Inject scope entries for this require (e.g., CSV::Row, CSV::Table)
 								if inject, ok := requireScopeInjectors[name]; ok {
@@ -387,24 +432,30 @@ func loadFile(absPath string, root *Root, loaded map[string]bool) error {
 						}
 						// No facade — try resolving via Ruby load paths
 						if gemPath := resolveGemRequire(name, root.loadPaths); gemPath != "" {
+							root.loadSignatureDir(gemSignatureDir(gemPath, name))
 							if !loaded[gemPath] {
-								Warn(1, "warning: require '%s': resolved from gem source at %s — compilation may be incomplete\n", name, gemPath)
+								Report(NewDiagnostic(call, SeverityWarning, "gem-source", "require '%s': resolved from gem source at %s — compilation may be incomplete", name, gemPath))
 								savedErrors := append([]error{}, root.Errors...)
 								if err := safeLoadFile(gemPath, root, loaded); err != nil {
-									Warn(1, "warning: require '%s' (%s): load failed:\n%v\n", name, gemPath, err)
+									Report(NewDiagnostic(call, SeverityWarning, "gem-load-failed", "require '%s' (%s): load failed:\n%v", name, gemPath, err))
 								}
 								root.Errors = savedErrors
 							}
 							continue // strip
 						}
-						return fmt.Errorf("line %d: cannot locate source for require '%s' (no facade and no gem source found)", call.LineNo(), name)
+						err := NewParseError(call, "cannot locate source for require '%s' (no facade and no gem source found)", name)
+						if !Tolerant {
+							return err
+						}
+						root.tolerate(err)
+						continue // strip
 					}
 				}
 			}
 		}
 		remaining = append(remaining, stmt)
 	}
-	root.Statements = remaining
+	root.Statements = append(root.Statements[:stmtCount:stmtCount], remaining...)
 
 	return nil
 }
@@ -477,14 +528,15 @@ func stripRequires(root *Root, allFacades types.FacadeConfig) {
 					}
 					// No facade — try resolving via Ruby load paths
 					if gemPath := resolveGemRequire(name, root.loadPaths); gemPath != "" {
+						root.loadSignatureDir(gemSignatureDir(gemPath, name))
 						if loaded == nil {
 							loaded = make(map[string]bool)
 						}
 						if !loaded[gemPath] {
-							Warn(1, "warning: require '%s': resolved from gem source at %s — compilation may be incomplete\n", name, gemPath)
+							Report(NewDiagnostic(call, SeverityWarning, "gem-source", "require '%s': resolved from gem source at %s — compilation may be incomplete", name, gemPath))
 							savedErrors := append([]error{}, root.Errors...)
 							if err := safeLoadFile(gemPath, root, loaded); err != nil {
-								Warn(1, "warning: require '%s' (%s): load failed:\n%v\n", name, gemPath, err)
+								Report(NewDiagnostic(call, SeverityWarning, "gem-load-failed", "require '%s' (%s): load failed:\n%v", name, gemPath, err))
 							}
 							// Always restore errors — gem parsing may add terminal errors
 							// via AddCall that shouldn't block user code analysis.
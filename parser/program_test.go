@@ -1,9 +1,12 @@
 package parser
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/redneckbeard/thanos/types"
 )
 
 func TestParseProgram(t *testing.T) {
@@ -45,6 +48,11 @@ puts h.value
 		t.Fatalf("expected class Helper, got %s", root.Classes[0].Name())
 	}
 
+	// Both files are recorded as sources, entry file first
+	if len(root.Sources) != 2 || root.Sources[0] != entryPath || root.Sources[1] != helperPath {
+		t.Fatalf("expected sources [%s %s], got %v", entryPath, helperPath, root.Sources)
+	}
+
 	// require_relative should be stripped from statements
 	for _, stmt := range root.Statements {
 		if call, ok := stmt.(*MethodCall); ok && call.MethodName == "require_relative" {
@@ -92,6 +100,50 @@ puts m.greet
 	}
 }
 
+func TestParseProgramSubdirectories(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "lib"), 0755)
+
+	// Each require_relative resolves against the directory of the file it
+	// appears in, not that of a file it requires.
+	os.WriteFile(filepath.Join(dir, "lib", "util.rb"), []byte(`
+def double(x)
+  x * 2
+end
+`), 0644)
+
+	os.WriteFile(filepath.Join(dir, "lib", "shapes.rb"), []byte(`
+require_relative 'util'
+
+class Square
+  def initialize(side)
+    @side = side
+  end
+
+  def area
+    double(@side) / 2 * @side
+  end
+end
+`), 0644)
+
+	os.WriteFile(filepath.Join(dir, "main.rb"), []byte(`
+require_relative 'lib/shapes'
+require_relative 'lib/util'
+
+puts Square.new(3).area
+puts double(4)
+`), 0644)
+
+	root, err := ParseProgram(filepath.Join(dir, "main.rb"))
+	if err != nil {
+		t.Fatalf("ParseProgram failed: %v", err)
+	}
+
+	if len(root.Classes) != 1 {
+		t.Fatalf("expected 1 class, got %d", len(root.Classes))
+	}
+}
+
 func TestParseProgramDedup(t *testing.T) {
 	dir := t.TempDir()
 
@@ -131,3 +183,135 @@ puts s.val
 		t.Fatalf("expected 1 class (deduped), got %d", len(root.Classes))
 	}
 }
+
+func TestParseProgramSignatures(t *testing.T) {
+	dir := t.TempDir()
+	os.Mkdir(filepath.Join(dir, "sig"), 0755)
+
+	// Nothing in the program calls Greeter or double, so their types come
+	// only from the RBS file.
+	os.WriteFile(filepath.Join(dir, "lib.rb"), []byte(`
+class Greeter
+  def greet(name, times)
+    name * times
+  end
+end
+
+def double(xs, scale: 2)
+  xs.map { |x| x * scale }
+end
+
+def label(n)
+  "n=#{n}"
+end
+
+puts label(3)
+
+# Without its signature, the recursive call would be typed before the
+# method's return type is known.
+def countdown(n)
+  if n > 0
+    countdown(n - 1) + 1
+  else
+    0
+  end
+end
+
+puts countdown(3)
+`), 0644)
+
+	os.WriteFile(filepath.Join(dir, "sig", "lib.rbs"), []byte(`
+class Greeter
+  def greet: (String name, Integer times) -> String
+           | (String name) -> String
+end
+
+class Object
+  def double: (Array[Integer], ?scale: Integer) -> Array[Integer]
+  def label: (String n) -> String
+  def countdown: (Integer n) -> Integer
+end
+`), 0644)
+
+	verbosity := Verbosity
+	Verbosity = 0
+	defer func() { Verbosity = verbosity }()
+
+	root, err := ParseProgram(filepath.Join(dir, "lib.rb"))
+	if err != nil {
+		t.Fatalf("ParseProgram failed: %v", err)
+	}
+
+	greet := root.Classes[0].MethodSet.Methods["greet"]
+	if greet.IsUncallable() || greet.Params[1].Type() != types.IntType || greet.ReturnType() != types.StringType {
+		t.Errorf("expected greet(String, Integer) -> String, got %s", greet)
+	}
+	double := root.MethodSetStack.Peek().Methods["double"]
+	if !double.ReturnType().Equals(types.NewArray(types.IntType)) {
+		t.Errorf("expected double to return Array(IntType), got %s", double.ReturnType())
+	}
+
+	if countdown := root.MethodSetStack.Peek().Methods["countdown"]; countdown.ReturnType() != types.IntType {
+		t.Errorf("expected countdown to return IntType, got %s", countdown.ReturnType())
+	}
+
+	expected := fmt.Sprintf("warning: line 12: RBS signature for 'label' (%s:9) declares parameter 'n' as StringType but it is inferred as IntType", filepath.Join(dir, "sig", "lib.rbs"))
+	if len(root.Warnings) != 1 || root.Warnings[0].String() != expected {
+		t.Fatalf("expected warning %q, got %v", expected, root.Warnings)
+	}
+	if w := root.Warnings[0]; w.Code != "rbs-mismatch" || len(w.Related) != 1 || w.Related[0].Line != 9 || w.Related[0].Column != 3 {
+		t.Errorf("expected rbs-mismatch warning related to sig/lib.rbs:9:3, got %+v", w)
+	}
+}
+
+func TestParseSignatures(t *testing.T) {
+	sigs := parseSignatures(`
+module Shapes
+  class Circle < Shape
+    # Overloads after the first are ignored
+    def area: () -> Float
+    def self.unit: () -> Circle
+    def scale: (
+      Float factor,
+      ?round: bool
+    ) { (Float) -> void } -> Float?
+  end
+
+  interface _Sized
+    def size: () -> Integer
+  end
+end
+`, "shapes.rbs")
+
+	area, ok := sigs["Shapes::Circle#area"]
+	if !ok || area.Return != "Float" || len(area.Params) != 0 || area.Location() != "shapes.rbs:5" {
+		t.Errorf("unexpected signature for area: %+v", area)
+	}
+	if _, ok := sigs["Shapes::Circle.unit"]; !ok {
+		t.Error("expected a signature for Shapes::Circle.unit")
+	}
+	scale := sigs["Shapes::Circle#scale"]
+	expected := []SignatureParam{{Name: "factor", Kind: Positional, Type: "Float"}, {Name: "round", Kind: Keyword, Type: "bool"}}
+	if scale == nil || scale.Return != "Float?" || len(scale.Params) != 2 || scale.Params[0] != expected[0] || scale.Params[1] != expected[1] {
+		t.Errorf("unexpected signature for scale: %+v", scale)
+	}
+	if len(sigs) != 3 {
+		t.Errorf("expected interface members to be skipped, got %d signatures", len(sigs))
+	}
+}
+
+// Gauntlets that each assign a local of the same name, Rational in one and
+// Complex in another, have separate variables rather than one widened to a
+// union of the two.
+func TestSiblingBlocksHaveSeparateLocals(t *testing.T) {
+	root, err := ParseFile("../tests/rational_imaginary.rb")
+	if root == nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if calls := root.MethodSetStack.Peek().Calls["gauntlet"]; len(calls) == 0 {
+		t.Fatal("expected the gauntlets to be parsed")
+	}
+	if len(Unions) > 0 {
+		t.Errorf("expected no unions, got %s", Unions)
+	}
+}
@@ -0,0 +1,581 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/redneckbeard/thanos/types"
+)
+
+// RBS support is deliberately minimal: thanos reads the method declarations
+// in `sig/*.rbs` files and uses them to type methods that have no callers in
+// the program, such as library entry points. Everything else in the file
+// (interfaces, type aliases, attributes, constants, mixins) is skipped.
+//
+//	class Greeter
+//	  def greet: (String name, ?Integer times) -> String
+//	  def self.default: () -> Greeter
+//	end
+//
+// Top-level methods are declared on Object, as Ruby defines them there.
+
+// Signature is a method declaration read from an RBS file. Types are kept as
+// source text until Root.Analyze resolves them, since they may name classes
+// that are only registered once the whole program has been parsed.
+type Signature struct {
+	Params []SignatureParam
+	Return string
+	file   string
+	lineNo int
+}
+
+// SignatureParam is a single parameter of an RBS method type.
+type SignatureParam struct {
+	Name string // empty if the signature doesn't name the parameter
+	Kind ParamKind
+	Type string
+}
+
+// Location is the file and line the signature was read from, for warnings.
+func (sig *Signature) Location() string {
+	return fmt.Sprintf("%s:%d", DisplayPath(sig.file), sig.lineNo)
+}
+
+// diagnostic builds a warning about the signature at the definition of m,
+// pointing back at the signature itself.
+func (sig *Signature) diagnostic(m *Method, format string, args ...interface{}) Diagnostic {
+	d := NewDiagnostic(m, SeverityWarning, "rbs-mismatch", format, args...)
+	d.Related = []RelatedLocation{{
+		Location: Location{File: sig.file, Line: sig.lineNo, Column: sourceColumn(sig.file, sig.lineNo, "def")},
+		Message:  "RBS signature",
+	}}
+	return d
+}
+
+var (
+	rbsDeclPatt      = regexp.MustCompile(`^(class|module|interface)\s+(?:::)?([\w:]+)`)
+	rbsDefPatt       = regexp.MustCompile(`^def\s+(self\??\.)?(\S+?)\s*:\s*(.*)$`)
+	rbsParamNamePatt = regexp.MustCompile(`^[a-z_]\w*$`)
+	rbsKeywordPatt   = regexp.MustCompile(`^(\w+[?!]?):\s+(.*)$`)
+)
+
+// rbsTypeKeywords are lowercase words that are types rather than parameter
+// names.
+var rbsTypeKeywords = map[string]bool{
+	"bool": true, "boolish": true, "untyped": true, "nil": true, "void": true,
+	"self": true, "instance": true, "class": true, "top": true, "bot": true,
+}
+
+// loadSignatureDir reads every .rbs file in dir into the Root's signatures.
+// A directory is only read once, and a missing directory is not an error.
+func (r *Root) loadSignatureDir(dir string) {
+	if r.signatureDirs[dir] {
+		return
+	}
+	r.signatureDirs[dir] = true
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.rbs"))
+	sort.Strings(paths)
+	for _, path := range paths {
+		r.Sources = append(r.Sources, path)
+		b, err := os.ReadFile(path)
+		if err != nil {
+			Report(Diagnostic{Location: Location{File: path}, Severity: SeverityWarning, Code: "rbs-unreadable", Message: fmt.Sprintf("could not read %s: %v", path, err)})
+			continue
+		}
+		for key, sig := range parseSignatures(string(b), path) {
+			if _, seen := r.signatures[key]; !seen {
+				r.signatures[key] = sig
+			}
+		}
+	}
+}
+
+// gemSignatureDir returns the sig directory of the gem that a require
+// resolved to, given the load path entry it was found in (`<gem>/lib`).
+func gemSignatureDir(gemPath, name string) string {
+	libDir := strings.TrimSuffix(gemPath, filepath.FromSlash(name)+".rb")
+	return filepath.Join(filepath.Dir(filepath.Clean(libDir)), "sig")
+}
+
+// parseSignatures extracts method declarations from RBS source, keyed by
+// `Class#method` for instance methods and `Class.method` for singleton
+// methods. Overloaded methods take their first overload.
+func parseSignatures(src, file string) map[string]*Signature {
+	sigs := map[string]*Signature{}
+	// Each frame is a namespace, or "" for a body whose members are ignored.
+	var frames []string
+	namespace := func() string {
+		var parts []string
+		for _, f := range frames {
+			if f == "" {
+				return ""
+			}
+			parts = append(parts, f)
+		}
+		if len(parts) == 0 {
+			return "Object"
+		}
+		return strings.Join(parts, "::")
+	}
+	for _, line := range joinRBSLines(src) {
+		text := line.text
+		switch {
+		case text == "end":
+			if len(frames) > 0 {
+				frames = frames[:len(frames)-1]
+			}
+		case rbsDeclPatt.MatchString(text):
+			m := rbsDeclPatt.FindStringSubmatch(text)
+			name := m[2]
+			if m[1] == "interface" {
+				name = ""
+			}
+			frames = append(frames, name)
+			if strings.HasSuffix(text, " end") {
+				frames = frames[:len(frames)-1]
+			}
+		case rbsDefPatt.MatchString(text):
+			ns := namespace()
+			if ns == "" {
+				continue
+			}
+			m := rbsDefPatt.FindStringSubmatch(text)
+			sig, ok := parseMethodType(m[3])
+			if !ok {
+				continue
+			}
+			sig.file, sig.lineNo = file, line.lineNo
+			switch m[1] {
+			case "self.":
+				sigs[ns+"."+m[2]] = sig
+			case "self?.":
+				sigs[ns+"."+m[2]] = sig
+				sigs[ns+"#"+m[2]] = sig
+			default:
+				sigs[ns+"#"+m[2]] = sig
+			}
+		}
+	}
+	return sigs
+}
+
+type rbsLine struct {
+	text   string
+	lineNo int
+}
+
+// joinRBSLines strips comments and joins declarations that span several lines
+// (open brackets, or overloads continued with a leading `|`) into one.
+func joinRBSLines(src string) []rbsLine {
+	var lines []rbsLine
+	depth := 0
+	for i, raw := range strings.Split(src, "\n") {
+		if idx := strings.Index(raw, "#"); idx >= 0 {
+			raw = raw[:idx]
+		}
+		text := strings.TrimSpace(raw)
+		if text == "" {
+			continue
+		}
+		if len(lines) > 0 && (depth > 0 || strings.HasPrefix(text, "|")) {
+			lines[len(lines)-1].text += " " + text
+		} else {
+			lines = append(lines, rbsLine{text: text, lineNo: i + 1})
+		}
+		depth += strings.Count(text, "(") + strings.Count(text, "[") + strings.Count(text, "{")
+		depth -= strings.Count(text, ")") + strings.Count(text, "]") + strings.Count(text, "}")
+	}
+	return lines
+}
+
+// splitTopLevel splits s on sep wherever it occurs outside brackets.
+func splitTopLevel(s string, sep byte) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
+
+// closingBracket returns the index of the bracket closing the one at s[open].
+func closingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseMethodType reads the first overload of an RBS method type:
+//
+//	[T] (params) ?{ block } -> return
+func parseMethodType(s string) (*Signature, bool) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "[") {
+		end := closingBracket(s, 0)
+		if end < 0 {
+			return nil, false
+		}
+		s = strings.TrimSpace(s[end+1:])
+	}
+	sig := &Signature{}
+	if strings.HasPrefix(s, "(") {
+		end := closingBracket(s, 0)
+		if end < 0 {
+			return nil, false
+		}
+		if params := strings.TrimSpace(s[1:end]); params != "" {
+			for _, p := range splitTopLevel(params, ',') {
+				sig.Params = append(sig.Params, parseSignatureParam(p))
+			}
+		}
+		s = strings.TrimSpace(s[end+1:])
+	}
+	s = strings.TrimPrefix(s, "?")
+	if strings.HasPrefix(s, "{") {
+		end := closingBracket(s, 0)
+		if end < 0 {
+			return nil, false
+		}
+		s = strings.TrimSpace(s[end+1:])
+	}
+	if !strings.HasPrefix(s, "->") {
+		return nil, false
+	}
+	ret := strings.TrimSpace(s[2:])
+	// A top-level `|` followed by a parameter list starts the next overload;
+	// one followed by a type continues a union return type.
+	var kept []string
+	for i, alt := range splitTopLevel(ret, '|') {
+		if i > 0 && (strings.HasPrefix(alt, "(") || strings.HasPrefix(alt, "[") || strings.HasPrefix(alt, "{") || strings.HasPrefix(alt, "?{")) {
+			break
+		}
+		kept = append(kept, alt)
+	}
+	sig.Return = strings.Join(kept, " | ")
+	return sig, true
+}
+
+func parseSignatureParam(s string) SignatureParam {
+	p := SignatureParam{Kind: Positional}
+	switch {
+	case strings.HasPrefix(s, "**"):
+		p.Kind, s = DoubleSplat, s[2:]
+	case strings.HasPrefix(s, "*"):
+		p.Kind, s = Splat, s[1:]
+	case rbsKeywordPatt.MatchString(strings.TrimPrefix(s, "?")):
+		m := rbsKeywordPatt.FindStringSubmatch(strings.TrimPrefix(s, "?"))
+		return SignatureParam{Name: m[1], Kind: Keyword, Type: strings.TrimSpace(m[2])}
+	case strings.HasPrefix(s, "?"):
+		p.Kind, s = Named, s[1:]
+	}
+	s = strings.TrimSpace(s)
+	if idx := strings.LastIndexByte(s, ' '); idx > 0 {
+		name := s[idx+1:]
+		rest := strings.TrimSpace(s[:idx])
+		if rbsParamNamePatt.MatchString(name) && !rbsTypeKeywords[name] && !strings.HasSuffix(rest, "|") {
+			p.Name, s = name, rest
+		}
+	}
+	p.Type = s
+	return p
+}
+
+// resolveSignatureType converts an RBS type to a thanos type. It returns nil
+// with no error for types that carry no information (untyped, void, type
+// variables), and an error for types thanos can't represent.
+func resolveSignatureType(s string, self types.Type) (types.Type, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "(") && closingBracket(s, 0) == len(s)-1 {
+		return resolveSignatureType(s[1:len(s)-1], self)
+	}
+	if alts := splitTopLevel(s, '|'); len(alts) > 1 {
+		var (
+			members  []types.Type
+			optional bool
+		)
+		for _, alt := range alts {
+			t, err := resolveSignatureType(alt, self)
+			if err != nil || t == nil {
+				return t, err
+			}
+			if t == types.NilType {
+				optional = true
+			} else if opt, ok := t.(types.Optional); ok {
+				optional = true
+				members = append(members, opt.Element)
+			} else {
+				members = append(members, t)
+			}
+		}
+		var t types.Type
+		switch len(members) {
+		case 0:
+			return types.NilType, nil
+		case 1:
+			t = members[0]
+		default:
+			u, err := unionOf(members...)
+			if err != nil {
+				return nil, err
+			}
+			t = u
+		}
+		if optional {
+			return types.NewOptional(t), nil
+		}
+		return t, nil
+	}
+	if strings.HasSuffix(s, "?") {
+		inner, err := resolveSignatureType(s[:len(s)-1], self)
+		if inner == nil || err != nil {
+			return inner, err
+		}
+		return types.NewOptional(inner), nil
+	}
+	if idx := strings.IndexByte(s, '['); idx > 0 && strings.HasSuffix(s, "]") {
+		var args []types.Type
+		for _, arg := range splitTopLevel(s[idx+1:len(s)-1], ',') {
+			t, err := resolveSignatureType(arg, self)
+			if t == nil || err != nil {
+				return t, err
+			}
+			args = append(args, t)
+		}
+		switch name := strings.TrimPrefix(s[:idx], "::"); {
+		case name == "Array" && len(args) == 1:
+			return types.NewArray(args[0]), nil
+		case name == "Hash" && len(args) == 2:
+			return types.NewHash(args[0], args[1]), nil
+		case name == "Set" && len(args) == 1:
+			return types.NewSet(args[0]), nil
+		case name == "Range" && len(args) == 1:
+			return types.NewRange(args[0]), nil
+		}
+		return nil, fmt.Errorf("unsupported type '%s'", s)
+	}
+	switch s {
+	case "untyped", "top", "void":
+		return nil, nil
+	case "Integer":
+		return types.IntType, nil
+	case "Float":
+		return types.FloatType, nil
+	case "String":
+		return types.StringType, nil
+	case "Symbol":
+		return types.SymbolType, nil
+	case "bool", "boolish", "TrueClass", "FalseClass", "true", "false":
+		return types.BoolType, nil
+	case "nil", "NilClass":
+		return types.NilType, nil
+	case "self", "instance":
+		return self, nil
+	}
+	switch {
+	case strings.HasPrefix(s, `"`), strings.HasPrefix(s, `'`):
+		return types.StringType, nil
+	case strings.HasPrefix(s, ":"):
+		return types.SymbolType, nil
+	case strings.Trim(s, "-0123456789") == "":
+		return types.IntType, nil
+	}
+	name := strings.TrimPrefix(s, "::")
+	if !strings.Contains(name, "::") && len(name) == 1 {
+		// A single capital letter is a type variable.
+		return nil, nil
+	}
+	for _, candidate := range []string{name, name[strings.LastIndex(name, ":")+1:]} {
+		if cls, err := types.ClassRegistry.Get(candidate); err == nil && cls.Instance != nil {
+			return cls.Instance.(types.Type), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown type '%s'", s)
+}
+
+// seedSignatures attaches the signatures loaded from RBS files to the methods
+// they describe, so that Method.Analyze can type parameters that no call site
+// in the program provides.
+func (r *Root) seedSignatures() {
+	if len(r.signatures) == 0 {
+		return
+	}
+	attach := func(owner string, ms *MethodSet, classMethods []*Method) {
+		var class *Class
+		if ms != nil {
+			class = ms.Class
+			for _, m := range ms.Methods {
+				if sig, ok := r.signatures[owner+"#"+m.Name]; ok {
+					m.setSignature(sig, class)
+				}
+			}
+		}
+		for _, m := range classMethods {
+			if sig, ok := r.signatures[owner+"."+m.Name]; ok {
+				m.setSignature(sig, class)
+			}
+		}
+	}
+	attach("Object", r.MethodSetStack.Peek(), nil)
+	for _, cls := range r.Classes {
+		attach(cls.name, cls.MethodSet, cls.ClassMethods)
+	}
+	var walk func(mod *Module, prefix string)
+	walk = func(mod *Module, prefix string) {
+		name := prefix + mod.name
+		attach(name, mod.MethodSet, mod.ClassMethods)
+		for _, cls := range mod.Classes {
+			attach(name+"::"+cls.name, cls.MethodSet, cls.ClassMethods)
+		}
+		for _, sub := range mod.Modules {
+			walk(sub, name+"::")
+		}
+	}
+	for _, mod := range r.TopLevelModules {
+		walk(mod, "")
+	}
+}
+
+// setSignature attaches sig to the method and resolves the return type it
+// declares. Until the body has been analyzed, that is the type recursive
+// calls to the method get, rather than nil.
+func (m *Method) setSignature(sig *Signature, class *Class) {
+	m.Signature = sig
+	if t, err := resolveSignatureType(sig.Return, m.signatureSelf(class)); err == nil {
+		m.signatureReturn = t
+	}
+}
+
+// signatureParam finds the RBS parameter describing param: keywords by name,
+// the rest parameter by kind, and positional parameters by position.
+func (sig *Signature) signatureParam(m *Method, param *Param) (SignatureParam, bool) {
+	switch param.Kind {
+	case Keyword, DoubleSplat, Splat:
+		for _, sp := range sig.Params {
+			if sp.Kind == param.Kind && (param.Kind != Keyword || sp.Name == param.Name) {
+				return sp, true
+			}
+		}
+	case Positional, Named:
+		i := 0
+		for _, p := range m.Params {
+			if p == param {
+				break
+			}
+			if p.Kind == Positional || p.Kind == Named {
+				i++
+			}
+		}
+		for _, sp := range sig.Params {
+			if sp.Kind == Positional || sp.Kind == Named {
+				if i == 0 {
+					return sp, true
+				}
+				i--
+			}
+		}
+	}
+	return SignatureParam{}, false
+}
+
+// applySignature types the method's parameters from its RBS signature where
+// inference from call sites came up empty, and warns where the two disagree.
+func (m *Method) applySignature(class *Class) {
+	sig := m.Signature
+	if sig == nil {
+		return
+	}
+	name := m.signatureName(class)
+	for _, param := range m.Params {
+		if param.HasNilDefault() {
+			continue
+		}
+		sp, ok := sig.signatureParam(m, param)
+		if !ok {
+			continue
+		}
+		t, err := resolveSignatureType(sp.Type, m.signatureSelf(class))
+		if err != nil {
+			m.Root.Warn(sig.diagnostic(m, "RBS signature for '%s' (%s): %s", name, sig.Location(), err))
+			continue
+		}
+		if t == nil {
+			continue
+		}
+		switch param.Kind {
+		case Splat:
+			t = types.NewArray(t)
+		case DoubleSplat:
+			t = types.NewHash(types.SymbolType, t)
+		}
+		if param.Type() == nil {
+			if param.Kind == Splat {
+				param._type = t.(types.Array).Element
+			} else {
+				param._type = t
+			}
+			m.Locals.Set(param.Name, &RubyLocal{_type: param.Type()})
+		} else if !param.Type().Equals(t) {
+			m.Root.Warn(sig.diagnostic(m, "RBS signature for '%s' (%s) declares parameter '%s' as %s but it is inferred as %s", name, sig.Location(), param.Name, t, param.Type()))
+		}
+	}
+}
+
+// checkSignatureReturn warns if the inferred return type of the method
+// disagrees with its RBS signature.
+func (m *Method) checkSignatureReturn(class *Class) {
+	sig, t := m.Signature, m.signatureReturn
+	if sig == nil || t == nil || m.ReturnType() == nil {
+		return
+	}
+	if !m.ReturnType().Equals(t) {
+		m.Root.Warn(sig.diagnostic(m, "RBS signature for '%s' (%s) declares return type %s but it is inferred as %s", m.signatureName(class), sig.Location(), t, m.ReturnType()))
+	}
+}
+
+func (m *Method) signatureName(class *Class) string {
+	switch {
+	case class == nil:
+		return m.Name
+	case m.ClassMethod:
+		return class.Name() + "." + m.Name
+	default:
+		return class.Name() + "#" + m.Name
+	}
+}
+
+func (m *Method) signatureSelf(class *Class) types.Type {
+	if class == nil || class.Type() == nil {
+		return nil
+	}
+	if cls, ok := class.Type().(*types.Class); ok && !m.ClassMethod {
+		return cls.Instance.(types.Type)
+	}
+	return class.Type()
+}
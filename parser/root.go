@@ -46,10 +46,17 @@ type Root struct {
 	singletonTargetDepth int
 	nextConstantType    int
 	cpathDepth          int
+	rootCpath           bool // the cpath being pushed started with `::`
 	facades             types.FacadeConfig
 	loadPaths           []string
 	loadingGem          bool // true while parsing gem source files
 	dataDefineNames     map[string]bool // fully-qualified names assigned via Data.define
+	signatures          map[string]*Signature // RBS method signatures, keyed Class#method or Class.method
+	signatureDirs       map[string]bool
+	warned              map[string]bool
+	Warnings            []Diagnostic
+	Sources             []string // every file the program was read from: Ruby, facade configs and RBS signatures
+	TestSuite           string   // path of the entry file when it is a Minitest or RSpec suite, to be compiled into a Go test
 }
 
 func NewRoot() *Root {
@@ -58,6 +65,8 @@ func NewRoot() *Root {
 	ResetGlobalVars()
 	ResetDuckInterfaces()
 	ResetSynthStructs()
+	ResetUnions()
+	types.CompileTests(false)
 	p := &Root{
 		State:           &Stack[State]{},
 		StringStack:     &Stack[*StringNode]{},
@@ -66,11 +75,26 @@ func NewRoot() *Root {
 		Comments:        make(map[int]Comment),
 		ScopeChain:      ScopeChain{NewScope(Main)},
 		dataDefineNames: make(map[string]bool),
+		signatures:      make(map[string]*Signature),
+		signatureDirs:   make(map[string]bool),
+		warned:          make(map[string]bool),
 	}
 	types.ClassRegistry.Initialize()
 	return p
 }
 
+// Warn reports a problem that doesn't stop compilation. Methods may be
+// analyzed more than once, so each distinct warning is only reported once.
+func (r *Root) Warn(d Diagnostic) {
+	w := d.String()
+	if r.warned[w] {
+		return
+	}
+	r.warned[w] = true
+	r.Warnings = append(r.Warnings, d)
+	Report(d)
+}
+
 func (r *Root) AddComment(c Comment) {
 	if r.loadingGem {
 		return
@@ -86,9 +110,31 @@ func (r *Root) AddError(err error) {
 	r.Errors = append(r.Errors, err)
 }
 
+// tolerate records an error that Tolerant analysis carries on past, unless it
+// has been recorded already.
+func (r *Root) tolerate(err error) {
+	for _, e := range r.Errors {
+		if e == err || e.Error() == err.Error() {
+			return
+		}
+	}
+	r.AddError(err)
+}
+
+// halt reports whether analysis should stop at err. When Tolerant it never
+// does, recording err instead.
+func (r *Root) halt(err error) bool {
+	if Tolerant {
+		r.tolerate(err)
+		return false
+	}
+	return true
+}
+
 type ParseError struct {
 	node     Node
 	msg      string
+	code     string
 	terminal bool
 }
 
@@ -99,15 +145,25 @@ func (p *ParseError) Error() string {
 	return fmt.Sprintf("line %d: %s", p.node.LineNo(), p.msg)
 }
 
+// Code classifies the error for structured diagnostics, e.g. "cannot-infer".
+func (p *ParseError) Code() string {
+	return p.code
+}
+
 func (p *ParseError) Terminal() *ParseError {
 	p.terminal = true
 	return p
 }
 
 func NewParseError(node Node, fmtString string, args ...interface{}) *ParseError {
+	code, ok := parseErrorCodes[fmtString]
+	if !ok {
+		code = "analysis-error"
+	}
 	return &ParseError{
 		node: node,
 		msg:  fmt.Sprintf(fmtString, args...),
+		code: code,
 	}
 }
 
@@ -196,6 +252,7 @@ func (r *Root) PopSingletonTarget() {
 func (r *Root) ConvertClassToModule() {
 	name := r.currentClass.name
 	lineNo := r.currentClass.lineNo
+	r.rootCpath = r.currentClass.topLevel
 	r.MethodSetStack.Pop()
 	r.currentClass = nil
 	r.State.Pop()
@@ -205,17 +262,21 @@ func (r *Root) ConvertClassToModule() {
 
 func (r *Root) PushModule(name string, lineNo int) {
 	r.State.Push(InModuleBody)
+	topLevel := r.rootCpath
 	// Check for existing module (reopening)
-	if existing := r.findModule(name); existing != nil {
+	existing := r.findModule(name)
+	r.rootCpath = false
+	if existing != nil {
 		if r.loadingGem {
 			existing.fromGem = true
 		}
+		existing.topLevel = existing.topLevel || topLevel
 		r.MethodSetStack.Push(existing.MethodSet)
 		r.moduleStack.Push(existing)
 		r.ScopeChain = r.ScopeChain.Extend(existing)
 		return
 	}
-	mod := &Module{name: name, Pos: Pos{lineNo: lineNo}, fromGem: r.loadingGem}
+	mod := &Module{name: name, Pos: Pos{lineNo: lineNo}, fromGem: r.loadingGem, topLevel: topLevel}
 	ms := NewMethodSet()
 	mod.MethodSet = ms
 	ms.Module = mod
@@ -245,11 +306,21 @@ func (r *Root) PopModule() *Module {
 
 	GetType(module, r.ScopeChain, nil)
 	r.ScopeChain = r.ScopeChain[:len(r.ScopeChain)-1]
-	r.ScopeChain.Set(module.Name(), module)
-	module.Parent = r.moduleStack.Peek()
+	r.registerModule(module)
+
+	return module
+}
 
-	// Register in parent's Modules list if not already there (reopened modules are already registered)
-	if parent := r.moduleStack.Peek(); parent != nil {
+// registerModule records a popped module in its parent's Modules list, or
+// among the top-level modules, unless it's already there (reopened modules
+// are already registered).
+func (r *Root) registerModule(module *Module) {
+	r.definitionScope(module.topLevel).Set(module.Name(), module)
+	module.Parent = r.moduleStack.Peek()
+	if module.topLevel {
+		module.Parent = nil
+	}
+	if parent := module.Parent; parent != nil {
 		found := false
 		for _, m := range parent.Modules {
 			if m == module {
@@ -272,8 +343,6 @@ func (r *Root) PopModule() *Module {
 			r.TopLevelModules = append(r.TopLevelModules, module)
 		}
 	}
-
-	return module
 }
 
 // PopIntermediateModule pops a module that was pushed as part of a :: chain
@@ -289,44 +358,22 @@ func (r *Root) PopIntermediateModule() {
 
 	r.ScopeChain = r.ScopeChain[:len(r.ScopeChain)-1]
 	// Register module name in parent scope for ScopeAccessNode resolution
-	r.ScopeChain.Set(module.Name(), module)
-	module.Parent = r.moduleStack.Peek()
-
-	// Register in parent's Modules list if not already there (reopened modules are already registered)
-	if parent := r.moduleStack.Peek(); parent != nil {
-		found := false
-		for _, m := range parent.Modules {
-			if m == module {
-				found = true
-				break
-			}
-		}
-		if !found {
-			parent.Modules = append(parent.Modules, module)
-		}
-	} else {
-		found := false
-		for _, m := range r.TopLevelModules {
-			if m == module {
-				found = true
-				break
-			}
-		}
-		if !found {
-			r.TopLevelModules = append(r.TopLevelModules, module)
-		}
-	}
+	r.registerModule(module)
 }
 
 func (r *Root) PushClass(name string, lineNo int) {
 	r.State.Push(InClassBody)
+	topLevel := r.rootCpath
 	// Check if the class already exists (open class / monkey patching)
-	if existing := r.findClass(name); existing != nil {
+	existing := r.findClass(name)
+	r.rootCpath = false
+	if existing != nil {
+		existing.topLevel = existing.topLevel || topLevel
 		r.currentClass = existing
 		r.MethodSetStack.Push(existing.MethodSet)
 		return
 	}
-	cls := &Class{name: name, Pos: Pos{lineNo: lineNo}, ivars: make(map[string]*IVar), cvars: make(map[string]*CVar)}
+	cls := &Class{name: name, Pos: Pos{lineNo: lineNo}, ivars: make(map[string]*IVar), cvars: make(map[string]*CVar), topLevel: topLevel}
 	ms := NewMethodSet()
 	cls.MethodSet = ms
 	ms.Class = cls
@@ -334,58 +381,78 @@ func (r *Root) PushClass(name string, lineNo int) {
 	r.MethodSetStack.Push(ms)
 }
 
+// lexicalModule returns the module that a class or module definition is
+// nested in: the innermost enclosing module, or nil at the top level or when
+// the cpath is anchored at the root namespace (`class ::Foo`).
+func (r *Root) lexicalModule() *Module {
+	if r.rootCpath {
+		return nil
+	}
+	return r.moduleStack.Peek()
+}
+
 func (r *Root) findClass(name string) *Class {
-	if parent := r.moduleStack.Peek(); parent != nil {
-		for _, cls := range parent.Classes {
-			if cls.Name() == name {
-				return cls
-			}
-		}
-	} else {
-		for _, cls := range r.Classes {
-			if cls.Name() == name {
-				return cls
-			}
+	return r.classIn(r.lexicalModule(), name)
+}
+
+func (r *Root) classIn(parent *Module, name string) *Class {
+	classes := r.Classes
+	if parent != nil {
+		classes = parent.Classes
+	}
+	for _, cls := range classes {
+		if cls.Name() == name {
+			return cls
 		}
 	}
 	return nil
 }
 
 func (r *Root) findModule(name string) *Module {
-	if parent := r.moduleStack.Peek(); parent != nil {
-		for _, mod := range parent.Modules {
-			if mod.Name() == name {
-				return mod
-			}
-		}
-	} else {
-		for _, mod := range r.TopLevelModules {
-			if mod.Name() == name {
-				return mod
-			}
+	modules := r.TopLevelModules
+	if parent := r.lexicalModule(); parent != nil {
+		modules = parent.Modules
+	}
+	for _, mod := range modules {
+		if mod.Name() == name {
+			return mod
 		}
 	}
 	return nil
 }
 
+// definitionScope returns the scope chain a class or module being popped
+// should be registered in.
+func (r *Root) definitionScope(topLevel bool) ScopeChain {
+	if topLevel {
+		return r.ScopeChain[:1]
+	}
+	return r.ScopeChain
+}
+
 func (r *Root) PopClass() *Class {
 	class := r.currentClass
 	r.MethodSetStack.Pop()
 	r.currentClass = nil
+	parent := r.moduleStack.Peek()
+	if class.topLevel {
+		parent = nil
+	}
 	// Only add to class list if not already present (open class reopening)
-	if r.findClass(class.Name()) == nil {
-		if parent := r.moduleStack.Peek(); parent != nil {
+	if r.classIn(parent, class.Name()) == nil {
+		if parent != nil {
 			parent.Classes = append(parent.Classes, class)
 		} else {
 			r.Classes = append(r.Classes, class)
 		}
 	}
 	r.inPrivateMethods = false
-	class.Module = r.moduleStack.Peek()
-	t := class.BuildType(r.ScopeChain)
+	class.Module = parent
+	scope := r.definitionScope(class.topLevel)
+	t := class.BuildType(scope)
 	classMethodSets[t.Instance.(types.Type)] = class.MethodSet
 	r.State.Pop()
-	r.ScopeChain.Set(class.Name(), class)
+	scope.Set(class.Name(), class)
 	return class
 }
 
@@ -426,7 +493,7 @@ func (r *Root) AddCall(c *MethodCall) {
 			if loc != BadLocal {
 				loc.(*RubyLocal).AddCall(c)
 			} else {
-				uncalled := &RubyLocal{}
+				uncalled := &RubyLocal{placeholder: true}
 				uncalled.AddCall(c)
 				r.ScopeChain.Set(rcvr.Val, uncalled)
 				return
@@ -674,9 +741,11 @@ func (r *Root) analyzeClassMethodBodies() {
 					m.AnalyzeArguments(cls, c, nil)
 				}()
 			}
+			m.applySignature(cls)
 			if m.Body.ReturnType == nil {
 				Tracer.Record("infer-return", fmt.Sprintf("%s.%s (attempting)", cls.name, m.Name))
 				m.Body.InferReturnType(m.Scope, cls)
+				m.checkSignatureReturn(cls)
 			}
 			retStr := "nil"
 			if m.Body.ReturnType != nil {
@@ -710,6 +779,16 @@ func (r *Root) analyzeModuleConstants(mod *Module, parentScope ScopeChain) {
 	}
 }
 
+// gemModuleWarning demotes an error analyzing a gem-loaded module to a
+// warning, so that it doesn't block analysis of user code.
+func gemModuleWarning(name string, err error) Diagnostic {
+	d := DiagnosticFromError(err)
+	d.Severity = SeverityWarning
+	d.Code = "gem-module-skipped"
+	d.Message = fmt.Sprintf("module %s: %s (continuing)", name, d.Message)
+	return d
+}
+
 func (r *Root) analyzeModule(mod *Module, parentScope ScopeChain) error {
 	modScope := parentScope.Extend(mod)
 	if len(mod.Statements) > 0 {
@@ -728,7 +807,7 @@ func (r *Root) analyzeModule(mod *Module, parentScope ScopeChain) error {
 		if len(stmts) > 0 {
 			if _, err := GetType(stmts, modScope, nil); err != nil {
 				if mod.fromGem {
-					Warn(1, "warning: module %s: %v (continuing)\n", mod.Name(), err)
+					Report(gemModuleWarning(mod.Name(), err))
 				} else {
 					return err
 				}
@@ -864,6 +943,7 @@ func (r *Root) preAnalyzeModuleInitializers(mod *Module) {
 
 func (r *Root) AnalyzeMethodSet(ms *MethodSet, rcvr types.Type) error {
 	var err error
+	failures := map[*Method]error{}
 	unanalyzedCount := len(ms.Methods)
 	for unanalyzedCount > 0 {
 		successes := 0
@@ -877,8 +957,10 @@ func (r *Root) AnalyzeMethodSet(ms *MethodSet, rcvr types.Type) error {
 			if err == nil {
 				initialize.analyzed = true
 				successes++
+				delete(failures, initialize)
 			} else {
 				Tracer.Record("error", err.Error())
+				failures[initialize] = err
 			}
 		}
 		for _, name := range ms.Order {
@@ -898,8 +980,10 @@ func (r *Root) AnalyzeMethodSet(ms *MethodSet, rcvr types.Type) error {
 						retType = m.ReturnType().String()
 					}
 					Tracer.Record("method-typed", fmt.Sprintf("%s => %s", name, retType))
+					delete(failures, m)
 				} else {
 					Tracer.Record("error", err.Error())
+					failures[m] = err
 				}
 			}
 		}
@@ -908,6 +992,18 @@ func (r *Root) AnalyzeMethodSet(ms *MethodSet, rcvr types.Type) error {
 		}
 		unanalyzedCount -= successes
 	}
+	if Tolerant {
+		// Every method that never analyzed gets its own error, not just
+		// the last to be tried.
+		for _, name := range ms.Order {
+			if failure, ok := failures[ms.Methods[name]]; ok {
+				r.tolerate(failure)
+			}
+		}
+		if failure, ok := failures[ms.Methods["initialize"]]; ok {
+			r.tolerate(failure)
+		}
+	}
 	return err
 }
 
@@ -1298,7 +1394,7 @@ func (r *Root) Analyze() error {
 	// Apply DataDefine flag to classes whose Data.define/Struct.new assignments
 	// were recorded during loadFile but lost before expandStructDefinitions ran.
 	r.applyDataDefineFlags()
-	if len(r.Errors) > 0 {
+	if len(r.Errors) > 0 && !Tolerant {
 		for _, err := range r.Errors {
 			if parseError, ok := err.(*ParseError); ok && parseError.terminal {
 				return parseError
@@ -1314,6 +1410,9 @@ func (r *Root) Analyze() error {
 	//      analyze all incompletely analyzed methods
 	//   if method set is fully analyzed, flag as complete
 
+	// Type methods declared in RBS files that nothing in the program calls.
+	r.seedSignatures()
+
 	// Pre-pass: register module-level constants before the first pass so
 	// that method bodies inside modules can resolve them.
 	Tracer.SetPhase("module-constants")
@@ -1328,7 +1427,7 @@ func (r *Root) Analyze() error {
 		err := (&Body{Statements: r.Statements}).InferReturnType(r.ScopeChain, nil)
 		if err != nil {
 			Tracer.Record("error", err.Error())
-			if parseError, ok := err.(*ParseError); ok && parseError.terminal {
+			if parseError, ok := err.(*ParseError); ok && parseError.terminal && r.halt(err) {
 				return err
 			}
 		}
@@ -1349,8 +1448,8 @@ func (r *Root) Analyze() error {
 		Tracer.Record("analyze-module", mod.name)
 		if err := r.analyzeModule(mod, r.ScopeChain); err != nil {
 			if mod.fromGem {
-				Warn(1, "warning: module %s: %v (continuing)\n", mod.name, err)
-			} else {
+				Report(gemModuleWarning(mod.name, err))
+			} else if r.halt(err) {
 				return err
 			}
 		}
@@ -1368,12 +1467,12 @@ func (r *Root) Analyze() error {
 	for i := len(r.Classes) - 1; i >= 0; i-- {
 		class := r.Classes[i]
 		Tracer.Record("analyze-class", class.name)
-		if err := r.AnalyzeMethodSet(class.MethodSet, class.Type()); err != nil {
+		if err := r.AnalyzeMethodSet(class.MethodSet, class.Type()); err != nil && r.halt(err) {
 			return err
 		}
 	}
 	Tracer.SetPhase("global-method-set")
-	if err := r.AnalyzeMethodSet(r.MethodSetStack.Peek(), nil); err != nil {
+	if err := r.AnalyzeMethodSet(r.MethodSetStack.Peek(), nil); err != nil && r.halt(err) {
 		return err
 	}
 
@@ -1391,7 +1490,7 @@ func (r *Root) Analyze() error {
 	r.clearUnresolvedTypes(r.Statements)
 
 	if len(r.Statements) > 0 {
-		if err := (&Body{Statements: r.Statements}).InferReturnType(r.ScopeChain, nil); err != nil {
+		if err := (&Body{Statements: r.Statements}).InferReturnType(r.ScopeChain, nil); err != nil && r.halt(err) {
 			// probably this is too aggressive
 			return err
 		}
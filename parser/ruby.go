@@ -62,134 +62,142 @@ type yySymType struct {
 }
 
 const LOWEST = 57346
-const ASSIGN = 57347
-const MODASSIGN = 57348
-const MULASSIGN = 57349
-const ADDASSIGN = 57350
-const SUBASSIGN = 57351
-const DIVASSIGN = 57352
-const LSHIFTASSIGN = 57353
-const RSHIFTASSIGN = 57354
-const ORASSIGN = 57355
-const QMARK = 57356
-const COLON = 57357
-const DOT2 = 57358
-const DOT3 = 57359
-const LOGICALOR = 57360
-const LOGICALAND = 57361
-const SPACESHIP = 57362
-const EQ = 57363
-const NEQ = 57364
-const MATCH = 57365
-const NOTMATCH = 57366
-const GT = 57367
-const GTE = 57368
-const LT = 57369
-const LTE = 57370
-const AND = 57371
-const PIPE = 57372
-const CARET = 57373
-const LSHIFT = 57374
-const RSHIFT = 57375
-const PLUS = 57376
-const MINUS = 57377
-const ASTERISK = 57378
-const SLASH = 57379
-const MODULO = 57380
-const UNARY_NUM = 57381
-const POW = 57382
-const BANG = 57383
-const NIL = 57384
-const SYMBOL = 57385
-const STRING = 57386
-const INT = 57387
-const FLOAT = 57388
-const RATIONAL = 57389
-const IMAGINARY = 57390
-const TRUE = 57391
-const FALSE = 57392
-const CLASS = 57393
-const MODULE = 57394
-const DEF = 57395
-const END = 57396
-const IF = 57397
-const IF_MOD = 57398
-const UNLESS = 57399
-const UNLESS_MOD = 57400
-const BEGIN = 57401
-const RESCUE = 57402
-const RESCUE_MOD = 57403
-const THEN = 57404
-const ELSE = 57405
-const WHILE = 57406
-const WHILE_MOD = 57407
-const RETURN = 57408
-const YIELD = 57409
-const SELF = 57410
-const CONSTANT = 57411
-const ENSURE = 57412
-const ELSIF = 57413
-const CASE = 57414
-const WHEN = 57415
-const UNTIL = 57416
-const UNTIL_MOD = 57417
+const IF_MOD = 57347
+const UNLESS_MOD = 57348
+const WHILE_MOD = 57349
+const UNTIL_MOD = 57350
+const ASSIGN = 57351
+const MODASSIGN = 57352
+const MULASSIGN = 57353
+const ADDASSIGN = 57354
+const SUBASSIGN = 57355
+const DIVASSIGN = 57356
+const LSHIFTASSIGN = 57357
+const RSHIFTASSIGN = 57358
+const ORASSIGN = 57359
+const RESCUE_MOD = 57360
+const QMARK = 57361
+const COLON = 57362
+const DOT2 = 57363
+const DOT3 = 57364
+const LOGICALOR = 57365
+const LOGICALAND = 57366
+const SPACESHIP = 57367
+const EQ = 57368
+const NEQ = 57369
+const MATCH = 57370
+const NOTMATCH = 57371
+const GT = 57372
+const GTE = 57373
+const LT = 57374
+const LTE = 57375
+const AND = 57376
+const PIPE = 57377
+const CARET = 57378
+const LSHIFT = 57379
+const RSHIFT = 57380
+const PLUS = 57381
+const MINUS = 57382
+const ASTERISK = 57383
+const SLASH = 57384
+const MODULO = 57385
+const UNARY_NUM = 57386
+const POW = 57387
+const BANG = 57388
+const NIL = 57389
+const SYMBOL = 57390
+const STRING = 57391
+const INT = 57392
+const FLOAT = 57393
+const RATIONAL = 57394
+const IMAGINARY = 57395
+const TRUE = 57396
+const FALSE = 57397
+const CLASS = 57398
+const MODULE = 57399
+const DEF = 57400
+const END = 57401
+const IF = 57402
+const UNLESS = 57403
+const BEGIN = 57404
+const RESCUE = 57405
+const THEN = 57406
+const ELSE = 57407
+const WHILE = 57408
+const RETURN = 57409
+const YIELD = 57410
+const SELF = 57411
+const CONSTANT = 57412
+const ENSURE = 57413
+const ELSIF = 57414
+const CASE = 57415
+const WHEN = 57416
+const UNTIL = 57417
 const FOR = 57418
 const BREAK = 57419
 const NEXT = 57420
-const SUPER = 57421
-const ALIAS = 57422
-const DO = 57423
-const DO_COND = 57424
-const DO_BLOCK = 57425
-const PRIVATE = 57426
-const PROTECTED = 57427
-const IN = 57428
-const IVAR = 57429
-const CVAR = 57430
-const GVAR = 57431
-const METHODIDENT = 57432
-const IDENT = 57433
-const COMMENT = 57434
-const LABEL = 57435
-const ANDDOT = 57436
-const DOT = 57437
-const LBRACE = 57438
-const LBRACEBLOCK = 57439
-const RBRACE = 57440
-const NEWLINE = 57441
-const COMMA = 57442
-const DOUBLESPLAT = 57443
-const STRINGBEG = 57444
-const STRINGEND = 57445
-const INTERPBEG = 57446
-const INTERPEND = 57447
-const STRINGBODY = 57448
-const REGEXBEG = 57449
-const REGEXEND = 57450
-const REGEXPOPT = 57451
-const RAWSTRINGBEG = 57452
-const RAWSTRINGEND = 57453
-const WORDSBEG = 57454
-const RAWWORDSBEG = 57455
-const XSTRINGBEG = 57456
-const RAWXSTRINGBEG = 57457
-const SEMICOLON = 57458
-const LBRACKET = 57459
-const LBRACKETSTART = 57460
-const RBRACKET = 57461
-const LPAREN = 57462
-const LPARENSTART = 57463
-const RPAREN = 57464
-const HASHROCKET = 57465
-const SCOPE = 57466
-const LAMBDA = 57467
-const LOOP = 57468
+const REDO = 57421
+const RETRY = 57422
+const SUPER = 57423
+const ALIAS = 57424
+const DO = 57425
+const DO_COND = 57426
+const DO_BLOCK = 57427
+const PRIVATE = 57428
+const PROTECTED = 57429
+const IN = 57430
+const IVAR = 57431
+const CVAR = 57432
+const GVAR = 57433
+const METHODIDENT = 57434
+const IDENT = 57435
+const COMMENT = 57436
+const LABEL = 57437
+const ANDDOT = 57438
+const DOT = 57439
+const LBRACE = 57440
+const LBRACEBLOCK = 57441
+const RBRACE = 57442
+const NEWLINE = 57443
+const COMMA = 57444
+const DOUBLESPLAT = 57445
+const STRINGBEG = 57446
+const STRINGEND = 57447
+const INTERPBEG = 57448
+const INTERPEND = 57449
+const STRINGBODY = 57450
+const REGEXBEG = 57451
+const REGEXEND = 57452
+const REGEXPOPT = 57453
+const RAWSTRINGBEG = 57454
+const RAWSTRINGEND = 57455
+const WORDSBEG = 57456
+const RAWWORDSBEG = 57457
+const XSTRINGBEG = 57458
+const RAWXSTRINGBEG = 57459
+const DSYMBEG = 57460
+const SEMICOLON = 57461
+const LBRACKET = 57462
+const LBRACKETSTART = 57463
+const RBRACKET = 57464
+const LPAREN = 57465
+const LPARENSTART = 57466
+const RPAREN = 57467
+const HASHROCKET = 57468
+const SCOPE = 57469
+const SCOPESTART = 57470
+const LAMBDA = 57471
+const LOOP = 57472
 
 var yyToknames = [...]string{
 	"$end",
 	"error",
 	"$unk",
 	"LOWEST",
+	"IF_MOD",
+	"UNLESS_MOD",
+	"WHILE_MOD",
+	"UNTIL_MOD",
 	"ASSIGN",
 	"MODASSIGN",
 	"MULASSIGN",
@@ -199,6 +207,7 @@ var yyToknames = [...]string{
 	"LSHIFTASSIGN",
 	"RSHIFTASSIGN",
 	"ORASSIGN",
+	"RESCUE_MOD",
 	"QMARK",
 	"COLON",
 	"DOT2",
@@ -241,16 +250,12 @@ var yyToknames = [...]string{
 	"DEF",
 	"END",
 	"IF",
-	"IF_MOD",
 	"UNLESS",
-	"UNLESS_MOD",
 	"BEGIN",
 	"RESCUE",
-	"RESCUE_MOD",
 	"THEN",
 	"ELSE",
 	"WHILE",
-	"WHILE_MOD",
 	"RETURN",
 	"YIELD",
 	"SELF",
@@ -260,10 +265,11 @@ var yyToknames = [...]string{
 	"CASE",
 	"WHEN",
 	"UNTIL",
-	"UNTIL_MOD",
 	"FOR",
 	"BREAK",
 	"NEXT",
+	"REDO",
+	"RETRY",
 	"SUPER",
 	"ALIAS",
 	"DO",
@@ -301,6 +307,7 @@ var yyToknames = [...]string{
 	"RAWWORDSBEG",
 	"XSTRINGBEG",
 	"RAWXSTRINGBEG",
+	"DSYMBEG",
 	"SEMICOLON",
 	"LBRACKET",
 	"LBRACKETSTART",
@@ -310,6 +317,7 @@ var yyToknames = [...]string{
 	"RPAREN",
 	"HASHROCKET",
 	"SCOPE",
+	"SCOPESTART",
 	"LAMBDA",
 	"LOOP",
 }
@@ -325,1235 +333,1286 @@ var yyExca = [...]int16{
 	1, -1,
 	-2, 0,
 	-1, 15,
-	5, 62,
-	6, 306,
-	7, 306,
-	8, 306,
-	9, 306,
-	10, 306,
-	11, 306,
-	12, 306,
-	13, 306,
-	100, 58,
-	-2, 304,
+	9, 64,
+	10, 323,
+	11, 323,
+	12, 323,
+	13, 323,
+	14, 323,
+	15, 323,
+	16, 323,
+	17, 323,
+	102, 60,
+	-2, 321,
 	-1, 16,
-	5, 63,
-	6, 307,
-	7, 307,
-	8, 307,
-	9, 307,
-	10, 307,
-	11, 307,
-	12, 307,
-	13, 307,
-	100, 59,
-	-2, 305,
+	9, 65,
+	10, 324,
+	11, 324,
+	12, 324,
+	13, 324,
+	14, 324,
+	15, 324,
+	16, 324,
+	17, 324,
+	102, 61,
+	-2, 322,
 	-1, 22,
-	94, 204,
-	95, 204,
-	117, 204,
-	124, 204,
-	-2, 129,
+	96, 212,
+	97, 212,
+	120, 212,
+	127, 212,
+	-2, 132,
 	-1, 24,
-	39, 356,
-	41, 356,
-	42, 356,
-	43, 356,
-	45, 356,
-	46, 356,
-	47, 356,
-	48, 356,
-	49, 356,
-	50, 356,
-	51, 356,
-	52, 356,
-	53, 356,
-	55, 356,
-	57, 356,
-	59, 356,
-	64, 356,
-	67, 356,
-	68, 356,
-	69, 356,
-	72, 356,
-	74, 356,
-	76, 356,
-	77, 356,
-	78, 356,
-	79, 356,
-	87, 356,
-	88, 356,
-	89, 356,
-	90, 356,
-	91, 356,
-	93, 356,
-	96, 356,
-	101, 356,
-	102, 356,
-	107, 356,
-	110, 356,
-	112, 356,
-	113, 356,
-	114, 356,
-	115, 356,
-	118, 356,
-	120, 356,
-	121, 356,
-	125, 356,
-	126, 356,
-	-2, 295,
+	44, 375,
+	46, 375,
+	47, 375,
+	48, 375,
+	50, 375,
+	51, 375,
+	52, 375,
+	53, 375,
+	54, 375,
+	55, 375,
+	56, 375,
+	57, 375,
+	58, 375,
+	60, 375,
+	61, 375,
+	62, 375,
+	66, 375,
+	68, 375,
+	69, 375,
+	70, 375,
+	73, 375,
+	75, 375,
+	76, 375,
+	77, 375,
+	78, 375,
+	79, 375,
+	80, 375,
+	81, 375,
+	89, 375,
+	90, 375,
+	91, 375,
+	92, 375,
+	93, 375,
+	95, 375,
+	98, 375,
+	103, 375,
+	104, 375,
+	109, 375,
+	112, 375,
+	114, 375,
+	115, 375,
+	116, 375,
+	117, 375,
+	118, 375,
+	121, 375,
+	123, 375,
+	124, 375,
+	128, 375,
+	129, 375,
+	130, 375,
+	-2, 312,
 	-1, 27,
-	39, 357,
-	41, 357,
-	42, 357,
-	43, 357,
-	45, 357,
-	46, 357,
-	47, 357,
-	48, 357,
-	49, 357,
-	50, 357,
-	51, 357,
-	52, 357,
-	53, 357,
-	55, 357,
-	57, 357,
-	59, 357,
-	64, 357,
-	67, 357,
-	68, 357,
-	69, 357,
-	72, 357,
-	74, 357,
-	76, 357,
-	77, 357,
-	78, 357,
-	79, 357,
-	87, 357,
-	88, 357,
-	89, 357,
-	90, 357,
-	91, 357,
-	93, 357,
-	96, 357,
-	101, 357,
-	102, 357,
-	107, 357,
-	110, 357,
-	112, 357,
-	113, 357,
-	114, 357,
-	115, 357,
-	118, 357,
-	120, 357,
-	121, 357,
-	125, 357,
-	126, 357,
-	-2, 298,
+	44, 376,
+	46, 376,
+	47, 376,
+	48, 376,
+	50, 376,
+	51, 376,
+	52, 376,
+	53, 376,
+	54, 376,
+	55, 376,
+	56, 376,
+	57, 376,
+	58, 376,
+	60, 376,
+	61, 376,
+	62, 376,
+	66, 376,
+	68, 376,
+	69, 376,
+	70, 376,
+	73, 376,
+	75, 376,
+	76, 376,
+	77, 376,
+	78, 376,
+	79, 376,
+	80, 376,
+	81, 376,
+	89, 376,
+	90, 376,
+	91, 376,
+	92, 376,
+	93, 376,
+	95, 376,
+	98, 376,
+	103, 376,
+	104, 376,
+	109, 376,
+	112, 376,
+	114, 376,
+	115, 376,
+	116, 376,
+	117, 376,
+	118, 376,
+	121, 376,
+	123, 376,
+	124, 376,
+	128, 376,
+	129, 376,
+	130, 376,
+	-2, 315,
 	-1, 36,
-	5, 286,
-	-2, 325,
+	9, 301,
+	-2, 344,
 	-1, 37,
-	5, 286,
-	-2, 325,
-	-1, 46,
-	36, 159,
-	39, 159,
-	41, 159,
-	42, 159,
-	43, 159,
-	45, 159,
-	46, 159,
-	47, 159,
-	48, 159,
-	49, 159,
-	50, 159,
-	51, 159,
-	52, 159,
-	53, 159,
-	55, 159,
-	57, 159,
-	59, 159,
-	64, 159,
-	67, 159,
-	68, 159,
-	69, 159,
-	72, 159,
-	74, 159,
-	76, 159,
-	77, 159,
-	78, 159,
-	79, 159,
-	87, 159,
-	88, 159,
-	89, 159,
-	90, 159,
-	91, 159,
-	93, 159,
-	96, 159,
-	101, 159,
-	102, 159,
-	107, 159,
-	110, 159,
-	112, 159,
-	113, 159,
-	114, 159,
-	115, 159,
-	118, 159,
-	121, 159,
-	125, 159,
-	126, 159,
-	-2, 176,
-	-1, 48,
-	39, 358,
-	41, 358,
-	42, 358,
-	43, 358,
-	45, 358,
-	46, 358,
-	47, 358,
-	48, 358,
-	49, 358,
-	50, 358,
-	51, 358,
-	52, 358,
-	53, 358,
-	55, 358,
-	57, 358,
-	59, 358,
-	64, 358,
-	67, 358,
-	68, 358,
-	69, 358,
-	72, 358,
-	74, 358,
-	76, 358,
-	77, 358,
-	78, 358,
-	79, 358,
-	87, 358,
-	88, 358,
-	89, 358,
-	90, 358,
-	91, 358,
-	93, 358,
-	96, 358,
-	101, 358,
-	102, 358,
-	107, 358,
-	110, 358,
-	112, 358,
-	113, 358,
-	114, 358,
-	115, 358,
-	118, 358,
-	120, 358,
-	121, 358,
-	125, 358,
-	126, 358,
-	-2, 178,
-	-1, 65,
-	36, 159,
-	39, 159,
-	41, 159,
-	42, 159,
-	43, 159,
-	45, 159,
-	46, 159,
-	47, 159,
-	48, 159,
-	49, 159,
-	50, 159,
-	51, 159,
-	52, 159,
-	53, 159,
-	55, 159,
-	57, 159,
-	59, 159,
-	64, 159,
-	67, 159,
-	68, 159,
-	69, 159,
-	72, 159,
-	74, 159,
-	76, 159,
-	77, 159,
-	78, 159,
-	79, 159,
-	87, 159,
-	88, 159,
-	89, 159,
-	90, 159,
-	91, 159,
-	93, 159,
-	96, 159,
-	101, 159,
-	102, 159,
-	107, 159,
-	110, 159,
-	112, 159,
-	113, 159,
-	114, 159,
-	115, 159,
-	118, 159,
-	121, 159,
-	125, 159,
-	126, 159,
-	-2, 236,
-	-1, 150,
-	5, 48,
-	-2, 50,
-	-1, 158,
-	5, 62,
-	6, 306,
-	7, 306,
-	8, 306,
-	9, 306,
-	10, 306,
-	11, 306,
-	12, 306,
-	13, 306,
-	-2, 304,
-	-1, 159,
-	5, 63,
-	6, 307,
-	7, 307,
-	8, 307,
-	9, 307,
-	10, 307,
-	11, 307,
-	12, 307,
-	13, 307,
-	-2, 305,
-	-1, 189,
-	94, 304,
-	95, 304,
-	117, 304,
-	124, 304,
-	-2, 58,
-	-1, 190,
-	94, 305,
-	95, 305,
-	117, 305,
-	124, 305,
-	-2, 59,
-	-1, 263,
-	86, 62,
-	100, 58,
-	-2, 304,
-	-1, 264,
-	86, 63,
-	100, 59,
-	-2, 305,
-	-1, 299,
-	100, 153,
-	-2, 158,
-	-1, 307,
-	100, 136,
-	-2, 139,
-	-1, 317,
-	5, 65,
-	100, 61,
-	-2, 356,
+	9, 301,
+	-2, 344,
+	-1, 47,
+	1, 181,
+	5, 181,
+	6, 181,
+	7, 181,
+	8, 181,
+	18, 181,
+	19, 181,
+	21, 181,
+	22, 181,
+	23, 181,
+	24, 181,
+	25, 181,
+	26, 181,
+	27, 181,
+	28, 181,
+	29, 181,
+	30, 181,
+	31, 181,
+	32, 181,
+	33, 181,
+	34, 181,
+	35, 181,
+	36, 181,
+	37, 181,
+	38, 181,
+	39, 181,
+	40, 181,
+	42, 181,
+	43, 181,
+	45, 181,
+	59, 181,
+	63, 181,
+	64, 181,
+	65, 181,
+	71, 181,
+	72, 181,
+	74, 181,
+	84, 181,
+	88, 181,
+	94, 181,
+	96, 181,
+	97, 181,
+	100, 181,
+	101, 181,
+	102, 181,
+	119, 181,
+	120, 181,
+	125, 181,
+	127, 181,
+	-2, 163,
+	-1, 49,
+	44, 377,
+	46, 377,
+	47, 377,
+	48, 377,
+	50, 377,
+	51, 377,
+	52, 377,
+	53, 377,
+	54, 377,
+	55, 377,
+	56, 377,
+	57, 377,
+	58, 377,
+	60, 377,
+	61, 377,
+	62, 377,
+	66, 377,
+	68, 377,
+	69, 377,
+	70, 377,
+	73, 377,
+	75, 377,
+	76, 377,
+	77, 377,
+	78, 377,
+	79, 377,
+	80, 377,
+	81, 377,
+	89, 377,
+	90, 377,
+	91, 377,
+	92, 377,
+	93, 377,
+	95, 377,
+	98, 377,
+	103, 377,
+	104, 377,
+	109, 377,
+	112, 377,
+	114, 377,
+	115, 377,
+	116, 377,
+	117, 377,
+	118, 377,
+	121, 377,
+	123, 377,
+	124, 377,
+	128, 377,
+	129, 377,
+	130, 377,
+	-2, 184,
+	-1, 68,
+	41, 163,
+	44, 163,
+	46, 163,
+	47, 163,
+	48, 163,
+	50, 163,
+	51, 163,
+	52, 163,
+	53, 163,
+	54, 163,
+	55, 163,
+	56, 163,
+	57, 163,
+	58, 163,
+	60, 163,
+	61, 163,
+	62, 163,
+	66, 163,
+	68, 163,
+	69, 163,
+	70, 163,
+	73, 163,
+	75, 163,
+	76, 163,
+	77, 163,
+	78, 163,
+	79, 163,
+	80, 163,
+	81, 163,
+	89, 163,
+	90, 163,
+	91, 163,
+	92, 163,
+	93, 163,
+	95, 163,
+	98, 163,
+	103, 163,
+	104, 163,
+	109, 163,
+	112, 163,
+	114, 163,
+	115, 163,
+	116, 163,
+	117, 163,
+	118, 163,
+	121, 163,
+	124, 163,
+	128, 163,
+	129, 163,
+	130, 163,
+	-2, 250,
+	-1, 157,
+	9, 50,
+	-2, 52,
+	-1, 165,
+	9, 64,
+	10, 323,
+	11, 323,
+	12, 323,
+	13, 323,
+	14, 323,
+	15, 323,
+	16, 323,
+	17, 323,
+	-2, 321,
+	-1, 166,
+	9, 65,
+	10, 324,
+	11, 324,
+	12, 324,
+	13, 324,
+	14, 324,
+	15, 324,
+	16, 324,
+	17, 324,
+	-2, 322,
+	-1, 196,
+	96, 321,
+	97, 321,
+	120, 321,
+	127, 321,
+	-2, 60,
+	-1, 197,
+	96, 322,
+	97, 322,
+	120, 322,
+	127, 322,
+	-2, 61,
+	-1, 272,
+	88, 64,
+	102, 60,
+	-2, 321,
+	-1, 273,
+	88, 65,
+	102, 61,
+	-2, 322,
+	-1, 311,
+	102, 157,
+	-2, 162,
 	-1, 319,
-	39, 159,
-	41, 159,
-	42, 159,
-	43, 159,
-	45, 159,
-	46, 159,
-	47, 159,
-	48, 159,
-	49, 159,
-	50, 159,
-	51, 159,
-	52, 159,
-	53, 159,
-	55, 159,
-	57, 159,
-	59, 159,
-	64, 159,
-	67, 159,
-	68, 159,
-	69, 159,
-	72, 159,
-	74, 159,
-	76, 159,
-	77, 159,
-	78, 159,
-	79, 159,
-	87, 159,
-	88, 159,
-	89, 159,
-	90, 159,
-	91, 159,
-	93, 159,
-	96, 159,
-	101, 159,
-	102, 159,
-	107, 159,
-	110, 159,
-	112, 159,
-	113, 159,
-	114, 159,
-	115, 159,
-	118, 159,
-	121, 159,
-	125, 159,
-	126, 159,
-	-2, 73,
-	-1, 321,
-	5, 66,
-	-2, 171,
-	-1, 332,
-	16, 0,
-	17, 0,
-	-2, 102,
+	102, 139,
+	-2, 142,
+	-1, 329,
+	9, 67,
+	102, 63,
+	-2, 375,
+	-1, 331,
+	44, 163,
+	46, 163,
+	47, 163,
+	48, 163,
+	50, 163,
+	51, 163,
+	52, 163,
+	53, 163,
+	54, 163,
+	55, 163,
+	56, 163,
+	57, 163,
+	58, 163,
+	60, 163,
+	61, 163,
+	62, 163,
+	66, 163,
+	68, 163,
+	69, 163,
+	70, 163,
+	73, 163,
+	75, 163,
+	76, 163,
+	77, 163,
+	78, 163,
+	79, 163,
+	80, 163,
+	81, 163,
+	89, 163,
+	90, 163,
+	91, 163,
+	92, 163,
+	93, 163,
+	95, 163,
+	98, 163,
+	103, 163,
+	104, 163,
+	109, 163,
+	112, 163,
+	114, 163,
+	115, 163,
+	116, 163,
+	117, 163,
+	118, 163,
+	121, 163,
+	124, 163,
+	128, 163,
+	129, 163,
+	130, 163,
+	-2, 76,
 	-1, 333,
-	16, 0,
-	17, 0,
-	-2, 103,
-	-1, 343,
-	20, 0,
-	21, 0,
-	22, 0,
-	23, 0,
-	24, 0,
-	-2, 115,
+	9, 68,
+	-2, 175,
 	-1, 344,
-	20, 0,
 	21, 0,
 	22, 0,
-	23, 0,
-	24, 0,
-	-2, 117,
+	-2, 105,
 	-1, 345,
-	20, 0,
 	21, 0,
 	22, 0,
-	23, 0,
-	24, 0,
+	-2, 106,
+	-1, 355,
+	25, 0,
+	26, 0,
+	27, 0,
+	28, 0,
+	29, 0,
 	-2, 118,
-	-1, 346,
-	20, 0,
-	21, 0,
-	22, 0,
-	23, 0,
-	24, 0,
-	-2, 119,
-	-1, 347,
-	20, 0,
-	21, 0,
-	22, 0,
-	23, 0,
-	24, 0,
+	-1, 356,
+	25, 0,
+	26, 0,
+	27, 0,
+	28, 0,
+	29, 0,
 	-2, 120,
-	-1, 438,
-	1, 141,
-	54, 141,
-	56, 141,
-	58, 141,
-	60, 141,
-	62, 141,
-	63, 141,
-	65, 141,
-	70, 141,
-	71, 141,
-	73, 141,
-	75, 141,
-	81, 141,
-	82, 141,
-	86, 141,
-	92, 141,
-	95, 141,
-	97, 141,
-	98, 141,
-	99, 141,
-	116, 141,
-	122, 141,
-	-2, 159,
-	-1, 449,
-	100, 155,
+	-1, 357,
+	25, 0,
+	26, 0,
+	27, 0,
+	28, 0,
+	29, 0,
+	-2, 121,
+	-1, 358,
+	25, 0,
+	26, 0,
+	27, 0,
+	28, 0,
+	29, 0,
+	-2, 122,
+	-1, 359,
+	25, 0,
+	26, 0,
+	27, 0,
+	28, 0,
+	29, 0,
+	-2, 123,
+	-1, 451,
+	1, 145,
+	5, 145,
+	6, 145,
+	7, 145,
+	8, 145,
+	18, 145,
+	59, 145,
+	63, 145,
+	64, 145,
+	65, 145,
+	71, 145,
+	72, 145,
+	74, 145,
+	83, 145,
+	84, 145,
+	88, 145,
+	94, 145,
+	97, 145,
+	99, 145,
+	100, 145,
+	101, 145,
+	119, 145,
+	125, 145,
 	-2, 163,
-	-1, 453,
-	5, 64,
-	100, 60,
-	-2, 237,
-	-1, 464,
-	5, 49,
-	-2, 51,
-	-1, 465,
-	5, 65,
-	-2, 356,
-	-1, 468,
-	5, 66,
-	-2, 171,
-	-1, 471,
-	5, 61,
-	86, 61,
-	99, 61,
-	100, 61,
-	122, 61,
-	-2, 356,
-	-1, 479,
-	5, 287,
-	-2, 312,
-	-1, 531,
-	86, 65,
-	100, 61,
-	-2, 356,
-	-1, 532,
-	86, 66,
-	-2, 171,
-	-1, 552,
-	100, 154,
-	-2, 161,
-	-1, 555,
-	5, 65,
-	-2, 356,
-	-1, 566,
-	5, 64,
-	-2, 237,
-	-1, 569,
-	5, 60,
-	86, 60,
-	99, 60,
-	100, 60,
-	122, 60,
-	-2, 237,
-	-1, 612,
-	86, 64,
-	100, 60,
-	-2, 237,
-	-1, 624,
-	100, 156,
-	-2, 162,
-	-1, 625,
-	5, 64,
-	-2, 237,
+	-1, 463,
+	102, 159,
+	-2, 167,
+	-1, 469,
+	9, 66,
+	102, 62,
+	-2, 251,
+	-1, 480,
+	9, 51,
+	-2, 53,
+	-1, 481,
+	9, 67,
+	-2, 375,
+	-1, 484,
+	9, 68,
+	-2, 175,
+	-1, 487,
+	9, 63,
+	88, 63,
+	101, 63,
+	102, 63,
+	125, 63,
+	-2, 375,
+	-1, 498,
+	9, 302,
+	-2, 331,
+	-1, 548,
+	88, 67,
+	102, 63,
+	-2, 375,
+	-1, 549,
+	88, 68,
+	-2, 175,
+	-1, 570,
+	102, 158,
+	-2, 165,
+	-1, 575,
+	9, 67,
+	-2, 375,
+	-1, 586,
+	9, 66,
+	-2, 251,
+	-1, 589,
+	9, 62,
+	88, 62,
+	101, 62,
+	102, 62,
+	125, 62,
+	-2, 251,
+	-1, 636,
+	88, 66,
+	102, 62,
+	-2, 251,
+	-1, 649,
+	102, 160,
+	-2, 166,
+	-1, 650,
+	9, 66,
+	-2, 251,
 }
 
 const yyPrivate = 57344
 
-const yyLast = 3929
+const yyLast = 4052
 
 var yyAct = [...]int16{
-	230, 12, 588, 410, 323, 648, 387, 93, 207, 209,
-	270, 409, 589, 198, 208, 12, 313, 647, 241, 520,
-	38, 156, 194, 47, 191, 591, 206, 210, 303, 368,
-	374, 37, 213, 212, 205, 304, 204, 47, 217, 242,
-	91, 144, 385, 47, 12, 36, 278, 92, 96, 460,
-	237, 360, 156, 156, 389, 197, 76, 156, 265, 22,
-	93, 148, 12, 419, 322, 233, 47, 298, 246, 196,
-	202, 314, 428, 315, 47, 47, 10, 397, 507, 47,
-	4, 320, 252, 192, 47, 463, 249, 102, 272, 197,
-	477, 133, 232, 12, 13, 149, 245, 258, 240, 156,
-	156, 156, 156, 196, 259, 275, 248, 238, 222, 150,
-	498, 445, 97, 551, 284, 47, 274, 192, 226, 95,
-	216, 47, 47, 47, 47, 147, 146, 97, 250, 250,
-	247, 156, 309, 250, 95, 309, 94, 254, 276, 251,
-	277, 289, 411, 257, 670, 621, 667, 667, 623, 372,
-	281, 94, 12, 47, 47, 358, 444, 47, 441, 615,
-	447, 12, 437, 310, 376, 668, 666, 154, 8, 426,
-	311, 574, 97, 287, 47, 250, 250, 250, 250, 95,
-	636, 571, 8, 47, 294, 290, 291, 292, 293, 191,
-	324, 540, 147, 146, 97, 97, 94, 454, 329, 302,
-	244, 95, 95, 634, 300, 300, 324, 491, 366, 443,
-	444, 8, 441, 326, 325, 451, 243, 219, 94, 94,
-	197, 448, 145, 391, 396, 261, 537, 436, 93, 8,
-	388, 373, 149, 357, 196, 275, 369, 411, 371, 245,
-	365, 149, 370, 375, 367, 355, 150, 354, 192, 12,
-	273, 572, 457, 12, 156, 12, 12, 12, 324, 403,
-	8, 93, 406, 412, 134, 386, 97, 97, 222, 662,
-	12, 47, 393, 288, 95, 47, 47, 47, 47, 47,
-	432, 220, 391, 390, 407, 518, 439, 395, 508, 218,
-	244, 94, 47, 587, 411, 404, 405, 219, 418, 308,
-	504, 584, 308, 401, 214, 259, 243, 424, 289, 586,
-	400, 402, 277, 277, 434, 515, 394, 19, 388, 8,
-	453, 255, 147, 146, 97, 97, 280, 628, 8, 659,
-	250, 95, 95, 633, 6, 147, 146, 256, 152, 455,
-	414, 578, 382, 381, 380, 423, 435, 467, 94, 94,
-	107, 452, 425, 377, 199, 581, 422, 469, 330, 215,
-	461, 220, 12, 598, 311, 331, 421, 219, 77, 218,
-	459, 108, 106, 470, 214, 629, 480, 483, 678, 480,
-	421, 480, 482, 283, 47, 484, 522, 456, 359, 216,
-	661, 464, 653, 522, 481, 526, 473, 476, 485, 155,
-	11, 510, 98, 617, 99, 421, 489, 487, 328, 489,
-	12, 100, 488, 12, 11, 497, 8, 98, 514, 99,
-	8, 101, 8, 8, 8, 496, 100, 535, 12, 486,
-	479, 220, 47, 536, 538, 47, 101, 8, 534, 218,
-	530, 429, 431, 11, 492, 548, 525, 286, 296, 305,
-	47, 309, 305, 533, 532, 159, 16, 285, 309, 309,
-	324, 11, 501, 309, 472, 503, 306, 468, 554, 306,
-	16, 506, 388, 47, 566, 388, 197, 569, 461, 190,
-	47, 47, 519, 542, 527, 47, 558, 560, 545, 544,
-	196, 562, 11, 559, 561, 528, 321, 12, 563, 16,
-	541, 542, 592, 501, 547, 529, 267, 592, 266, 583,
-	590, 573, 575, 264, 576, 497, 672, 16, 577, 47,
-	7, 546, 671, 663, 593, 652, 643, 12, 600, 8,
-	12, 11, 147, 146, 11, 388, 640, 612, 570, 614,
-	610, 599, 613, 605, 595, 147, 146, 597, 16, 47,
-	594, 11, 47, 579, 539, 356, 523, 309, 619, 388,
-	11, 625, 358, 517, 620, 516, 512, 505, 143, 269,
-	474, 458, 433, 295, 462, 145, 168, 8, 262, 47,
-	8, 268, 483, 480, 12, 626, 201, 12, 12, 632,
-	622, 156, 627, 12, 635, 8, 182, 362, 219, 12,
-	559, 561, 485, 563, 64, 568, 47, 16, 378, 47,
-	47, 12, 645, 47, 450, 47, 16, 12, 308, 12,
-	650, 47, 605, 605, 221, 308, 308, 654, 187, 655,
-	308, 219, 200, 47, 384, 364, 12, 327, 214, 47,
-	656, 47, 131, 130, 190, 658, 12, 618, 11, 224,
-	3, 616, 11, 664, 11, 11, 11, 669, 47, 12,
-	239, 388, 657, 673, 8, 12, 627, 641, 47, 11,
-	253, 675, 1, 677, 603, 596, 12, 219, 521, 680,
-	582, 47, 681, 494, 214, 495, 231, 47, 605, 61,
-	158, 15, 524, 215, 8, 220, 420, 8, 47, 87,
-	88, 89, 90, 218, 16, 15, 556, 379, 16, 211,
-	16, 16, 16, 361, 189, 297, 225, 260, 564, 565,
-	411, 567, 203, 216, 308, 16, 23, 165, 166, 167,
-	74, 168, 2, 40, 15, 39, 104, 35, 49, 215,
-	104, 220, 183, 185, 184, 186, 67, 34, 263, 218,
-	97, 8, 15, 41, 8, 8, 33, 95, 540, 42,
-	8, 11, 69, 70, 60, 58, 8, 72, 305, 216,
-	513, 9, 427, 430, 94, 305, 305, 446, 8, 73,
-	305, 442, 440, 15, 8, 306, 8, 71, 105, 0,
-	0, 0, 306, 306, 0, 0, 0, 306, 0, 104,
-	0, 0, 0, 8, 163, 164, 165, 166, 167, 11,
-	168, 0, 11, 8, 0, 0, 0, 16, 0, 630,
-	0, 0, 0, 0, 0, 0, 8, 11, 0, 0,
-	0, 0, 8, 104, 135, 136, 137, 138, 139, 140,
-	141, 142, 15, 8, 0, 0, 0, 0, 0, 0,
-	11, 15, 0, 0, 0, 0, 0, 11, 11, 0,
-	0, 0, 11, 0, 0, 16, 271, 0, 16, 0,
-	0, 0, 0, 0, 305, 319, 0, 0, 556, 189,
-	0, 0, 0, 16, 177, 178, 163, 164, 165, 166,
-	167, 306, 168, 0, 0, 0, 11, 0, 78, 0,
-	550, 606, 79, 0, 87, 88, 89, 90, 607, 608,
-	223, 78, 0, 0, 606, 79, 0, 87, 88, 89,
-	90, 607, 608, 0, 0, 0, 11, 78, 0, 11,
-	606, 79, 0, 87, 88, 89, 90, 607, 608, 15,
-	0, 0, 0, 15, 0, 15, 15, 15, 0, 0,
-	604, 0, 16, 0, 0, 0, 11, 0, 0, 0,
-	15, 0, 0, 604, 0, 0, 0, 279, 0, 0,
-	0, 0, 0, 0, 0, 0, 601, 602, 649, 604,
-	0, 0, 16, 11, 0, 16, 11, 11, 0, 601,
-	602, 646, 11, 0, 0, 0, 0, 0, 11, 0,
-	0, 0, 0, 0, 0, 601, 602, 0, 0, 0,
-	11, 438, 227, 234, 0, 0, 11, 0, 11, 183,
-	185, 184, 186, 171, 169, 170, 177, 178, 163, 164,
-	165, 166, 167, 0, 168, 11, 0, 0, 0, 16,
-	0, 0, 16, 16, 0, 11, 0, 0, 16, 0,
-	0, 0, 15, 0, 16, 0, 0, 0, 11, 0,
-	319, 0, 0, 0, 11, 0, 16, 0, 0, 0,
-	0, 0, 16, 0, 16, 11, 0, 0, 0, 0,
-	0, 21, 0, 0, 0, 0, 0, 0, 104, 0,
-	0, 16, 0, 0, 0, 0, 0, 0, 299, 227,
-	15, 16, 153, 15, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 16, 408, 0, 0, 15, 413,
-	16, 415, 416, 417, 104, 0, 229, 229, 0, 0,
-	0, 16, 0, 0, 0, 549, 171, 169, 170, 177,
-	178, 163, 164, 165, 166, 167, 0, 168, 229, 0,
-	0, 0, 0, 0, 0, 104, 169, 170, 177, 178,
-	163, 164, 165, 166, 167, 0, 168, 0, 0, 0,
-	104, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 319, 0, 0, 0, 15, 383, 0,
-	0, 0, 0, 0, 0, 0, 392, 0, 0, 0,
-	0, 0, 0, 398, 399, 0, 0, 0, 0, 0,
-	0, 0, 229, 307, 0, 0, 312, 15, 0, 0,
-	15, 0, 0, 0, 0, 229, 0, 0, 475, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 153,
-	227, 0, 0, 332, 333, 334, 335, 336, 337, 338,
-	339, 340, 341, 342, 343, 344, 345, 346, 347, 348,
-	349, 350, 351, 352, 353, 0, 0, 0, 0, 449,
-	0, 0, 0, 0, 15, 0, 509, 15, 15, 511,
-	0, 0, 363, 15, 0, 0, 0, 0, 0, 15,
+	241, 12, 612, 677, 382, 38, 335, 595, 218, 594,
+	401, 676, 280, 201, 48, 12, 208, 217, 388, 422,
+	220, 163, 421, 99, 198, 219, 151, 537, 48, 261,
+	252, 244, 325, 613, 48, 223, 6, 37, 216, 473,
+	431, 288, 222, 227, 12, 453, 253, 399, 615, 476,
+	403, 141, 212, 163, 163, 205, 315, 48, 163, 274,
+	155, 204, 326, 12, 215, 36, 48, 48, 4, 154,
+	153, 48, 411, 524, 19, 97, 48, 99, 22, 13,
+	248, 255, 214, 156, 102, 263, 552, 372, 243, 203,
+	232, 310, 98, 648, 157, 159, 204, 440, 423, 12,
+	370, 479, 199, 10, 80, 163, 163, 163, 163, 12,
+	282, 423, 48, 254, 699, 285, 494, 608, 48, 48,
+	48, 48, 48, 699, 203, 249, 276, 517, 103, 251,
+	161, 8, 286, 267, 700, 101, 665, 199, 163, 321,
+	257, 103, 321, 698, 553, 8, 458, 226, 101, 284,
+	268, 48, 48, 100, 494, 48, 259, 259, 294, 12,
+	663, 259, 103, 207, 569, 256, 100, 298, 12, 101,
+	154, 153, 48, 605, 8, 318, 646, 305, 318, 206,
+	493, 48, 336, 300, 277, 226, 327, 100, 386, 270,
+	341, 660, 438, 8, 467, 702, 198, 592, 322, 450,
+	659, 152, 340, 254, 390, 423, 336, 558, 259, 259,
+	259, 259, 308, 317, 593, 103, 317, 598, 332, 338,
+	470, 383, 101, 385, 109, 369, 462, 156, 140, 8,
+	314, 449, 237, 204, 380, 103, 156, 103, 157, 8,
+	100, 389, 101, 402, 299, 103, 336, 510, 684, 285,
+	258, 387, 101, 694, 99, 316, 287, 232, 12, 384,
+	100, 203, 12, 163, 12, 12, 12, 660, 407, 320,
+	100, 48, 320, 381, 199, 48, 48, 48, 48, 48,
+	12, 103, 417, 424, 103, 423, 99, 418, 101, 8,
+	291, 101, 445, 48, 409, 375, 379, 376, 8, 535,
+	367, 436, 366, 457, 260, 454, 100, 460, 266, 100,
+	400, 229, 414, 416, 639, 103, 283, 555, 162, 11,
+	154, 153, 101, 558, 312, 312, 405, 410, 306, 447,
+	525, 78, 402, 11, 405, 404, 469, 111, 207, 419,
+	100, 111, 430, 461, 435, 154, 153, 611, 468, 456,
+	457, 437, 454, 300, 206, 301, 302, 303, 304, 268,
+	229, 490, 11, 610, 452, 408, 259, 224, 290, 342,
+	474, 485, 230, 478, 12, 483, 343, 691, 596, 477,
+	228, 11, 154, 153, 154, 153, 662, 48, 8, 602,
+	501, 396, 8, 503, 8, 8, 8, 323, 264, 499,
+	653, 486, 499, 111, 499, 504, 368, 502, 150, 395,
+	8, 114, 475, 370, 265, 152, 471, 11, 394, 225,
+	500, 230, 12, 508, 391, 12, 508, 11, 622, 228,
+	506, 507, 434, 115, 113, 48, 287, 287, 48, 492,
+	12, 111, 520, 209, 81, 654, 531, 433, 711, 371,
+	226, 539, 551, 48, 489, 281, 433, 11, 566, 229,
+	11, 472, 554, 556, 546, 12, 224, 321, 547, 448,
+	539, 536, 703, 544, 321, 321, 543, 11, 48, 321,
+	48, 542, 520, 331, 693, 433, 11, 48, 48, 293,
+	402, 560, 48, 402, 586, 204, 682, 589, 477, 233,
+	574, 563, 642, 318, 8, 597, 599, 616, 600, 576,
+	318, 318, 560, 426, 614, 318, 12, 640, 505, 550,
+	230, 584, 585, 203, 587, 601, 516, 296, 228, 48,
+	578, 580, 562, 572, 515, 582, 565, 442, 444, 607,
+	549, 317, 488, 624, 12, 629, 484, 12, 317, 317,
+	441, 334, 8, 317, 402, 8, 333, 48, 636, 235,
+	48, 616, 617, 527, 516, 275, 623, 295, 634, 705,
+	8, 704, 638, 695, 297, 681, 11, 321, 672, 644,
+	11, 402, 11, 11, 11, 650, 669, 621, 637, 619,
+	48, 618, 603, 647, 645, 8, 323, 320, 11, 557,
+	540, 534, 533, 658, 320, 320, 529, 661, 12, 320,
+	496, 12, 12, 318, 495, 163, 499, 12, 502, 504,
+	545, 48, 451, 12, 48, 48, 655, 279, 48, 664,
+	48, 629, 629, 651, 446, 12, 48, 307, 679, 175,
+	278, 7, 12, 189, 12, 657, 8, 674, 48, 211,
+	374, 317, 166, 16, 685, 48, 686, 48, 229, 588,
+	683, 92, 93, 94, 95, 12, 689, 16, 688, 464,
+	392, 564, 466, 331, 8, 12, 197, 8, 48, 210,
+	398, 687, 172, 173, 174, 690, 175, 231, 48, 532,
+	576, 12, 11, 696, 402, 378, 16, 12, 706, 103,
+	271, 111, 701, 710, 48, 629, 101, 320, 337, 12,
+	48, 273, 714, 420, 708, 16, 339, 425, 670, 427,
+	428, 429, 48, 712, 100, 190, 192, 191, 193, 579,
+	581, 715, 67, 138, 583, 137, 234, 3, 8, 111,
+	11, 8, 8, 11, 643, 229, 641, 8, 250, 262,
+	1, 16, 224, 8, 165, 15, 194, 229, 11, 627,
+	620, 16, 538, 606, 224, 8, 513, 514, 111, 15,
+	242, 62, 8, 541, 8, 432, 393, 221, 196, 591,
+	373, 309, 236, 11, 111, 11, 104, 105, 106, 107,
+	269, 23, 11, 11, 2, 8, 40, 11, 15, 108,
+	331, 415, 39, 35, 225, 8, 230, 50, 70, 34,
+	41, 16, 33, 272, 228, 42, 225, 15, 230, 72,
+	16, 8, 74, 73, 61, 59, 228, 8, 76, 491,
+	530, 9, 652, 439, 11, 226, 443, 459, 77, 8,
+	579, 581, 455, 583, 84, 75, 213, 226, 197, 112,
+	0, 0, 0, 15, 0, 104, 105, 106, 107, 0,
+	0, 0, 11, 15, 0, 11, 0, 0, 108, 170,
+	171, 172, 173, 174, 0, 175, 0, 526, 82, 0,
+	528, 630, 83, 0, 92, 93, 94, 95, 631, 632,
+	480, 0, 0, 0, 0, 11, 0, 0, 82, 0,
+	0, 630, 83, 0, 92, 93, 94, 95, 631, 632,
+	16, 652, 0, 15, 16, 0, 16, 16, 16, 0,
+	0, 0, 15, 0, 0, 0, 11, 628, 0, 11,
+	11, 498, 16, 0, 0, 11, 0, 0, 0, 0,
+	0, 11, 289, 0, 0, 511, 0, 628, 0, 0,
+	196, 336, 96, 11, 625, 626, 678, 0, 0, 0,
+	11, 0, 11, 0, 0, 0, 522, 0, 0, 0,
+	523, 604, 96, 0, 625, 626, 675, 0, 0, 111,
+	0, 0, 0, 11, 0, 0, 0, 0, 238, 245,
+	0, 0, 0, 11, 0, 0, 0, 0, 0, 633,
+	559, 0, 635, 0, 0, 0, 0, 0, 0, 11,
+	0, 0, 15, 0, 0, 11, 15, 0, 15, 15,
+	15, 0, 0, 0, 0, 0, 16, 11, 0, 0,
+	0, 0, 0, 0, 15, 0, 0, 0, 0, 82,
+	0, 590, 630, 83, 0, 92, 93, 94, 95, 631,
+	632, 178, 176, 177, 184, 185, 170, 171, 172, 173,
+	174, 0, 175, 666, 0, 0, 667, 668, 0, 0,
+	0, 0, 671, 0, 16, 0, 0, 16, 673, 0,
+	311, 238, 0, 0, 0, 0, 0, 0, 628, 0,
+	680, 0, 16, 190, 192, 191, 193, 178, 176, 177,
+	184, 185, 170, 171, 172, 173, 174, 0, 175, 0,
+	568, 0, 0, 96, 0, 625, 626, 16, 0, 0,
+	692, 184, 185, 170, 171, 172, 173, 174, 15, 175,
+	697, 176, 177, 184, 185, 170, 171, 172, 173, 174,
+	0, 175, 0, 0, 0, 0, 707, 0, 0, 0,
+	0, 0, 709, 142, 143, 144, 145, 146, 147, 148,
+	149, 0, 0, 0, 713, 0, 0, 0, 16, 0,
+	0, 0, 0, 397, 0, 0, 15, 0, 0, 15,
+	0, 0, 406, 0, 0, 0, 0, 0, 0, 412,
+	413, 0, 0, 0, 15, 0, 16, 0, 0, 16,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 15, 229, 0, 0, 0, 0, 15, 0, 15,
-	229, 0, 0, 0, 0, 0, 0, 229, 229, 0,
-	229, 229, 0, 0, 0, 0, 15, 229, 0, 0,
-	0, 0, 0, 0, 0, 0, 15, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 490, 0, 0, 15,
-	0, 0, 0, 104, 229, 15, 0, 0, 499, 0,
-	0, 0, 0, 580, 234, 502, 15, 0, 0, 0,
+	0, 0, 567, 0, 0, 0, 0, 0, 0, 15,
+	0, 0, 0, 0, 188, 238, 168, 169, 187, 186,
+	179, 180, 181, 182, 183, 190, 192, 191, 193, 178,
+	176, 177, 184, 185, 170, 171, 172, 173, 174, 0,
+	175, 0, 0, 0, 0, 0, 463, 0, 0, 0,
+	16, 0, 0, 16, 16, 0, 0, 0, 0, 16,
+	15, 0, 0, 0, 0, 16, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 16, 0, 0,
+	0, 0, 0, 0, 16, 0, 16, 0, 15, 21,
+	0, 15, 0, 0, 0, 0, 336, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 16, 0, 0,
+	160, 0, 0, 0, 0, 0, 0, 16, 0, 0,
+	0, 0, 0, 0, 0, 509, 0, 0, 0, 0,
+	0, 0, 0, 16, 0, 240, 240, 518, 0, 16,
+	0, 0, 0, 245, 521, 0, 0, 0, 0, 0,
+	0, 16, 15, 0, 0, 15, 15, 0, 0, 240,
+	0, 15, 0, 0, 0, 0, 238, 15, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 15,
+	0, 0, 561, 245, 0, 0, 15, 0, 15, 0,
+	0, 0, 0, 0, 0, 570, 0, 0, 0, 0,
+	0, 0, 0, 561, 0, 0, 0, 0, 0, 15,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 15,
+	0, 0, 0, 0, 0, 0, 0, 240, 319, 0,
+	0, 324, 0, 0, 0, 15, 0, 0, 0, 0,
+	240, 15, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 609, 15, 160, 0, 0, 0, 344, 345,
+	346, 347, 348, 349, 350, 351, 352, 353, 354, 355,
+	356, 357, 358, 359, 360, 361, 362, 363, 364, 365,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 229, 0, 0, 0, 0, 0, 227,
-	0, 0, 0, 609, 0, 0, 611, 0, 0, 0,
-	0, 0, 0, 0, 543, 234, 0, 0, 0, 312,
-	312, 0, 229, 0, 0, 0, 552, 0, 0, 0,
-	0, 0, 543, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 229, 0,
-	0, 0, 0, 0, 0, 0, 478, 0, 0, 0,
-	637, 0, 0, 638, 639, 0, 0, 0, 0, 642,
-	229, 0, 0, 0, 0, 644, 493, 0, 585, 0,
-	0, 0, 229, 0, 0, 0, 0, 651, 229, 229,
-	179, 172, 173, 174, 175, 176, 183, 185, 184, 186,
-	171, 169, 170, 177, 178, 163, 164, 165, 166, 167,
-	0, 168, 660, 229, 0, 229, 0, 0, 499, 0,
-	0, 0, 665, 0, 0, 0, 0, 0, 229, 229,
-	0, 624, 0, 0, 0, 674, 0, 0, 0, 0,
-	229, 676, 312, 229, 0, 0, 229, 0, 0, 312,
-	312, 0, 679, 0, 312, 0, 0, 0, 0, 0,
-	78, 0, 20, 29, 79, 0, 87, 88, 89, 90,
-	31, 32, 59, 75, 68, 0, 51, 0, 52, 0,
-	43, 0, 0, 0, 0, 53, 0, 66, 46, 30,
-	27, 0, 229, 56, 0, 54, 0, 57, 62, 63,
-	65, 5, 0, 0, 0, 17, 18, 0, 25, 28,
-	26, 48, 24, 97, 0, 0, 0, 45, 0, 0,
-	288, 0, 0, 80, 0, 0, 0, 0, 86, 0,
-	0, 83, 229, 81, 84, 82, 85, 0, 0, 44,
-	0, 0, 14, 0, 0, 229, 50, 55, 312, 0,
-	0, 0, 0, 0, 0, 0, 312, 312, 0, 312,
-	631, 78, 0, 20, 29, 79, 0, 87, 88, 89,
-	90, 31, 32, 59, 75, 68, 0, 51, 0, 52,
-	0, 43, 0, 0, 0, 0, 53, 0, 66, 46,
-	30, 27, 0, 0, 56, 0, 54, 0, 57, 62,
-	63, 65, 5, 0, 0, 0, 17, 18, 0, 25,
-	28, 26, 48, 24, 0, 229, 0, 0, 45, 0,
-	0, 0, 312, 0, 80, 0, 0, 0, 0, 86,
-	0, 0, 83, 0, 81, 84, 82, 85, 0, 0,
-	44, 0, 0, 14, 0, 0, 426, 50, 55, 78,
-	0, 20, 29, 79, 0, 87, 88, 89, 90, 31,
-	32, 59, 75, 68, 0, 51, 0, 52, 0, 43,
-	0, 0, 0, 0, 53, 0, 66, 46, 30, 27,
-	0, 0, 56, 0, 54, 0, 57, 62, 63, 65,
-	5, 0, 0, 0, 17, 18, 0, 25, 28, 26,
-	48, 24, 0, 0, 0, 0, 45, 0, 0, 0,
-	0, 0, 80, 0, 0, 0, 0, 86, 0, 0,
-	83, 0, 81, 84, 82, 85, 0, 316, 44, 0,
-	0, 14, 0, 0, 228, 50, 55, 78, 0, 157,
-	29, 79, 0, 87, 88, 89, 90, 31, 32, 59,
-	75, 68, 0, 51, 0, 52, 0, 43, 0, 0,
-	0, 0, 53, 0, 0, 193, 30, 27, 0, 0,
-	56, 0, 54, 0, 57, 62, 63, 195, 0, 0,
-	0, 0, 0, 0, 0, 25, 28, 26, 48, 24,
-	0, 235, 0, 0, 45, 0, 0, 0, 0, 236,
-	80, 0, 0, 0, 0, 86, 0, 0, 83, 0,
-	81, 84, 82, 85, 0, 557, 44, 0, 0, 160,
-	0, 0, 500, 50, 55, 78, 0, 157, 29, 79,
-	0, 87, 88, 89, 90, 31, 32, 59, 75, 68,
-	0, 51, 0, 52, 0, 43, 0, 0, 0, 0,
-	53, 0, 0, 193, 30, 27, 0, 0, 56, 0,
-	54, 0, 57, 62, 63, 195, 0, 0, 0, 0,
-	0, 0, 0, 25, 28, 26, 48, 24, 0, 235,
-	0, 0, 45, 0, 0, 0, 0, 236, 80, 0,
-	0, 0, 0, 86, 0, 0, 83, 0, 81, 84,
-	82, 85, 0, 0, 44, 0, 0, 160, 0, 0,
-	228, 50, 55, 78, 0, 157, 29, 79, 0, 87,
-	88, 89, 90, 31, 32, 59, 75, 68, 0, 51,
-	0, 52, 0, 43, 0, 0, 0, 0, 53, 0,
-	0, 193, 30, 27, 0, 0, 56, 0, 54, 0,
-	57, 62, 63, 195, 0, 0, 0, 0, 0, 0,
-	0, 25, 28, 26, 48, 24, 0, 235, 0, 0,
-	45, 0, 0, 324, 0, 236, 80, 0, 0, 0,
-	0, 86, 0, 0, 83, 0, 81, 84, 82, 85,
-	0, 0, 44, 0, 0, 160, 0, 0, 0, 50,
-	55, 78, 0, 20, 29, 79, 0, 87, 88, 89,
-	90, 31, 32, 59, 75, 68, 0, 51, 0, 52,
-	0, 43, 0, 0, 0, 0, 53, 0, 66, 46,
-	30, 27, 0, 0, 56, 0, 54, 0, 57, 62,
-	63, 65, 5, 0, 0, 0, 17, 18, 0, 25,
-	28, 26, 48, 24, 0, 0, 0, 0, 45, 0,
-	0, 0, 0, 0, 80, 0, 0, 0, 0, 86,
-	0, 0, 83, 0, 81, 84, 82, 85, 0, 0,
-	44, 0, 0, 151, 0, 0, 0, 50, 55, 78,
-	0, 20, 29, 79, 0, 87, 88, 89, 90, 31,
-	32, 59, 75, 68, 0, 51, 0, 52, 0, 43,
-	0, 0, 0, 0, 53, 0, 66, 46, 30, 27,
-	0, 0, 56, 0, 54, 0, 57, 62, 63, 65,
-	0, 0, 0, 0, 0, 0, 0, 25, 28, 26,
-	48, 24, 97, 0, 0, 0, 45, 0, 0, 95,
-	0, 0, 80, 0, 0, 0, 0, 86, 0, 0,
-	83, 0, 81, 84, 82, 85, 94, 0, 44, 0,
-	0, 160, 0, 0, 500, 50, 55, 78, 0, 157,
-	29, 79, 0, 87, 88, 89, 90, 31, 32, 59,
-	75, 68, 0, 51, 0, 52, 0, 43, 0, 0,
-	0, 0, 53, 0, 0, 193, 30, 27, 0, 0,
-	56, 0, 54, 0, 57, 62, 63, 195, 0, 0,
-	0, 0, 0, 0, 0, 25, 28, 26, 48, 24,
-	0, 235, 0, 0, 45, 0, 0, 0, 0, 236,
-	80, 0, 0, 0, 0, 86, 0, 0, 83, 0,
-	81, 84, 82, 85, 0, 0, 44, 0, 0, 160,
-	0, 0, 228, 50, 55, 78, 0, 157, 29, 79,
-	0, 87, 88, 89, 90, 31, 32, 59, 75, 68,
-	0, 51, 0, 52, 0, 43, 0, 0, 0, 0,
-	53, 0, 0, 193, 30, 27, 0, 0, 56, 0,
-	54, 0, 57, 62, 63, 195, 0, 0, 0, 0,
-	0, 0, 0, 25, 28, 26, 48, 24, 0, 235,
-	0, 0, 45, 0, 0, 0, 0, 236, 80, 0,
-	0, 0, 0, 86, 0, 0, 83, 0, 81, 84,
-	82, 85, 0, 0, 44, 0, 0, 160, 0, 0,
-	0, 50, 55, 78, 0, 157, 29, 79, 0, 87,
-	88, 89, 90, 31, 32, 59, 75, 68, 0, 51,
-	0, 52, 0, 43, 0, 0, 0, 0, 53, 0,
-	0, 193, 30, 27, 0, 0, 56, 0, 54, 0,
-	57, 62, 63, 195, 0, 0, 0, 0, 0, 0,
-	0, 25, 28, 26, 48, 24, 0, 235, 0, 0,
-	45, 0, 0, 0, 0, 236, 80, 0, 0, 0,
-	0, 86, 0, 0, 83, 0, 81, 84, 82, 85,
-	0, 0, 44, 0, 0, 160, 0, 0, 301, 50,
-	55, 78, 0, 157, 29, 79, 0, 87, 88, 89,
-	90, 31, 32, 59, 75, 68, 0, 51, 0, 52,
-	0, 43, 0, 0, 0, 0, 53, 0, 66, 46,
-	30, 27, 0, 0, 56, 0, 54, 0, 57, 62,
-	63, 65, 0, 0, 0, 0, 0, 0, 0, 25,
-	28, 26, 48, 24, 0, 0, 0, 0, 45, 0,
-	0, 0, 0, 0, 80, 0, 0, 0, 0, 86,
-	0, 0, 83, 0, 81, 84, 82, 85, 0, 0,
-	44, 0, 0, 160, 0, 0, 0, 50, 55, 78,
-	0, 20, 29, 79, 0, 87, 88, 89, 90, 31,
-	32, 59, 75, 68, 0, 51, 0, 52, 0, 43,
-	0, 0, 0, 0, 53, 0, 66, 46, 30, 27,
-	0, 0, 56, 0, 54, 0, 57, 62, 63, 65,
+	0, 518, 0, 0, 0, 0, 0, 0, 0, 0,
+	377, 0, 0, 0, 649, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	240, 0, 0, 0, 0, 0, 0, 0, 0, 240,
+	0, 0, 0, 0, 0, 0, 240, 240, 0, 240,
+	240, 0, 0, 0, 240, 0, 0, 0, 0, 0,
+	0, 82, 0, 20, 29, 83, 0, 92, 93, 94,
+	95, 31, 32, 60, 79, 71, 0, 52, 53, 43,
+	0, 0, 240, 54, 69, 47, 30, 27, 0, 0,
+	57, 0, 55, 58, 63, 64, 65, 66, 68, 5,
+	0, 0, 0, 17, 18, 0, 25, 28, 26, 49,
+	24, 103, 0, 240, 0, 46, 0, 0, 299, 0,
+	0, 85, 0, 0, 0, 0, 91, 0, 0, 88,
+	0, 86, 89, 87, 90, 96, 0, 0, 45, 324,
+	324, 14, 240, 0, 0, 44, 51, 56, 0, 119,
+	120, 127, 121, 122, 123, 124, 125, 126, 118, 116,
+	117, 128, 129, 130, 131, 132, 133, 134, 240, 135,
+	136, 0, 0, 0, 0, 0, 0, 0, 497, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 240, 292, 114, 0, 0, 0, 512, 0,
+	0, 0, 0, 0, 240, 0, 0, 0, 0, 0,
+	240, 240, 0, 0, 0, 0, 115, 113, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 240, 0, 240, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 240,
+	240, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 240, 0, 0, 573, 324, 240, 0, 0,
+	240, 0, 0, 324, 324, 0, 0, 0, 324, 82,
+	0, 20, 29, 83, 0, 92, 93, 94, 95, 31,
+	32, 60, 79, 71, 0, 52, 53, 43, 0, 0,
+	0, 54, 69, 47, 30, 27, 0, 0, 57, 0,
+	55, 58, 63, 64, 65, 66, 68, 5, 0, 240,
+	0, 17, 18, 0, 25, 28, 26, 49, 24, 0,
+	0, 0, 0, 46, 0, 0, 0, 0, 0, 85,
+	0, 0, 0, 0, 91, 0, 0, 88, 0, 86,
+	89, 87, 90, 96, 0, 0, 45, 0, 240, 14,
+	0, 0, 438, 44, 51, 56, 0, 0, 0, 0,
+	0, 240, 0, 0, 0, 0, 324, 0, 0, 0,
+	0, 0, 0, 0, 324, 324, 0, 324, 656, 82,
+	0, 20, 29, 83, 0, 92, 93, 94, 95, 31,
+	32, 60, 79, 71, 0, 52, 53, 43, 0, 0,
+	0, 54, 69, 47, 30, 27, 0, 0, 57, 0,
+	55, 58, 63, 64, 65, 66, 68, 5, 0, 0,
+	0, 17, 18, 0, 25, 28, 26, 49, 24, 0,
+	0, 0, 0, 46, 0, 0, 0, 0, 240, 85,
+	0, 0, 0, 0, 91, 324, 0, 88, 0, 86,
+	89, 87, 90, 96, 0, 0, 45, 0, 0, 14,
+	328, 0, 0, 44, 51, 56, 0, 239, 0, 0,
+	82, 0, 164, 29, 83, 0, 92, 93, 94, 95,
+	31, 32, 60, 79, 71, 0, 52, 53, 43, 0,
+	0, 0, 54, 0, 200, 30, 27, 0, 0, 57,
+	0, 55, 58, 63, 64, 65, 66, 202, 0, 0,
+	0, 0, 0, 0, 0, 25, 28, 26, 49, 24,
+	0, 246, 0, 0, 46, 0, 0, 0, 0, 247,
+	85, 0, 0, 0, 0, 91, 0, 0, 88, 0,
+	86, 89, 87, 90, 96, 0, 0, 45, 0, 0,
+	167, 577, 0, 0, 44, 51, 56, 0, 519, 0,
+	0, 82, 0, 164, 29, 83, 0, 92, 93, 94,
+	95, 31, 32, 60, 79, 71, 0, 52, 53, 43,
+	0, 0, 0, 54, 0, 200, 30, 27, 0, 0,
+	57, 0, 55, 58, 63, 64, 65, 66, 202, 0,
+	0, 0, 0, 0, 0, 0, 25, 28, 26, 49,
+	24, 0, 246, 0, 0, 46, 0, 0, 0, 0,
+	247, 85, 0, 0, 0, 0, 91, 0, 0, 88,
+	0, 86, 89, 87, 90, 96, 0, 0, 45, 0,
+	0, 167, 0, 0, 0, 44, 51, 56, 239, 0,
+	0, 82, 0, 164, 29, 83, 0, 92, 93, 94,
+	95, 31, 32, 60, 79, 71, 0, 52, 53, 43,
+	0, 0, 0, 54, 0, 200, 30, 27, 0, 0,
+	57, 0, 55, 58, 63, 64, 65, 66, 202, 0,
+	0, 0, 0, 0, 0, 0, 25, 28, 26, 49,
+	24, 0, 246, 0, 0, 46, 0, 0, 336, 0,
+	247, 85, 0, 0, 0, 0, 91, 0, 0, 88,
+	0, 86, 89, 87, 90, 96, 0, 0, 45, 0,
+	0, 167, 0, 0, 0, 44, 51, 56, 82, 0,
+	20, 29, 83, 0, 92, 93, 94, 95, 31, 32,
+	60, 79, 71, 0, 52, 53, 43, 0, 0, 0,
+	54, 69, 47, 30, 27, 0, 0, 57, 0, 55,
+	58, 63, 64, 65, 66, 68, 5, 0, 0, 0,
+	17, 18, 0, 25, 28, 26, 49, 24, 0, 0,
+	0, 0, 46, 0, 0, 0, 0, 0, 85, 0,
+	0, 0, 0, 91, 0, 0, 88, 0, 86, 89,
+	87, 90, 96, 0, 0, 45, 0, 0, 158, 0,
+	0, 0, 44, 51, 56, 82, 0, 20, 29, 83,
+	0, 92, 93, 94, 95, 31, 32, 60, 79, 71,
+	0, 52, 53, 43, 0, 0, 0, 54, 69, 47,
+	30, 27, 0, 0, 57, 0, 55, 58, 63, 64,
+	65, 66, 68, 0, 0, 0, 0, 0, 0, 0,
+	25, 28, 26, 49, 24, 103, 0, 0, 0, 46,
+	0, 0, 101, 0, 0, 85, 0, 0, 0, 0,
+	91, 0, 0, 88, 0, 86, 89, 87, 90, 96,
+	100, 0, 45, 0, 0, 167, 0, 0, 0, 44,
+	51, 56, 519, 0, 0, 82, 0, 164, 29, 83,
+	0, 92, 93, 94, 95, 31, 32, 60, 79, 71,
+	0, 52, 53, 43, 0, 0, 0, 54, 0, 200,
+	30, 27, 0, 0, 57, 0, 55, 58, 63, 64,
+	65, 66, 202, 0, 0, 0, 0, 0, 0, 0,
+	25, 28, 26, 49, 24, 0, 246, 0, 0, 46,
+	0, 0, 0, 0, 247, 85, 0, 0, 0, 0,
+	91, 0, 0, 88, 0, 86, 89, 87, 90, 96,
+	0, 0, 45, 0, 0, 167, 0, 0, 0, 44,
+	51, 56, 239, 0, 0, 82, 0, 164, 29, 83,
+	0, 92, 93, 94, 95, 31, 32, 60, 79, 71,
+	0, 52, 53, 43, 0, 0, 0, 54, 0, 200,
+	30, 27, 0, 0, 57, 0, 55, 58, 63, 64,
+	65, 66, 202, 0, 0, 0, 0, 0, 0, 0,
+	25, 28, 26, 49, 24, 0, 246, 0, 0, 46,
+	0, 0, 0, 0, 247, 85, 0, 0, 0, 0,
+	91, 0, 0, 88, 0, 86, 89, 87, 90, 96,
+	0, 0, 45, 0, 0, 167, 0, 0, 0, 44,
+	51, 56, 82, 0, 164, 29, 83, 0, 92, 93,
+	94, 95, 31, 32, 60, 79, 71, 0, 52, 53,
+	43, 0, 0, 0, 54, 0, 200, 30, 27, 0,
+	0, 57, 0, 55, 58, 63, 64, 65, 66, 202,
 	0, 0, 0, 0, 0, 0, 0, 25, 28, 26,
-	48, 24, 0, 0, 0, 0, 45, 0, 0, 0,
-	0, 0, 80, 0, 0, 0, 0, 86, 0, 0,
-	83, 0, 81, 84, 82, 85, 0, 0, 44, 0,
-	0, 160, 0, 0, 0, 50, 55, 78, 0, 157,
-	29, 79, 0, 87, 88, 89, 90, 31, 32, 59,
-	75, 68, 0, 51, 0, 52, 0, 43, 0, 0,
-	0, 0, 53, 0, 66, 46, 30, 27, 0, 0,
-	56, 0, 54, 0, 57, 62, 63, 65, 0, 0,
-	0, 0, 0, 0, 0, 25, 28, 26, 48, 24,
-	0, 0, 0, 0, 45, 0, 0, 0, 0, 0,
-	80, 0, 0, 0, 0, 86, 0, 0, 83, 0,
-	81, 84, 82, 85, 0, 0, 44, 0, 0, 160,
-	0, 0, 500, 50, 55, 78, 0, 157, 29, 79,
-	0, 87, 88, 89, 90, 31, 32, 59, 75, 68,
-	0, 51, 0, 52, 0, 43, 0, 0, 0, 0,
-	53, 0, 0, 193, 30, 27, 0, 0, 56, 0,
-	54, 0, 57, 62, 63, 195, 0, 0, 0, 0,
-	0, 0, 0, 25, 28, 26, 48, 24, 0, 0,
-	0, 0, 45, 0, 0, 0, 0, 0, 80, 0,
-	0, 0, 0, 86, 0, 0, 83, 0, 81, 84,
-	82, 85, 0, 0, 44, 0, 0, 160, 0, 0,
-	553, 50, 55, 78, 0, 157, 29, 79, 0, 87,
-	88, 89, 90, 31, 32, 59, 75, 68, 0, 51,
-	0, 52, 0, 43, 0, 0, 0, 0, 53, 0,
-	0, 193, 30, 27, 0, 0, 56, 0, 54, 0,
-	57, 62, 63, 195, 0, 0, 0, 0, 0, 0,
-	0, 25, 28, 26, 48, 24, 0, 0, 0, 0,
-	45, 0, 0, 0, 0, 0, 80, 0, 0, 0,
-	0, 86, 0, 0, 83, 0, 81, 84, 82, 85,
-	0, 0, 44, 0, 0, 160, 0, 0, 228, 50,
-	55, 78, 0, 157, 29, 79, 0, 87, 88, 89,
-	90, 31, 32, 59, 75, 68, 0, 51, 0, 52,
-	0, 43, 0, 0, 0, 0, 53, 0, 0, 193,
-	30, 27, 0, 0, 56, 0, 54, 0, 57, 62,
-	63, 195, 0, 0, 0, 0, 0, 0, 0, 25,
-	28, 26, 48, 24, 0, 0, 0, 0, 45, 0,
-	0, 0, 0, 0, 80, 0, 0, 0, 0, 86,
-	0, 0, 83, 0, 81, 84, 82, 85, 0, 0,
-	44, 0, 0, 160, 0, 0, 0, 50, 55, 78,
-	0, 157, 29, 79, 0, 87, 88, 89, 90, 31,
-	32, 59, 75, 68, 0, 51, 0, 52, 0, 43,
-	0, 0, 0, 0, 53, 0, 0, 193, 30, 27,
-	0, 0, 56, 0, 54, 0, 57, 62, 63, 195,
+	49, 24, 0, 246, 0, 0, 46, 0, 0, 0,
+	0, 247, 85, 0, 0, 0, 0, 91, 0, 0,
+	88, 0, 86, 89, 87, 90, 96, 0, 0, 45,
+	0, 0, 167, 0, 0, 0, 44, 51, 56, 313,
+	0, 0, 82, 0, 164, 29, 83, 0, 92, 93,
+	94, 95, 31, 32, 60, 79, 71, 0, 52, 53,
+	43, 0, 0, 0, 54, 69, 47, 30, 27, 0,
+	0, 57, 0, 55, 58, 63, 64, 65, 66, 68,
 	0, 0, 0, 0, 0, 0, 0, 25, 28, 26,
-	48, 24, 0, 0, 0, 0, 45, 0, 0, 0,
-	0, 0, 80, 0, 0, 0, 0, 86, 0, 0,
-	83, 0, 81, 84, 82, 85, 0, 0, 44, 0,
-	0, 160, 0, 0, 188, 50, 55, 78, 0, 0,
-	29, 79, 0, 87, 88, 89, 90, 31, 32, 59,
-	75, 68, 0, 51, 0, 52, 0, 43, 0, 0,
-	0, 0, 53, 0, 0, 193, 30, 27, 0, 0,
-	56, 0, 54, 0, 57, 62, 63, 195, 0, 0,
-	0, 0, 0, 0, 0, 25, 28, 26, 48, 24,
-	0, 0, 0, 0, 45, 0, 0, 0, 0, 0,
-	80, 0, 0, 0, 0, 86, 0, 0, 83, 0,
-	81, 84, 82, 85, 0, 0, 44, 0, 0, 160,
-	0, 0, 78, 50, 55, 29, 79, 0, 87, 88,
-	89, 90, 31, 32, 59, 75, 68, 0, 51, 0,
-	52, 0, 43, 0, 0, 0, 0, 53, 0, 0,
-	193, 30, 27, 0, 0, 56, 0, 54, 0, 57,
-	62, 63, 195, 0, 0, 0, 0, 0, 0, 0,
-	25, 28, 26, 48, 24, 0, 0, 0, 0, 45,
-	0, 0, 0, 0, 0, 80, 0, 0, 0, 0,
-	86, 0, 0, 83, 0, 81, 84, 82, 85, 0,
-	0, 44, 0, 0, 160, 0, 0, 78, 50, 55,
-	29, 79, 0, 87, 88, 89, 90, 31, 32, 59,
-	75, 68, 0, 51, 0, 52, 0, 43, 0, 0,
-	0, 0, 53, 0, 0, 193, 30, 27, 0, 0,
-	56, 0, 54, 0, 57, 62, 63, 195, 0, 0,
-	0, 0, 0, 0, 0, 25, 28, 26, 48, 24,
-	0, 0, 0, 0, 45, 0, 0, 0, 0, 0,
-	80, 0, 0, 0, 0, 86, 0, 0, 83, 0,
-	81, 84, 82, 85, 0, 0, 44, 0, 0, 14,
-	0, 0, 0, 50, 55, 181, 0, 161, 162, 180,
-	179, 172, 173, 174, 175, 176, 183, 185, 184, 186,
-	171, 169, 170, 177, 178, 163, 164, 165, 166, 167,
-	0, 168, 112, 113, 120, 114, 115, 116, 117, 118,
-	119, 111, 109, 110, 121, 122, 123, 124, 125, 126,
-	127, 0, 128, 129, 0, 112, 113, 120, 114, 115,
-	116, 117, 118, 119, 111, 109, 110, 121, 122, 123,
-	124, 125, 126, 127, 0, 128, 129, 0, 132, 0,
-	282, 107, 0, 0, 0, 0, 0, 0, 0, 0,
-	324, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 108, 106, 107, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 108, 106, 112, 113, 120,
-	114, 115, 116, 117, 118, 119, 111, 109, 110, 121,
-	122, 123, 124, 125, 126, 127, 0, 128, 129, 0,
-	103, 112, 113, 120, 114, 115, 116, 117, 118, 119,
-	111, 109, 110, 121, 122, 123, 124, 125, 126, 127,
-	0, 128, 129, 0, 0, 0, 107, 0, 0, 0,
+	49, 24, 0, 0, 0, 0, 46, 0, 0, 0,
+	0, 0, 85, 0, 0, 0, 0, 91, 0, 0,
+	88, 0, 86, 89, 87, 90, 96, 0, 0, 45,
+	0, 0, 167, 0, 0, 0, 44, 51, 56, 82,
+	0, 20, 29, 83, 0, 92, 93, 94, 95, 31,
+	32, 60, 79, 71, 0, 52, 53, 43, 0, 0,
+	0, 54, 69, 47, 30, 27, 0, 0, 57, 0,
+	55, 58, 63, 64, 65, 66, 68, 0, 0, 0,
+	0, 0, 0, 0, 25, 28, 26, 49, 24, 0,
+	0, 0, 0, 46, 0, 0, 0, 0, 0, 85,
+	0, 0, 0, 0, 91, 0, 0, 88, 0, 86,
+	89, 87, 90, 96, 0, 0, 45, 0, 0, 167,
+	0, 0, 0, 44, 51, 56, 82, 0, 164, 29,
+	83, 0, 92, 93, 94, 95, 31, 32, 60, 79,
+	71, 0, 52, 53, 43, 0, 0, 0, 54, 69,
+	47, 30, 27, 0, 0, 57, 0, 55, 58, 63,
+	64, 65, 66, 68, 0, 0, 0, 0, 0, 0,
+	0, 25, 28, 26, 49, 24, 0, 0, 0, 0,
+	46, 0, 0, 0, 0, 0, 85, 0, 0, 0,
+	0, 91, 0, 0, 88, 0, 86, 89, 87, 90,
+	96, 0, 0, 45, 0, 0, 167, 0, 0, 0,
+	44, 51, 56, 519, 0, 0, 82, 0, 164, 29,
+	83, 0, 92, 93, 94, 95, 31, 32, 60, 79,
+	71, 0, 52, 53, 43, 0, 0, 0, 54, 0,
+	200, 30, 27, 0, 0, 57, 0, 55, 58, 63,
+	64, 65, 66, 202, 0, 0, 0, 0, 0, 0,
+	0, 25, 28, 26, 49, 24, 0, 0, 0, 0,
+	46, 0, 0, 0, 0, 0, 85, 0, 0, 0,
+	0, 91, 0, 0, 88, 0, 86, 89, 87, 90,
+	96, 0, 0, 45, 0, 0, 167, 0, 0, 0,
+	44, 51, 56, 571, 0, 0, 82, 0, 164, 29,
+	83, 0, 92, 93, 94, 95, 31, 32, 60, 79,
+	71, 0, 52, 53, 43, 0, 0, 0, 54, 0,
+	200, 30, 27, 0, 0, 57, 0, 55, 58, 63,
+	64, 65, 66, 202, 0, 0, 0, 0, 0, 0,
+	0, 25, 28, 26, 49, 24, 0, 0, 0, 0,
+	46, 0, 0, 0, 0, 0, 85, 0, 0, 0,
+	0, 91, 0, 0, 88, 0, 86, 89, 87, 90,
+	96, 0, 0, 45, 0, 0, 167, 0, 0, 0,
+	44, 51, 56, 239, 0, 0, 82, 0, 164, 29,
+	83, 0, 92, 93, 94, 95, 31, 32, 60, 79,
+	71, 0, 52, 53, 43, 0, 0, 0, 54, 0,
+	200, 30, 27, 0, 0, 57, 0, 55, 58, 63,
+	64, 65, 66, 202, 0, 0, 0, 0, 0, 0,
+	0, 25, 28, 26, 49, 24, 0, 0, 0, 0,
+	46, 0, 0, 0, 0, 0, 85, 0, 0, 0,
+	0, 91, 0, 0, 88, 0, 86, 89, 87, 90,
+	96, 0, 0, 45, 0, 0, 167, 0, 0, 0,
+	44, 51, 56, 82, 0, 164, 29, 83, 0, 92,
+	93, 94, 95, 31, 32, 60, 79, 71, 0, 52,
+	53, 43, 0, 0, 0, 54, 0, 200, 30, 27,
+	0, 0, 57, 0, 55, 58, 63, 64, 65, 66,
+	202, 0, 0, 0, 0, 0, 0, 0, 25, 28,
+	26, 49, 24, 0, 0, 0, 0, 46, 0, 0,
+	0, 0, 0, 85, 0, 0, 0, 0, 91, 0,
+	0, 88, 0, 86, 89, 87, 90, 96, 0, 0,
+	45, 0, 0, 167, 0, 0, 0, 44, 51, 56,
+	195, 0, 0, 82, 0, 0, 29, 83, 0, 92,
+	93, 94, 95, 31, 32, 60, 79, 71, 0, 52,
+	53, 43, 0, 0, 0, 54, 0, 200, 30, 27,
+	0, 0, 57, 0, 55, 58, 63, 64, 65, 66,
+	202, 0, 0, 0, 0, 0, 0, 0, 25, 28,
+	26, 49, 24, 0, 0, 0, 0, 46, 0, 0,
+	0, 0, 0, 85, 0, 0, 0, 0, 91, 0,
+	0, 88, 0, 86, 89, 87, 90, 96, 0, 0,
+	45, 0, 0, 167, 0, 0, 0, 44, 51, 56,
+	82, 0, 0, 29, 83, 0, 92, 93, 94, 95,
+	31, 32, 60, 79, 71, 0, 52, 53, 43, 0,
+	0, 0, 54, 0, 200, 30, 27, 0, 0, 57,
+	0, 55, 58, 63, 64, 65, 66, 202, 0, 0,
+	0, 0, 0, 0, 0, 25, 28, 26, 49, 24,
+	0, 0, 0, 0, 46, 0, 0, 0, 0, 0,
+	85, 0, 0, 0, 0, 91, 0, 0, 88, 0,
+	86, 89, 87, 90, 96, 0, 0, 45, 0, 0,
+	167, 0, 0, 0, 44, 51, 56, 82, 0, 0,
+	29, 83, 0, 92, 93, 94, 95, 31, 32, 60,
+	79, 71, 0, 52, 53, 43, 0, 0, 0, 54,
+	0, 200, 30, 27, 0, 0, 57, 0, 55, 58,
+	63, 64, 65, 66, 202, 0, 0, 0, 0, 0,
+	0, 0, 25, 28, 26, 49, 24, 0, 0, 0,
+	0, 46, 0, 0, 0, 0, 0, 85, 0, 0,
+	0, 0, 91, 0, 0, 88, 0, 86, 89, 87,
+	90, 96, 0, 0, 45, 0, 0, 14, 0, 0,
+	0, 44, 51, 56, 119, 120, 127, 121, 122, 123,
+	124, 125, 126, 118, 116, 117, 128, 129, 130, 131,
+	132, 133, 134, 0, 135, 136, 0, 139, 0, 0,
+	119, 120, 127, 121, 122, 123, 124, 125, 126, 118,
+	116, 117, 128, 129, 130, 131, 132, 133, 134, 114,
+	135, 136, 0, 110, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 108, 106, 0,
-	107, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 115, 113, 0, 0, 114, 179, 180, 181, 182,
+	183, 190, 192, 191, 193, 178, 176, 177, 184, 185,
+	170, 171, 172, 173, 174, 0, 175, 115, 113, 119,
+	120, 127, 121, 122, 123, 124, 125, 126, 118, 116,
+	117, 128, 129, 130, 131, 132, 133, 134, 0, 135,
+	136, 0, 0, 0, 0, 119, 120, 127, 121, 122,
+	123, 124, 125, 126, 118, 116, 117, 128, 129, 130,
+	131, 132, 133, 134, 114, 135, 136, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 108, 106, 112, 113, 120, 114, 115, 116, 117,
-	118, 119, 111, 109, 110, 121, 122, 123, 124, 125,
-	126, 127, 0, 128, 129, 0, 112, 113, 120, 114,
-	115, 116, 117, 118, 119, 111, 109, 110, 121, 122,
-	123, 124, 125, 126, 127, 0, 128, 129, 0, 0,
-	0, 0, 318, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 115, 113, 0, 0,
+	330, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 108, 555, 107, 0, 0, 0, 0,
+	0, 0, 115, 575, 119, 120, 127, 121, 122, 123,
+	124, 125, 126, 118, 116, 117, 128, 129, 130, 131,
+	132, 133, 134, 0, 135, 136, 0, 0, 0, 0,
+	119, 120, 127, 121, 122, 123, 124, 125, 126, 118,
+	116, 117, 128, 129, 130, 131, 132, 133, 134, 114,
+	135, 136, 0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 108, 531, 112, 113,
-	120, 114, 115, 116, 117, 118, 119, 111, 109, 110,
-	121, 122, 123, 124, 125, 126, 127, 0, 128, 129,
-	0, 112, 113, 120, 114, 115, 116, 117, 118, 119,
-	111, 109, 110, 121, 122, 123, 124, 125, 126, 127,
-	0, 128, 129, 0, 0, 0, 0, 466, 0, 0,
+	0, 115, 548, 0, 0, 482, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 108, 465,
-	107, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 115, 481, 119,
+	120, 127, 121, 122, 123, 124, 125, 126, 118, 116,
+	117, 128, 129, 130, 131, 132, 133, 134, 0, 135,
+	136, 0, 0, 0, 0, 119, 120, 127, 121, 122,
+	123, 124, 125, 126, 118, 116, 117, 128, 129, 130,
+	131, 132, 133, 134, 114, 135, 136, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 108, 471, 112, 113, 120, 114, 115, 116, 117,
-	118, 119, 111, 109, 110, 121, 122, 123, 124, 125,
-	126, 127, 0, 128, 129, 181, 0, 161, 162, 180,
-	179, 172, 173, 174, 175, 176, 183, 185, 184, 186,
-	171, 169, 170, 177, 178, 163, 164, 165, 166, 167,
-	0, 168, 318, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 115, 487, 0, 0,
+	330, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 108, 317, 180, 179, 172, 173, 174,
-	175, 176, 183, 185, 184, 186, 171, 169, 170, 177,
-	178, 163, 164, 165, 166, 167, 0, 168, 172, 173,
-	174, 175, 176, 183, 185, 184, 186, 171, 169, 170,
-	177, 178, 163, 164, 165, 166, 167, 0, 168,
+	0, 0, 115, 329, 465, 188, 0, 168, 169, 187,
+	186, 179, 180, 181, 182, 183, 190, 192, 191, 193,
+	178, 176, 177, 184, 185, 170, 171, 172, 173, 174,
+	188, 175, 168, 169, 187, 186, 179, 180, 181, 182,
+	183, 190, 192, 191, 193, 178, 176, 177, 184, 185,
+	170, 171, 172, 173, 174, 0, 175, 187, 186, 179,
+	180, 181, 182, 183, 190, 192, 191, 193, 178, 176,
+	177, 184, 185, 170, 171, 172, 173, 174, 0, 175,
+	186, 179, 180, 181, 182, 183, 190, 192, 191, 193,
+	178, 176, 177, 184, 185, 170, 171, 172, 173, 174,
+	0, 175,
 }
 
 var yyPact = [...]int16{
-	1700, -1000, -1000, 103, 346, 3507, -1000, 638, 637, 3435,
-	-1000, 828, 451, -1000, 2052, -1000, -1000, -1000, -1000, -1000,
-	2668, 3821, -1000, 3108, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, 271, -1000, 717, 602, 602, -1000, -1000,
-	-1000, -1000, -1000, 1700, 2932, 2404, -13, 119, -1000, 209,
-	9, 2580, 2580, -1000, -1000, 240, 2140, 3278, 437, 549,
-	437, 1700, -1000, -33, 150, -24, 2316, 231, 3412, -1000,
-	-1000, -1000, 8, -1000, -1000, -1000, -1000, -1000, 654, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, 1511, -1000, -1000, -1000, -1000, -1000, 2580, 2580,
-	2580, 2580, 3531, 530, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	1845, -1000, -1000, 151, 781, 3575, -1000, 726, 724, 3549,
+	-1000, 1143, 288, -1000, 2204, -1000, -1000, -1000, -1000, -1000,
+	2822, 3961, -1000, 3269, 255, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, 358, -1000, 695, 723, 723, -1000, -1000,
+	-1000, -1000, -1000, 1845, 489, 3092, 2558, 2, 80, -1000,
+	255, 41, 2735, 2735, -1000, -1000, 315, 2291, 3443, 56,
+	603, 56, 1845, -1000, -14, -1000, -1000, 214, -10, 2471,
+	271, 1624, -1000, -1000, -1000, -1000, 50, -1000, -1000, -1000,
+	-1000, -1000, 611, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 1517, -1000,
+	-1000, -1000, -1000, -1000, 2735, 2735, 2735, 2735, 1845, 3644,
+	589, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	2492, 2492, -1000, -1000, 2668, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, 1788, 3793, 427, -1000, -1000, 159, 361,
-	-1000, 2052, -1000, -1000, 632, 828, 241, 3020, -1000, -1000,
-	1700, 3020, 3020, 3020, 3020, 3020, 3020, 3020, 3020, 3020,
-	3020, 3020, 3020, 3020, 3020, 3020, 3020, 3020, 3020, 3020,
-	3020, 3020, 3020, -1000, -1000, -1000, -1000, 147, 3193, -1000,
-	-1000, 438, -1000, -13, 119, -24, 268, 268, -1000, 567,
-	3020, 630, -1000, 648, 103, 144, 142, -1000, 49, -1000,
-	-1000, 131, 64, -1000, 262, 603, 253, -1000, 252, 251,
-	3020, 629, -1000, -1000, 103, 159, 183, -1000, 3020, 3821,
-	241, 218, 124, -1000, -46, 3020, 3020, -1000, 1964, 2316,
-	209, -1000, -1000, 567, 567, 1788, -1000, 648, 1700, 175,
-	-1000, 175, 1700, 2580, 1700, 1700, 1700, 103, 293, 174,
-	270, -1000, -1000, -1000, -1000, 228, 45, -1000, 373, 1612,
-	518, -1000, 2932, -1000, -1000, -1000, -1000, 127, 62, -46,
-	281, -1000, 191, 106, 0, 52, -1000, 346, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 2648, 2648, -1000,
+	-1000, 2822, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	1936, 3860, 486, -1000, -1000, 81, 850, -1000, 2204, -1000,
+	-1000, 707, 1143, 249, 3179, -1000, -1000, 1845, 3179, 3179,
+	3179, 3179, 3179, 3179, 3179, 3179, 3179, 3179, 3179, 3179,
+	3179, 3179, 3179, 3179, 3179, 3179, 3179, 3179, 3179, 3179,
+	-1000, -1000, -1000, -1000, 200, 3356, -1000, -1000, 286, -1000,
+	2, 80, -10, 326, 326, -1000, 615, 615, -1000, 615,
+	3179, 686, -1000, 711, 151, 171, 157, -1000, 86, -1000,
+	-1000, 149, 102, -1000, 331, 661, 325, -1000, 316, 298,
+	3179, 671, -1000, -1000, 151, -1000, 81, 233, -1000, 3179,
+	3961, 249, 265, 225, -1000, -54, 3179, 3179, -1000, 2117,
+	2471, 255, -1000, -1000, 1936, -1000, 711, 1845, 141, -1000,
+	141, 1845, 2735, 1845, 1845, 1845, 151, 373, 143, 344,
+	-1000, -1000, -1000, -1000, 224, 65, -1000, 480, 468, 1735,
+	575, -1000, 3092, -1000, -1000, -1000, -1000, 129, 97, -54,
+	341, -1000, 267, 244, 33, 197, -1000, 244, 781, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	121, 3020, -1000, -1000, -1000, -1000, -1000, 3821, 609, 98,
-	-1000, -1000, 3821, 159, -1000, 97, 296, 828, 828, -1000,
-	-24, 828, -1000, -37, -1000, -1000, 159, 3020, 3020, 3698,
-	1788, 398, 3867, 3867, 691, 691, 536, 536, 536, 536,
-	852, 852, 1126, 994, 994, 994, 994, 994, 770, 770,
-	3888, 1461, 3391, 1107, -1000, -1000, 1788, 3721, 395, 648,
-	516, 1700, -1, 1107, 3020, 159, -1000, 648, -1000, -1000,
-	338, -1000, 188, 188, -1000, -1000, 569, -1000, 3020, 107,
-	-1000, -1000, -1000, -1000, 3020, 355, -1000, -1000, -9, -1000,
-	2756, -1000, -1000, 3698, -1000, -1000, 2404, 3020, -1000, -1000,
-	159, -1000, -1000, -1000, 202, 513, 159, -44, 190, 1700,
-	339, -1000, 1700, 512, 233, 511, 509, 187, 307, 502,
-	332, 2932, -1000, 1788, 3626, 385, 384, 1700, 358, 103,
-	102, -1000, 500, -1000, 455, 91, 2228, 2404, -24, 3531,
-	-1000, -1000, -1000, -1000, 3193, -1000, 4, -1000, 2844, -1000,
-	2668, 1788, 3603, 828, 1876, -1000, -1000, 2668, 2668, -1000,
-	-1000, -1000, 2668, -1000, -1000, 828, 828, 159, 828, 590,
-	159, -1000, -1000, 159, -1000, -1000, 151, -1000, 3821, -1000,
-	-1000, 71, 49, -1000, 49, -1000, 603, 64, -1000, -1000,
-	-1000, 250, -1000, 3821, 499, -1000, 1700, 232, -1000, -1000,
-	3020, -1000, -1000, -1000, -1000, -1000, -1000, 212, -1000, 439,
-	-1000, 444, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 496,
-	490, 300, 888, -1000, -1000, -1000, 1700, -1000, 658, 1700,
-	159, -1000, -1000, -1000, 488, 35, -1000, 334, -1000, -1000,
-	2756, -1000, 62, -46, 271, 209, -1000, 40, 31, -1000,
-	-1000, -1000, -1000, 3020, 159, 828, 2668, 284, -1000, -1000,
-	-1000, -1000, -1000, -1000, 3020, 3020, 828, 3020, 3020, -1000,
-	-1000, -1000, -1, -1000, 338, -1000, -1000, -1000, -1000, -1000,
-	-1000, 242, 80, 1700, -1000, -1000, 1700, 1700, 482, -1000,
-	2580, -1000, 1700, 472, -1000, -1000, -1000, -1000, 1700, -1000,
-	175, 872, 859, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	1700, 471, -1000, -1000, -1000, 323, 1700, -1000, 1700, -1000,
-	-1000, -1000, 3531, 1788, -1000, 828, -1000, -1000, -1000, -1000,
-	3020, 3821, 49, 175, 238, 1700, 321, -1000, 171, 469,
-	-1000, 175, -1000, -1000, -1000, 1700, -1000, 47, -1000, -1000,
-	46, -1000, -1000, 20, 468, 462, 159, -1000, 1700, 175,
-	-1000, -1000, -1000, -1000, 1700, -1000, -1000, 888, -1000, -1000,
-	309, -1000, -1000, -1000, -1000, 1700, 439, -1000, 103, -1000,
-	-1000, -1000,
+	-1000, -1000, 124, 3179, -1000, -1000, -1000, 651, -1000, 3936,
+	663, 74, -1000, -1000, 3936, 81, -1000, 118, 368, 1143,
+	1143, -1000, -10, 1143, -1000, -24, -1000, -1000, 81, 3179,
+	3179, 3765, 1936, 476, 3984, 3984, 641, 641, 594, 594,
+	594, 594, 1084, 1084, 1096, 1063, 1063, 1063, 1063, 1063,
+	830, 830, 3621, 4006, 1205, 1017, -1000, -1000, 1936, 3834,
+	472, 711, 261, 1845, 61, 555, 551, 1017, 3179, 81,
+	-1000, 711, -1000, -1000, 425, -1000, 277, 277, -1000, -1000,
+	624, -1000, 3179, 145, -1000, -1000, -1000, -1000, 3179, 463,
+	-1000, -1000, 5, -1000, 2912, -1000, -1000, 3765, -1000, -1000,
+	2558, 3179, -1000, -1000, 81, -1000, -1000, -1000, 81, -52,
+	230, 1845, 499, -1000, 1845, 547, 605, 543, 542, 199,
+	382, 541, 411, 3092, -1000, 1936, 3739, 470, 449, 1845,
+	16, -1000, 151, 190, -1000, 540, -1000, 501, 105, 2381,
+	2558, -10, 3644, -1000, -1000, -1000, -1000, 3356, -1000, 53,
+	-1000, -1000, 3002, -1000, 1845, 3179, 2822, 1936, 3670, 1143,
+	2027, -1000, -1000, 2822, 2822, -1000, -1000, -1000, 2822, -1000,
+	-1000, 1143, 1143, 81, 1143, 639, 81, -1000, -1000, 81,
+	-1000, -1000, 95, 285, -1000, -1000, -1000, 3961, -1000, -1000,
+	115, 86, -1000, 86, -1000, 661, 102, -1000, -1000, -1000,
+	296, -1000, 3961, 533, -1000, 1845, 47, -1000, -1000, 3179,
+	-1000, -1000, -1000, -1000, 264, -1000, 442, -1000, 496, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, 532, 530, 363, 995,
+	-1000, -1000, -1000, 1845, -1000, 221, 1845, 81, -1000, -1000,
+	-1000, 529, 187, 447, -1000, 432, -1000, -1000, 2912, -1000,
+	97, -54, 358, 255, -1000, 69, -27, -1000, -1000, -1000,
+	-1000, 3179, -1000, 3961, 81, 1143, 2822, 352, -1000, -1000,
+	-1000, -1000, -1000, -1000, 3179, 3179, 1143, 3179, 3179, -1000,
+	-1000, 610, 23, 285, 165, -1000, -1000, -1000, 425, -1000,
+	-1000, -1000, -1000, -1000, -1000, 293, 34, 1845, -1000, -1000,
+	1845, 1845, 527, -1000, 2735, -1000, 1845, 519, -1000, -1000,
+	-1000, -1000, 1845, -1000, 141, 854, 834, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, 1845, 516, -1000, -1000, -1000, 426,
+	121, 1845, -1000, 1845, -1000, -1000, -1000, 3644, 1936, -1000,
+	1143, -1000, -1000, -1000, -1000, 3179, 3961, -1000, 89, -1000,
+	285, 86, 141, 284, 1845, 414, -1000, 153, 514, -1000,
+	141, -1000, -1000, -1000, 1845, -1000, 21, -1000, -1000, 12,
+	-1000, -1000, 68, -1000, 402, 512, 510, 81, -1000, -1000,
+	1845, 141, -1000, -1000, -1000, -1000, 1845, -1000, -1000, 995,
+	-1000, -1000, 378, 151, -1000, -1000, -1000, -1000, 1845, 442,
+	-1000, 151, -1000, -1000, -1000, -1000,
 }
 
 var yyPgo = [...]int16{
-	0, 22, 730, 64, 788, 81, 11, 3, 596, 6,
-	787, 782, 383, 781, 779, 777, 508, 773, 252, 772,
-	771, 770, 767, 765, 764, 48, 41, 763, 762, 690,
-	455, 368, 76, 1081, 59, 20, 167, 759, 399, 0,
-	86, 334, 28, 756, 317, 753, 82, 747, 746, 35,
-	967, 738, 80, 2, 12, 25, 737, 735, 733, 604,
-	56, 866, 649, 732, 520, 94, 726, 61, 717, 73,
-	71, 16, 39, 49, 716, 50, 67, 715, 9, 38,
-	27, 32, 14, 33, 70, 586, 36, 34, 713, 709,
-	29, 8, 26, 30, 707, 10, 696, 63, 692, 51,
-	18, 13, 45, 31, 689, 65, 46, 686, 685, 42,
-	683, 680, 678, 19, 675, 5, 674, 17, 672, 40,
-	47, 670, 4, 54, 660, 651, 647,
+	0, 13, 331, 551, 849, 218, 22, 19, 643, 10,
+	845, 844, 45, 489, 842, 838, 837, 565, 836, 39,
+	833, 831, 830, 828, 825, 824, 84, 26, 823, 822,
+	819, 754, 652, 444, 103, 1299, 78, 5, 130, 815,
+	318, 0, 250, 36, 56, 812, 74, 810, 29, 809,
+	808, 255, 942, 807, 68, 2, 33, 48, 803, 802,
+	796, 732, 104, 455, 736, 794, 641, 79, 791, 60,
+	790, 186, 62, 32, 46, 49, 782, 80, 91, 781,
+	25, 43, 20, 35, 8, 42, 7, 52, 649, 82,
+	64, 780, 779, 9, 777, 4, 17, 38, 18, 776,
+	12, 775, 40, 773, 87, 30, 16, 65, 37, 771,
+	31, 41, 770, 767, 47, 766, 763, 762, 27, 760,
+	3, 759, 11, 750, 75, 92, 749, 6, 50, 748,
+	746, 744,
 }
 
-var yyR1 = [...]int8{
-	0, 118, 63, 95, 61, 62, 62, 62, 52, 52,
-	52, 52, 52, 52, 52, 52, 52, 52, 52, 52,
-	52, 52, 41, 41, 41, 41, 41, 41, 42, 42,
-	32, 32, 32, 40, 121, 46, 44, 44, 47, 47,
-	1, 43, 43, 43, 43, 43, 43, 43, 64, 64,
-	67, 67, 65, 65, 65, 59, 66, 66, 60, 60,
-	60, 60, 36, 36, 36, 36, 36, 23, 24, 16,
-	16, 17, 17, 5, 5, 4, 4, 4, 4, 4,
+var yyR1 = [...]uint8{
+	0, 123, 65, 100, 63, 64, 64, 64, 54, 54,
+	54, 54, 54, 54, 54, 54, 54, 54, 54, 54,
+	54, 54, 54, 43, 43, 43, 43, 43, 43, 44,
+	44, 44, 34, 34, 34, 42, 126, 48, 46, 46,
+	49, 49, 1, 45, 45, 45, 45, 45, 45, 45,
+	66, 66, 69, 69, 67, 67, 67, 61, 68, 68,
+	62, 62, 62, 62, 38, 38, 38, 38, 38, 24,
+	25, 17, 17, 17, 18, 18, 5, 5, 4, 4,
 	4, 4, 4, 4, 4, 4, 4, 4, 4, 4,
-	4, 4, 4, 4, 4, 4, 33, 33, 33, 33,
-	33, 33, 33, 33, 33, 33, 33, 33, 33, 33,
-	33, 33, 33, 33, 33, 33, 33, 33, 33, 33,
-	33, 33, 33, 33, 33, 33, 33, 33, 33, 33,
-	8, 8, 8, 8, 56, 56, 50, 74, 74, 49,
-	72, 73, 73, 71, 71, 71, 71, 71, 71, 71,
-	70, 70, 70, 69, 69, 69, 69, 77, 77, 124,
-	75, 76, 76, 76, 34, 34, 34, 34, 34, 34,
-	34, 34, 34, 34, 34, 34, 34, 34, 34, 34,
-	34, 34, 34, 34, 34, 34, 34, 34, 34, 34,
-	34, 34, 34, 34, 34, 125, 34, 126, 34, 34,
-	34, 34, 34, 34, 39, 6, 6, 6, 109, 109,
-	108, 108, 108, 108, 111, 111, 110, 110, 21, 21,
-	53, 53, 54, 54, 68, 68, 88, 88, 101, 48,
-	48, 48, 48, 51, 51, 51, 51, 51, 100, 100,
-	99, 97, 96, 98, 98, 98, 113, 112, 114, 114,
-	114, 115, 115, 115, 115, 115, 116, 116, 116, 116,
-	116, 117, 117, 35, 35, 57, 58, 22, 22, 22,
-	10, 10, 10, 11, 12, 12, 12, 13, 45, 45,
-	14, 15, 102, 103, 104, 104, 85, 85, 27, 28,
-	28, 31, 31, 31, 31, 29, 29, 29, 29, 29,
-	30, 30, 30, 30, 37, 37, 38, 38, 19, 19,
-	19, 19, 84, 84, 91, 91, 91, 91, 91, 90,
-	90, 86, 86, 86, 86, 86, 86, 86, 86, 86,
-	94, 94, 78, 78, 87, 87, 79, 79, 89, 89,
-	83, 80, 92, 92, 82, 81, 93, 93, 107, 107,
-	106, 106, 105, 105, 105, 105, 2, 2, 2, 26,
-	26, 119, 119, 122, 122, 3, 9, 123, 123, 123,
-	7, 7, 7, 25, 120, 120, 120, 55, 18, 18,
-	18, 18, 18, 18, 18, 18, 20, 20,
+	4, 4, 4, 4, 4, 4, 4, 4, 4, 35,
+	35, 35, 35, 35, 35, 35, 35, 35, 35, 35,
+	35, 35, 35, 35, 35, 35, 35, 35, 35, 35,
+	35, 35, 35, 35, 35, 35, 35, 35, 35, 35,
+	35, 35, 35, 8, 8, 8, 8, 58, 58, 52,
+	76, 76, 51, 51, 74, 75, 75, 73, 73, 73,
+	73, 73, 73, 73, 72, 72, 72, 71, 71, 71,
+	71, 79, 79, 129, 77, 78, 78, 78, 36, 36,
+	36, 36, 36, 36, 36, 36, 36, 36, 36, 36,
+	36, 36, 36, 36, 36, 36, 36, 36, 36, 36,
+	36, 36, 36, 36, 36, 36, 36, 36, 36, 36,
+	36, 130, 36, 131, 36, 36, 36, 36, 36, 36,
+	36, 36, 41, 6, 6, 6, 114, 114, 113, 113,
+	113, 113, 116, 116, 115, 115, 22, 22, 55, 55,
+	56, 56, 70, 70, 91, 91, 91, 92, 92, 93,
+	93, 86, 106, 50, 50, 50, 50, 53, 53, 53,
+	53, 53, 105, 105, 104, 102, 101, 103, 103, 103,
+	118, 117, 119, 119, 119, 120, 120, 120, 120, 120,
+	121, 121, 121, 121, 121, 122, 122, 37, 37, 37,
+	59, 60, 23, 23, 23, 10, 10, 10, 12, 13,
+	13, 13, 14, 47, 47, 15, 16, 107, 108, 109,
+	109, 88, 88, 28, 29, 11, 30, 30, 33, 33,
+	33, 33, 31, 31, 31, 31, 31, 32, 32, 32,
+	32, 39, 39, 40, 40, 20, 20, 20, 20, 20,
+	20, 87, 87, 96, 96, 96, 96, 96, 95, 95,
+	89, 89, 89, 89, 89, 89, 89, 89, 89, 99,
+	99, 80, 80, 90, 90, 81, 81, 94, 94, 85,
+	82, 97, 97, 84, 83, 98, 98, 112, 112, 111,
+	111, 110, 110, 110, 110, 2, 2, 2, 27, 27,
+	124, 124, 127, 127, 3, 9, 128, 128, 128, 7,
+	7, 7, 26, 125, 125, 125, 57, 19, 19, 19,
+	19, 19, 19, 19, 19, 21, 21,
 }
 
 var yyR2 = [...]int8{
 	0, 1, 2, 2, 2, 0, 1, 3, 3, 3,
-	3, 3, 3, 3, 1, 3, 3, 3, 1, 2,
-	2, 1, 3, 3, 6, 5, 5, 5, 1, 1,
-	1, 2, 1, 1, 0, 3, 1, 1, 1, 4,
-	1, 2, 3, 4, 2, 2, 2, 1, 1, 3,
-	1, 3, 1, 2, 3, 1, 2, 3, 1, 1,
-	4, 3, 1, 1, 4, 3, 3, 1, 1, 1,
-	3, 1, 3, 1, 1, 1, 1, 1, 1, 1,
+	3, 3, 3, 3, 3, 1, 3, 3, 3, 1,
+	2, 2, 1, 3, 3, 6, 5, 5, 5, 1,
+	3, 1, 1, 2, 1, 1, 0, 3, 1, 1,
+	1, 4, 1, 2, 3, 4, 2, 2, 2, 1,
+	1, 3, 1, 3, 1, 2, 3, 1, 2, 3,
+	1, 1, 4, 3, 1, 1, 4, 3, 3, 1,
+	1, 1, 3, 2, 1, 3, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 1, 3, 3, 6, 5,
-	5, 5, 3, 3, 2, 2, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 1, 3, 3, 3,
-	3, 2, 3, 3, 3, 3, 6, 4, 4, 1,
-	1, 1, 1, 1, 3, 3, 1, 0, 2, 1,
-	3, 0, 1, 0, 1, 2, 2, 4, 2, 4,
-	1, 1, 3, 1, 3, 2, 4, 1, 1, 0,
-	2, 3, 4, 2, 1, 1, 1, 1, 1, 5,
-	3, 3, 3, 3, 4, 3, 1, 2, 1, 1,
-	2, 7, 4, 7, 6, 6, 4, 4, 4, 4,
-	5, 4, 5, 6, 5, 0, 7, 0, 7, 4,
-	3, 1, 1, 4, 1, 1, 1, 2, 0, 2,
-	5, 6, 4, 3, 1, 3, 0, 2, 1, 1,
-	1, 5, 1, 2, 1, 1, 0, 3, 3, 2,
-	4, 5, 5, 2, 4, 2, 1, 4, 3, 3,
-	2, 2, 4, 1, 2, 1, 2, 4, 1, 2,
-	1, 2, 2, 3, 3, 1, 1, 1, 1, 1,
-	1, 1, 3, 1, 1, 3, 3, 1, 1, 1,
-	1, 1, 1, 1, 2, 2, 0, 3, 3, 4,
-	1, 1, 2, 4, 2, 2, 0, 3, 1, 1,
-	2, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 1, 1, 1, 0, 3,
-	5, 7, 3, 2, 1, 4, 2, 2, 1, 2,
-	0, 4, 2, 2, 1, 0, 6, 4, 4, 2,
-	1, 3, 1, 3, 1, 3, 2, 1, 1, 3,
-	2, 3, 1, 3, 2, 2, 2, 0, 0, 2,
-	1, 3, 3, 2, 1, 2, 1, 1, 1, 1,
-	1, 0, 1, 0, 1, 2, 2, 0, 1, 1,
-	1, 1, 1, 1, 1, 2, 2, 0, 1, 1,
-	1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 3,
+	3, 6, 5, 5, 5, 3, 3, 2, 2, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 1,
+	3, 3, 3, 3, 2, 3, 3, 3, 3, 6,
+	4, 4, 1, 1, 1, 1, 1, 3, 3, 1,
+	0, 2, 1, 3, 3, 0, 1, 0, 1, 2,
+	2, 4, 2, 4, 1, 1, 3, 1, 3, 2,
+	4, 1, 1, 0, 2, 3, 4, 2, 1, 1,
+	1, 1, 1, 5, 3, 3, 2, 3, 3, 4,
+	3, 1, 2, 2, 1, 1, 2, 7, 4, 7,
+	6, 6, 4, 4, 4, 4, 5, 4, 5, 6,
+	5, 0, 7, 0, 7, 4, 3, 1, 1, 4,
+	1, 1, 1, 1, 1, 2, 0, 2, 5, 6,
+	4, 3, 1, 3, 0, 2, 1, 1, 1, 5,
+	1, 2, 1, 1, 0, 4, 4, 0, 2, 1,
+	3, 1, 3, 2, 4, 5, 5, 2, 4, 2,
+	1, 4, 3, 3, 2, 2, 4, 1, 2, 1,
+	2, 4, 1, 2, 1, 2, 2, 3, 3, 1,
+	1, 1, 1, 1, 1, 1, 3, 1, 1, 1,
+	3, 3, 1, 1, 1, 1, 1, 1, 1, 2,
+	2, 0, 3, 3, 4, 1, 1, 2, 4, 2,
+	2, 0, 3, 1, 3, 1, 1, 2, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 0, 3, 5, 7, 4,
+	6, 3, 2, 1, 4, 2, 2, 1, 2, 0,
+	4, 2, 2, 1, 0, 6, 4, 4, 2, 1,
+	3, 1, 3, 1, 3, 2, 1, 1, 3, 2,
+	3, 1, 3, 2, 2, 2, 0, 0, 2, 1,
+	3, 3, 2, 1, 2, 1, 1, 1, 1, 1,
+	0, 1, 0, 1, 2, 2, 0, 1, 1, 1,
+	1, 1, 1, 1, 2, 2, 0, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1,
 }
 
 var yyChk = [...]int16{
-	-1000, -118, -63, -62, -52, 80, -41, -64, -36, -20,
-	-32, -38, -39, -65, 121, -29, -30, 84, 85, -44,
-	41, -33, -34, -66, 91, 87, 89, 69, 88, 42,
-	68, 49, 50, -43, -47, -56, -102, -103, -35, -57,
-	-58, -45, -37, 59, 118, 96, 67, -1, 90, -51,
-	125, 55, 57, 64, 74, 126, 72, 76, -23, 51,
-	-24, -104, 77, 78, -59, 79, 66, -48, 53, -28,
-	-27, -10, -22, -14, -2, 52, -60, -31, 39, 43,
-	102, 112, 114, 110, 113, 115, 107, 45, 46, 47,
-	48, -119, -120, -7, 116, 99, -25, 92, 56, 58,
-	65, 75, -5, 43, -2, -4, 91, 69, 90, 30,
-	31, 29, 20, 21, 23, 24, 25, 26, 27, 28,
-	22, 32, 33, 34, 35, 36, 37, 38, 40, 41,
-	5, 5, 43, -5, -18, 6, 7, 8, 9, 10,
-	11, 12, 13, 117, -26, 124, 95, 94, -67, -52,
-	-65, 121, -44, -33, -36, -38, -39, 41, -29, -30,
-	121, 16, 17, 34, 35, 36, 37, 38, 40, 30,
-	31, 29, 20, 21, 22, 23, 24, 32, 33, 19,
-	18, 14, -8, 25, 27, 26, 28, -59, 36, -29,
-	-30, -39, -34, 67, -1, 79, -102, -103, -101, 83,
-	-8, -85, -84, 120, -86, -87, -92, -91, -82, -78,
-	-80, -89, -83, -81, 36, 91, 121, -79, 101, 29,
-	93, -85, -84, -61, -62, -74, -69, -50, 36, -33,
-	-39, -107, -106, -105, -50, 93, 101, -75, 120, -124,
-	-75, -100, -72, 97, 81, 120, -100, 121, 97, -40,
-	-32, -40, -46, -121, -46, 81, 97, -40, -119, -120,
-	-68, -36, -64, -29, -30, -39, -16, 69, 32, -16,
-	-95, -61, 121, 100, -75, -72, -70, -69, -106, -50,
-	95, -5, 68, -12, 106, -12, -31, -52, 99, -25,
-	-40, -40, -40, -40, -5, 43, -44, -77, -76, -50,
-	-69, 36, -76, -42, -49, -44, -41, -33, -36, -39,
-	-42, -49, -33, -71, -70, -69, 29, 91, 69, -2,
-	-5, 69, -3, -122, 99, -3, -67, 5, -18, -26,
-	117, 124, -33, -33, -33, -33, -33, -33, -33, -33,
-	-33, -33, -33, -33, -33, -33, -33, -33, -33, -33,
-	-33, -33, -33, -33, 100, -60, 117, -26, 124, 120,
-	-99, -88, 30, -33, 5, -86, -7, 100, -90, -90,
-	100, -90, 100, 100, -93, -93, 100, 91, 5, -94,
-	91, 91, 91, -50, 5, -109, -119, -9, -122, -123,
-	100, 99, -50, -26, 98, -123, 100, 123, -50, -50,
-	-70, -3, -70, -100, -99, -99, -71, -86, -61, -6,
-	-7, 62, -6, -61, -40, -61, -61, -61, -119, -97,
-	-96, 73, 86, 117, -26, 124, 124, -19, 27, 68,
-	-17, 69, -95, 54, -109, -69, 100, 100, -2, 95,
-	-11, 106, -13, 103, 104, 111, -15, 108, 100, -50,
-	5, 117, -26, -9, 100, 43, 91, -18, -18, -75,
-	-73, -72, -18, 122, -3, 91, 69, -71, 69, -122,
-	-71, 91, 69, -86, 54, -61, -87, 91, -33, -3,
-	-91, -92, -82, -78, -82, -80, 91, -83, -79, -81,
-	-50, 100, -3, -33, -110, -108, 70, 60, 119, -50,
-	36, -105, -50, -3, 98, 54, -3, 122, 98, -61,
-	62, -61, 54, -21, -7, 82, 54, 54, 98, -97,
-	-113, -112, 86, 54, -98, -55, 63, -97, -69, -46,
-	-71, 91, 69, 69, -95, 69, -7, 124, -7, 54,
-	100, -3, -106, -50, -75, -73, -5, -34, -39, -29,
-	-30, 109, -50, 36, -71, 91, -18, 29, -42, -49,
-	-42, -49, -42, -49, -18, -18, -9, -18, 15, -9,
-	-3, 30, 100, -90, 100, -90, -90, -93, 91, 54,
-	-61, 123, -111, -6, 69, -50, 97, 81, -53, -54,
-	71, -55, 63, -54, 54, 54, -114, -55, 63, -113,
-	-115, 117, 118, -116, 91, -35, 42, 49, 50, -61,
-	-6, -61, -9, 54, -7, 124, -125, 69, -126, -101,
-	-100, 105, -26, 117, -50, -9, -42, -49, 43, 91,
-	-18, -33, -82, 91, 123, -6, 100, -61, -61, -61,
-	54, -32, -61, 54, -61, -6, 119, -117, -115, 119,
-	-117, -61, 54, 69, -95, -95, -71, -90, -6, 91,
-	-61, 69, 98, 54, -6, -61, 119, 100, 119, -7,
-	124, 54, 54, -9, -61, -6, -61, -115, 69, -61,
-	-53, -7,
+	-1000, -123, -65, -64, -54, 82, -43, -66, -38, -21,
+	-34, -40, -41, -67, 124, -31, -32, 86, 87, -46,
+	46, -35, -36, -68, 93, 89, 91, 70, 90, 47,
+	69, 54, 55, -45, -49, -58, -107, -108, -37, -59,
+	-60, -47, -39, 62, 128, 121, 98, 68, -1, 92,
+	-53, 129, 60, 61, 66, 75, 130, 73, 76, -24,
+	56, -25, -109, 77, 78, 79, 80, -61, 81, 67,
+	-50, 58, -30, -28, -29, -10, -23, -15, -2, 57,
+	-62, -33, 44, 48, -11, 104, 114, 116, 112, 115,
+	117, 109, 50, 51, 52, 53, 118, -124, -125, -7,
+	119, 101, -26, 94, 5, 6, 7, 8, 18, -5,
+	48, -2, -4, 93, 70, 92, 35, 36, 34, 25,
+	26, 28, 29, 30, 31, 32, 33, 27, 37, 38,
+	39, 40, 41, 42, 43, 45, 46, 9, 9, 48,
+	-5, -19, 10, 11, 12, 13, 14, 15, 16, 17,
+	120, -27, 127, 97, 96, -69, -54, -67, 124, -46,
+	-35, -38, -40, -41, 46, -31, -32, 124, 21, 22,
+	39, 40, 41, 42, 43, 45, 35, 36, 34, 25,
+	26, 27, 28, 29, 37, 38, 24, 23, 19, -8,
+	30, 32, 31, 33, -61, 41, -31, -32, -41, -36,
+	68, -1, 81, -107, -108, -105, 99, 83, -106, 85,
+	-8, -88, -87, 123, -89, -90, -97, -96, -84, -80,
+	-82, -94, -85, -83, 41, 93, 124, -81, 103, 34,
+	95, -88, -87, -63, -64, 70, -76, -71, -52, 41,
+	-35, -41, -112, -111, -110, -52, 95, 103, -77, 123,
+	-129, -77, -105, -74, 123, -105, 124, 99, -42, -34,
+	-42, -48, -126, -48, 83, 99, -42, -124, -125, -70,
+	-38, -66, -31, -32, -41, -17, 70, 128, 37, -17,
+	-100, -63, 124, 102, -77, -74, -72, -71, -111, -52,
+	97, -5, 69, -13, 108, -13, -33, -13, -54, 101,
+	-26, -42, -42, -42, -42, -54, -5, 48, -46, -79,
+	-78, -52, -71, 41, -78, -44, -51, -46, -43, -35,
+	-38, -41, -44, -51, -35, -73, -72, -71, 34, 93,
+	70, -2, -5, 70, -3, -127, 101, -3, -69, 9,
+	-19, -27, 120, 127, -35, -35, -35, -35, -35, -35,
+	-35, -35, -35, -35, -35, -35, -35, -35, -35, -35,
+	-35, -35, -35, -35, -35, -35, 102, -62, 120, -27,
+	127, 123, -104, -91, 35, -104, -104, -35, 9, -89,
+	-7, 102, -95, -95, 102, -95, 102, 102, -98, -98,
+	102, 93, 9, -99, 93, 93, 93, -52, 9, -114,
+	-124, -9, -127, -128, 102, 101, -52, -27, 100, -128,
+	102, 126, -52, -52, -72, -3, -72, -105, -73, -89,
+	-63, -6, -7, 64, -6, -63, -42, -63, -63, -63,
+	-124, -102, -101, 74, 88, 120, -27, 127, 127, -20,
+	32, 70, 69, -18, 70, -100, 59, -114, -71, 102,
+	102, -2, 97, -12, 108, -14, 105, 106, 113, -16,
+	110, -12, 102, -52, 18, 18, 9, 120, -27, -9,
+	102, 48, 93, -19, -19, -77, -75, -74, -19, 125,
+	-3, 93, 70, -73, 70, -127, -73, 93, 70, -89,
+	100, -63, -90, 119, 93, 59, 59, -35, -3, -96,
+	-97, -84, -80, -84, -82, 93, -85, -81, -83, -52,
+	102, -3, -35, -115, -113, 71, 63, 122, -52, 41,
+	-110, -52, -3, -3, 125, 100, -63, 64, -63, 59,
+	-22, -7, 84, 59, 59, 100, -102, -118, -117, 88,
+	59, -103, -57, 65, -102, -71, -48, -73, 93, 70,
+	70, -100, 70, 128, -7, 127, -7, 59, 102, -3,
+	-111, -52, -77, -75, -5, -36, -41, -31, -32, 111,
+	-52, 41, -54, -35, -73, 93, -19, 34, -44, -51,
+	-44, -51, -44, -51, -19, -19, -9, -19, 20, -9,
+	-3, -92, 102, 119, -93, -86, 93, -95, 102, -95,
+	-95, -98, 93, 59, -63, 126, -116, -6, 70, -52,
+	99, 83, -55, -56, 72, -57, 65, -56, 59, 59,
+	-119, -57, 65, -118, -120, 120, 121, -121, 93, -37,
+	47, 54, 55, -63, -6, -63, -9, 59, -7, 127,
+	70, -130, 70, -131, -106, -105, 107, -27, 120, -52,
+	-9, -44, -51, 48, 93, -19, -35, 35, -93, 35,
+	102, -84, 93, 126, -6, 102, -63, -63, -63, 59,
+	-34, -63, 59, -63, -6, 122, -122, -120, 122, -122,
+	-63, 59, 70, -7, 127, -100, -100, -73, -86, -95,
+	-6, 93, -63, 70, 100, 59, -6, -63, 122, 102,
+	122, -7, 127, 70, 59, 59, -9, -63, -6, -63,
+	-120, 70, -7, -63, -55, -7,
 }
 
 var yyDef = [...]int16{
-	5, -2, 1, 361, 6, 0, 14, 0, 0, 18,
-	21, 0, 0, 48, 0, -2, -2, 386, 387, 30,
-	0, 32, -2, 52, -2, 296, 297, -2, 299, 300,
-	301, 302, 303, 36, 37, 116, -2, -2, 164, 165,
-	166, 167, 168, 5, 137, 348, -2, 159, -2, 179,
-	0, 0, 0, 34, 34, 0, 361, 0, 0, 67,
-	0, 5, 201, 202, 0, -2, 47, 38, 0, 263,
-	264, 276, 0, 276, 40, 68, 55, 289, 0, 288,
-	270, 271, 272, 267, 268, 269, 280, 291, 292, 293,
-	294, 2, 362, 374, 370, 371, 372, 373, 0, 0,
-	0, 0, 0, 0, 73, 74, 356, 357, 358, 75,
-	76, 77, 78, 79, 80, 81, 82, 83, 84, 85,
-	86, 87, 88, 89, 90, 91, 92, 93, 94, 95,
-	0, 0, 19, 20, 0, 378, 379, 380, 381, 382,
-	383, 384, 385, 143, 0, 0, 359, 360, 363, 363,
-	-2, 0, 31, 121, 0, 0, 0, 0, -2, -2,
-	0, 104, 105, 0, 0, 0, 0, 0, 0, 0,
+	5, -2, 1, 380, 6, 0, 15, 0, 0, 19,
+	22, 0, 0, 50, 0, -2, -2, 405, 406, 32,
+	0, 34, -2, 54, -2, 313, 314, -2, 316, 317,
+	318, 319, 320, 38, 39, 119, -2, -2, 168, 169,
+	170, 171, 172, 5, 0, 140, 367, -2, 163, -2,
+	185, 0, 0, 0, 36, 36, 0, 380, 0, 0,
+	69, 0, 5, 207, 208, 210, 211, 0, -2, 49,
+	40, 0, 277, 278, 279, 291, 0, 291, 42, 70,
+	57, 306, 0, 303, 291, 285, 286, 287, 282, 283,
+	284, 295, 308, 309, 310, 311, 305, 2, 381, 393,
+	389, 390, 391, 392, 0, 0, 0, 0, 0, 0,
+	0, 76, 77, 375, 376, 377, 78, 79, 80, 81,
+	82, 83, 84, 85, 86, 87, 88, 89, 90, 91,
+	92, 93, 94, 95, 96, 97, 98, 0, 0, 20,
+	21, 0, 397, 398, 399, 400, 401, 402, 403, 404,
+	147, 0, 0, 378, 379, 382, 382, -2, 0, 33,
+	124, 0, 0, 0, 0, -2, -2, 0, 107, 108,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 130, 131, 132, 133, 53, 0, -2,
-	-2, 0, 204, 176, 0, 236, 325, 325, 229, 226,
-	0, 0, 284, 325, 0, 320, 320, 324, 320, 334,
-	342, 314, 347, 318, 0, 332, 0, 338, 0, 0,
-	337, 0, 285, 208, 361, 363, 367, 153, 0, 136,
-	0, 0, 367, 350, 0, 354, 0, 45, 363, 0,
-	41, 177, 233, 226, 226, 143, 180, 325, 5, 0,
-	33, 0, 5, 0, 5, 5, 5, 361, 0, 362,
-	0, 224, 225, -2, -2, 0, 308, 69, 0, 5,
-	0, 208, 0, 56, 44, 235, 46, 150, 151, 153,
-	0, 282, 0, 0, 0, 0, 290, 7, 375, 376,
-	10, 11, 12, 13, 8, 9, 15, 17, 157, -2,
-	0, 0, 16, 22, 96, 28, 29, -2, 0, 0,
-	23, 97, 139, 363, 144, 150, 0, -2, 357, -2,
-	141, -2, 49, 0, 364, 170, 363, 0, 0, 0,
-	143, 0, -2, -2, 106, 107, 108, 109, 110, 111,
-	112, 113, 114, -2, -2, -2, -2, -2, 122, 123,
-	124, 125, 363, 134, 57, 54, 143, 0, 0, 325,
-	0, 5, 0, 135, 0, 363, 313, 0, 322, 323,
-	0, 329, 0, 0, 316, 317, 0, 344, 0, 363,
-	330, 340, 345, 336, 0, 216, 4, 172, 0, 138,
-	369, 368, 155, 0, 173, 349, 369, 0, 353, 355,
-	363, 175, 160, 42, 0, 0, 363, 0, 0, 5,
-	205, 206, 5, 0, 0, 0, 0, 0, 0, 0,
-	377, 0, 34, 143, 0, 0, 0, 5, 0, 0,
-	0, 71, 0, 200, 3, 363, 0, 0, -2, 0,
-	265, 274, 275, 273, 0, 266, 278, 281, 0, -2,
-	0, 143, 0, -2, 145, 146, 148, 0, 0, 43,
-	234, 142, 0, 365, -2, -2, 357, 363, -2, 0,
-	363, -2, 171, 363, 228, 240, 0, 332, 127, -2,
-	319, 320, 320, 335, 320, 343, 0, 347, 339, 346,
-	341, 0, 333, 128, 0, 209, 5, 0, 366, 154,
-	0, 351, 352, 174, 238, 239, 140, 0, 182, 377,
-	207, 377, 186, 35, 218, 219, 187, 188, 189, 0,
-	0, 377, 0, 191, 241, 243, 5, 245, 0, 5,
-	363, -2, -2, 70, 0, 0, 195, 0, 197, 199,
-	0, 203, 152, 154, 39, 230, 283, 204, 0, 304,
-	305, 279, -2, 0, 363, -2, 0, 0, 25, 99,
-	26, 100, 27, 101, 0, 0, -2, 0, 0, -2,
-	312, 227, 0, 321, 0, 327, 328, 315, 331, 169,
-	217, 0, 0, 5, 214, 156, 5, 5, 0, 220,
-	0, 222, 5, 0, 190, 192, 246, 248, 5, 250,
-	0, 0, 0, 255, 256, 257, 258, 259, 260, 244,
-	5, 0, -2, 194, 309, 0, 5, 72, 5, 232,
-	231, 277, 0, 143, -2, -2, 24, 98, 147, 149,
-	0, 126, 320, 0, 0, 5, 0, 213, 0, 0,
-	184, 0, 223, 185, 249, 5, 251, 0, 261, 252,
-	0, 242, 193, 0, 0, 0, 363, 326, 5, 0,
-	212, 215, 181, 183, 5, 247, 253, 0, 254, 310,
-	0, 196, 198, 237, 210, 5, 377, 262, 0, 211,
-	221, 311,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	133, 134, 135, 136, 55, 0, -2, -2, 0, 212,
+	181, 0, 250, 344, 344, 183, 234, 234, 243, 234,
+	0, 0, 299, 344, 0, 339, 339, 343, 339, 353,
+	361, 333, 366, 337, 0, 351, 0, 357, 0, 0,
+	356, 0, 300, 216, 380, 176, 382, 386, 157, 0,
+	139, 0, 0, 386, 369, 0, 373, 0, 47, 382,
+	0, 43, 182, 247, 147, 186, 344, 5, 0, 35,
+	0, 5, 0, 5, 5, 5, 380, 0, 381, 0,
+	232, 233, -2, -2, 0, 325, 71, 0, 0, 5,
+	0, 216, 0, 58, 46, 249, 48, 154, 155, 157,
+	0, 297, 0, 0, 0, 0, 307, 0, 7, 394,
+	395, 10, 11, 12, 13, 14, 8, 9, 16, 18,
+	161, -2, 0, 0, 17, 23, 99, 29, 31, -2,
+	0, 0, 24, 100, 142, 382, 148, 154, 0, -2,
+	376, -2, 145, -2, 51, 0, 383, 174, 382, 0,
+	0, 0, 147, 0, -2, -2, 109, 110, 111, 112,
+	113, 114, 115, 116, 117, -2, -2, -2, -2, -2,
+	125, 126, 127, 128, 382, 137, 59, 56, 147, 0,
+	0, 344, 0, 5, 0, 0, 0, 138, 0, 382,
+	332, 0, 341, 342, 0, 348, 0, 0, 335, 336,
+	0, 363, 0, 382, 349, 359, 364, 355, 0, 224,
+	4, 177, 0, 141, 388, 387, 159, 0, 178, 368,
+	388, 0, 372, 374, 382, 180, 164, 44, 382, 0,
+	0, 5, 213, 214, 5, 0, 0, 0, 0, 0,
+	0, 0, 396, 0, 36, 147, 0, 0, 0, 5,
+	0, 73, 0, 0, 74, 0, 206, 3, 382, 0,
+	0, -2, 0, 280, 289, 290, 288, 0, 281, 293,
+	296, 304, 0, -2, 0, 0, 0, 147, 0, -2,
+	149, 150, 152, 0, 0, 45, 248, 146, 0, 384,
+	-2, -2, 376, 382, -2, 0, 382, -2, 175, 382,
+	252, 254, 237, 0, 351, 253, 242, 130, -2, 338,
+	339, 339, 354, 339, 362, 0, 366, 358, 365, 360,
+	0, 352, 131, 0, 217, 5, 0, 385, 158, 0,
+	370, 371, 179, 144, 0, 188, 396, 215, 396, 192,
+	37, 226, 227, 193, 194, 195, 0, 0, 396, 0,
+	197, 255, 257, 5, 259, 0, 5, 382, -2, -2,
+	72, 0, 0, 0, 201, 0, 203, 205, 0, 209,
+	156, 158, 41, 244, 298, 212, 0, 321, 322, 294,
+	-2, 0, 30, 143, 382, -2, 0, 0, 26, 102,
+	27, 103, 28, 104, 0, 0, -2, 0, 0, -2,
+	331, 0, 0, 0, 0, 239, 241, 340, 0, 346,
+	347, 334, 350, 173, 225, 0, 0, 5, 222, 160,
+	5, 5, 0, 228, 0, 230, 5, 0, 196, 198,
+	260, 262, 5, 264, 0, 0, 0, 269, 270, 271,
+	272, 273, 274, 258, 5, 0, -2, 200, 326, 0,
+	0, 5, 75, 5, 246, 245, 292, 0, 147, -2,
+	-2, 25, 101, 151, 153, 0, 129, 235, 238, 236,
+	0, 339, 0, 0, 5, 0, 221, 0, 0, 190,
+	0, 231, 191, 263, 5, 265, 0, 275, 266, 0,
+	256, 199, 0, 329, 0, 0, 0, 382, 240, 345,
+	5, 0, 220, 223, 187, 189, 5, 261, 267, 0,
+	268, 327, 0, 0, 202, 204, 251, 218, 5, 396,
+	276, 0, 330, 219, 229, 328,
 }
 
 var yyTok1 = [...]int8{
 	1,
 }
 
-var yyTok2 = [...]int8{
+var yyTok2 = [...]uint8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
@@ -1566,7 +1625,7 @@ var yyTok2 = [...]int8{
 	92, 93, 94, 95, 96, 97, 98, 99, 100, 101,
 	102, 103, 104, 105, 106, 107, 108, 109, 110, 111,
 	112, 113, 114, 115, 116, 117, 118, 119, 120, 121,
-	122, 123, 124, 125, 126,
+	122, 123, 124, 125, 126, 127, 128, 129, 130,
 }
 
 var yyTok3 = [...]int8{
@@ -1998,52 +2057,57 @@ yydefault:
 		{
 			yyVAL.node = &WhileNode{Condition: &NotExpressionNode{Arg: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}, Body: Statements{yyDollar[1].node}, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 15:
+	case 14:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
-			yyVAL.node = &AssignmentNode{Left: yyDollar[1].node_list, Right: []Node{yyDollar[3].node}, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+			yyVAL.node = &RescueModNode{Expr: yyDollar[1].node, Fallback: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
 	case 16:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
-			yyVAL.node = &AssignmentNode{Left: []Node{yyDollar[1].node}, Right: yyDollar[3].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+			yyVAL.node = &AssignmentNode{Left: yyDollar[1].node_list, Right: []Node{yyDollar[3].node}, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
 	case 17:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
-			yyVAL.node = &AssignmentNode{Left: yyDollar[1].node_list, Right: yyDollar[3].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+			yyVAL.node = &AssignmentNode{Left: []Node{yyDollar[1].node}, Right: yyDollar[3].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
 	case 18:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.node = &AssignmentNode{Left: yyDollar[1].node_list, Right: yyDollar[3].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+		}
+	case 19:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			root(yylex).inPrivateMethods = true
 			yyVAL.node = &NoopNode{}
 		}
-	case 19:
+	case 20:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			// private :method_name — make specific method private (ignored for now)
 			yyVAL.node = &NoopNode{}
 		}
-	case 20:
+	case 21:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			// private :method_name or private def ... — ignored for now
 			yyVAL.node = &NoopNode{}
 		}
-	case 22:
+	case 23:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 
 			yyVAL.node = &AssignmentNode{Left: []Node{yyDollar[1].node}, Right: []Node{yyDollar[3].node}, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 23:
+	case 24:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			operation := &InfixExpressionNode{Left: yyDollar[1].node, Operator: strings.Trim(yyDollar[2].str, "="), Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			yyVAL.node = &AssignmentNode{Left: []Node{yyDollar[1].node}, Right: []Node{operation}, OpAssignment: true, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 24:
+	case 25:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			access := &BracketAccessNode{Composite: yyDollar[1].node, Args: yyDollar[3].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
@@ -2055,7 +2119,7 @@ yydefault:
 			}
 			yyVAL.node = &AssignmentNode{Left: []Node{assignment}, Right: []Node{operation}, OpAssignment: true, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 25:
+	case 26:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			call := &MethodCall{Receiver: yyDollar[1].node, MethodName: yyDollar[3].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
@@ -2063,51 +2127,56 @@ yydefault:
 			assignment := &MethodCall{Receiver: yyDollar[1].node, MethodName: yyDollar[3].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			yyVAL.node = &AssignmentNode{Left: []Node{assignment}, Right: []Node{operation}, OpAssignment: true, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 26:
+	case 27:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			noop := &NoopNode{Pos{currentLineNo, currentFile}}
 			root(yylex).AddError(NewParseError(&NoopNode{Pos{currentLineNo, currentFile}}, "Tried to modify constant '%s'. In Ruby this only warns, but thanos forbids it.", yyDollar[3].str).Terminal())
 			yyVAL.node = noop
 		}
-	case 27:
+	case 28:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			noop := &NoopNode{Pos{currentLineNo, currentFile}}
 			root(yylex).AddError(NewParseError(&NoopNode{Pos{currentLineNo, currentFile}}, "Tried to modify constant '%s'. In Ruby this only warns, but thanos forbids it.", yyDollar[3].str).Terminal())
 			yyVAL.node = noop
 		}
-	case 31:
+	case 30:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.node = &RescueModNode{Expr: yyDollar[1].node, Fallback: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+		}
+	case 33:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = &NotExpressionNode{Arg: yyDollar[2].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 34:
+	case 36:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yylex.(*Lexer).cond.Push(true)
 		}
-	case 35:
+	case 37:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yylex.(*Lexer).cond.Pop()
 			yyVAL.node = yyDollar[2].node
 		}
-	case 39:
+	case 41:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			call := &MethodCall{Receiver: yyDollar[1].node, MethodName: yyDollar[3].str, Args: yyDollar[4].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			root(yylex).AddCall(call)
 			yyVAL.node = call
 		}
-	case 41:
+	case 43:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			call := &MethodCall{MethodName: yyDollar[1].str, Args: yyDollar[2].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			root(yylex).AddCall(call)
 			yyVAL.node = call
 		}
-	case 42:
+	case 44:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			call := &MethodCall{MethodName: yyDollar[1].str, Args: yyDollar[2].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
@@ -2115,62 +2184,69 @@ yydefault:
 			root(yylex).AddCall(call)
 			yyVAL.node = call
 		}
-	case 44:
+	case 45:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			call := &MethodCall{Receiver: yyDollar[1].node, MethodName: yyDollar[3].str, Args: yyDollar[4].args, Op: yyDollar[2].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+			root(yylex).AddCall(call)
+			yyVAL.node = call
+		}
+	case 46:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = &SuperNode{Args: yyDollar[2].args, Method: root(yylex).currentMethod, Class: root(yylex).currentClass, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 45:
+	case 47:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			root(yylex).currentMethod.AddParam(&Param{Name: "blk", Kind: ExplicitBlock})
 			yyVAL.node = &MethodCall{Receiver: &IdentNode{Val: "blk"}, MethodName: "call", Args: yyDollar[2].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 46:
+	case 48:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			r := &ReturnNode{Val: yyDollar[2].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			root(yylex).AddReturn(r)
 			yyVAL.node = r
 		}
-	case 47:
+	case 49:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			r := &ReturnNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			root(yylex).AddReturn(r)
 			yyVAL.node = r
 		}
-	case 49:
+	case 51:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node_list = yyDollar[2].node_list
 		}
-	case 51:
+	case 53:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node_list = yyDollar[2].node_list
 		}
-	case 53:
+	case 55:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node_list = append(yyDollar[1].node_list, yyDollar[2].node)
 		}
-	case 54:
+	case 56:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node_list = append(yyDollar[1].node_list, &SplatNode{Arg: yyDollar[3].node})
 		}
-	case 56:
+	case 58:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node_list = []Node{yyDollar[1].node}
 		}
-	case 57:
+	case 59:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node_list = append(yyDollar[1].node_list, yyDollar[2].node)
 		}
-	case 60:
+	case 62:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = &BracketAssignmentNode{
@@ -2179,13 +2255,13 @@ yydefault:
 				Pos:       Pos{lineNo: currentLineNo, file: currentFile},
 			}
 		}
-	case 61:
+	case 63:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			call := &MethodCall{Receiver: yyDollar[1].node, MethodName: yyDollar[3].str, Op: yyDollar[2].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			yyVAL.node = call
 		}
-	case 64:
+	case 66:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = &BracketAssignmentNode{
@@ -2194,30 +2270,30 @@ yydefault:
 				Pos:       Pos{lineNo: currentLineNo, file: currentFile},
 			}
 		}
-	case 65:
+	case 67:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			call := &MethodCall{Receiver: yyDollar[1].node, MethodName: yyDollar[3].str, Op: yyDollar[2].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			yyVAL.node = call
 		}
-	case 66:
+	case 68:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &ConstantNode{Val: yyDollar[3].str, Namespace: scopeAccessPrefix(yyDollar[1].node), Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 67:
+	case 69:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			root(yylex).nextConstantType = CLASS
 			yyVAL.str = yyDollar[1].str
 		}
-	case 68:
+	case 70:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			root(yylex).nextConstantType = MODULE
 			yyVAL.str = yyDollar[1].str
 		}
-	case 69:
+	case 71:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			r := root(yylex)
@@ -2229,7 +2305,7 @@ yydefault:
 			r.cpathDepth = 0
 			yyVAL.str = yyDollar[1].str
 		}
-	case 70:
+	case 72:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			// For class/module A::B::C, each :: segment converts the previous head to
@@ -2248,35 +2324,50 @@ yydefault:
 			}
 			yyVAL.str = yyDollar[1].str + "::" + yyDollar[3].str
 		}
-	case 71:
+	case 73:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			// class ::Foo / module ::Foo define Foo in the root namespace even when
+			// lexically nested inside another module.
+			r := root(yylex)
+			r.rootCpath = true
+			if r.nextConstantType == MODULE {
+				r.PushModule(yyDollar[2].str, currentLineNo)
+			} else {
+				r.PushClass(yyDollar[2].str, currentLineNo)
+			}
+			r.cpathDepth = 0
+			yyVAL.str = "::" + yyDollar[2].str
+		}
+	case 74:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			root(yylex).PushSingletonTarget(yyDollar[1].str)
 			yyVAL.str = yyDollar[1].str
 		}
-	case 72:
+	case 75:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			root(yylex).PushSingletonTarget(yyDollar[3].str)
 			yyVAL.str = yyDollar[1].str + "::" + yyDollar[3].str
 		}
-	case 73:
+	case 76:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.str = yyDollar[1].str
 		}
-	case 96:
+	case 99:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &AssignmentNode{Left: []Node{yyDollar[1].node}, Right: []Node{yyDollar[3].node}, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 97:
+	case 100:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			operation := &InfixExpressionNode{Left: yyDollar[1].node, Operator: strings.Trim(yyDollar[2].str, "="), Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			yyVAL.node = &AssignmentNode{Left: []Node{yyDollar[1].node}, Right: []Node{operation}, OpAssignment: true, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 98:
+	case 101:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			access := &BracketAccessNode{Composite: yyDollar[1].node, Args: yyDollar[3].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
@@ -2288,7 +2379,7 @@ yydefault:
 			}
 			yyVAL.node = &AssignmentNode{Left: []Node{assignment}, Right: []Node{operation}, OpAssignment: true, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 99:
+	case 102:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			call := &MethodCall{Receiver: yyDollar[1].node, MethodName: yyDollar[3].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
@@ -2296,136 +2387,136 @@ yydefault:
 			assignment := &MethodCall{Receiver: yyDollar[1].node, MethodName: yyDollar[3].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			yyVAL.node = &AssignmentNode{Left: []Node{assignment}, Right: []Node{operation}, OpAssignment: true, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 100:
+	case 103:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			noop := &NoopNode{Pos{currentLineNo, currentFile}}
 			root(yylex).AddError(NewParseError(&NoopNode{Pos{currentLineNo, currentFile}}, "Tried to modify constant '%s'. In Ruby this only warns, but thanos forbids it.", yyDollar[3].str).Terminal())
 			yyVAL.node = noop
 		}
-	case 101:
+	case 104:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			noop := &NoopNode{Pos{currentLineNo, currentFile}}
 			root(yylex).AddError(NewParseError(&NoopNode{Pos{currentLineNo, currentFile}}, "Tried to modify constant '%s'. In Ruby this only warns, but thanos forbids it.", yyDollar[3].str).Terminal())
 			yyVAL.node = noop
 		}
-	case 102:
+	case 105:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &RangeNode{Lower: yyDollar[1].node, Upper: yyDollar[3].node, Inclusive: true, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 103:
+	case 106:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &RangeNode{Lower: yyDollar[1].node, Upper: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 104:
+	case 107:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = &RangeNode{Lower: yyDollar[1].node, Inclusive: true, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 105:
+	case 108:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = &RangeNode{Lower: yyDollar[1].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 106:
+	case 109:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 107:
+	case 110:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 108:
+	case 111:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 109:
+	case 112:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 110:
+	case 113:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 111:
+	case 114:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 112:
+	case 115:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 113:
+	case 116:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 114:
+	case 117:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 115:
+	case 118:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 117:
+	case 120:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 118:
+	case 121:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 119:
+	case 122:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 120:
+	case 123:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 121:
+	case 124:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = &NotExpressionNode{Arg: yyDollar[2].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 122:
+	case 125:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 123:
+	case 126:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 124:
+	case 127:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 125:
+	case 128:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 126:
+	case 129:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.node = &Condition{
@@ -2438,7 +2529,7 @@ yydefault:
 				Pos: Pos{lineNo: currentLineNo, file: currentFile},
 			}
 		}
-	case 127:
+	case 130:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			for _, p := range yyDollar[2].params {
@@ -2450,7 +2541,7 @@ yydefault:
 			root(yylex).AddMethod(yyDollar[1].meth)
 			yyVAL.node = yyDollar[1].meth
 		}
-	case 128:
+	case 131:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			for _, p := range yyDollar[2].params {
@@ -2462,74 +2553,79 @@ yydefault:
 			root(yylex).AddMethod(yyDollar[1].meth)
 			yyVAL.node = yyDollar[1].meth
 		}
-	case 134:
+	case 137:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 135:
+	case 138:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &InfixExpressionNode{Left: yyDollar[1].node, Operator: yyDollar[2].str, Right: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 137:
+	case 140:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.args = nil
 		}
-	case 138:
+	case 141:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.args = yyDollar[1].args
 		}
-	case 140:
+	case 143:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.node = &RescueModNode{Expr: yyDollar[1].node, Fallback: yyDollar[3].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+		}
+	case 144:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.args = yyDollar[2].args
 		}
-	case 141:
+	case 145:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.args = ArgsNode{}
 		}
-	case 143:
+	case 147:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.args = ArgsNode{}
 		}
-	case 146:
+	case 150:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.args = ArgsNode{&SymbolToProcNode{MethodName: strings.TrimPrefix(yyDollar[2].str, ":"), Pos: Pos{lineNo: currentLineNo, file: currentFile}}}
 		}
-	case 147:
+	case 151:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.args = append(yyDollar[1].args, &SymbolToProcNode{MethodName: strings.TrimPrefix(yyDollar[4].str, ":"), Pos: Pos{lineNo: currentLineNo, file: currentFile}})
 		}
-	case 148:
+	case 152:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.args = ArgsNode{&BlockPassNode{Name: yyDollar[2].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}}
 		}
-	case 149:
+	case 153:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.args = append(yyDollar[1].args, &BlockPassNode{Name: yyDollar[4].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}})
 		}
-	case 150:
+	case 154:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.args = yyDollar[1].args
 		}
-	case 151:
+	case 155:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			for _, kv := range yyDollar[1].kvs {
 				yyVAL.args = append(yyVAL.args, kv)
 			}
 		}
-	case 152:
+	case 156:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			for _, kv := range yyDollar[3].kvs {
@@ -2537,32 +2633,32 @@ yydefault:
 			}
 			yyVAL.args = yyDollar[1].args
 		}
-	case 153:
+	case 157:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.args = ArgsNode{yyDollar[1].node}
 		}
-	case 154:
+	case 158:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.args = append(yyDollar[1].args, yyDollar[3].node)
 		}
-	case 155:
+	case 159:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.args = ArgsNode{&SplatNode{Arg: yyDollar[2].node}}
 		}
-	case 156:
+	case 160:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.args = append(yyDollar[1].args, &SplatNode{Arg: yyDollar[4].node})
 		}
-	case 158:
+	case 162:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.args = []Node{yyDollar[1].node}
 		}
-	case 159:
+	case 163:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			if yyrcvr.Lookahead() == LBRACKETSTART || yyrcvr.Lookahead() == LPARENSTART {
@@ -2573,90 +2669,109 @@ yydefault:
 				yylex.(*Lexer).cmdArg.Push(true)
 			}
 		}
-	case 160:
+	case 164:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yylex.(*Lexer).cmdArg.Pop()
 			yylex.(*Lexer).cmdArg.Pop()
 			yyVAL.args = yyDollar[2].args
 		}
-	case 161:
+	case 165:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.args = append(yyDollar[1].args, yyDollar[3].node)
 		}
-	case 162:
+	case 166:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.args = append(yyDollar[1].args, &SplatNode{Arg: yyDollar[4].node})
 		}
-	case 163:
+	case 167:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.args = ArgsNode{yyDollar[2].node}
 		}
-	case 167:
+	case 171:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = yyDollar[1].node
 		}
-	case 168:
+	case 172:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = yyDollar[1].node
 		}
-	case 169:
+	case 173:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			yyVAL.node = &BeginNode{Body: yyDollar[2].node_list, RescueClauses: yyDollar[3].rescue_clauses, EnsureBody: yyDollar[4].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 170:
+	case 174:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = yyDollar[2].node
 		}
-	case 171:
+	case 175:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &ScopeAccessNode{Receiver: yyDollar[1].node, Constant: yyDollar[3].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 172:
+	case 176:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.node = &ConstantNode{Val: yyDollar[2].str, TopLevel: true, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+		}
+	case 177:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &ArrayNode{Args: yyDollar[2].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 173:
+	case 178:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &HashNode{Pairs: yyDollar[2].kvs, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 174:
+	case 179:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			// this is naive, as in theory the source could have non-block locals called "blk".
 			root(yylex).currentMethod.AddParam(&Param{Name: "blk", Kind: ExplicitBlock})
 			yyVAL.node = &MethodCall{Receiver: &IdentNode{Val: "blk"}, MethodName: "call", Args: yyDollar[3].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 175:
+	case 180:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			root(yylex).currentMethod.AddParam(&Param{Name: "blk", Kind: ExplicitBlock})
 			yyVAL.node = &MethodCall{Receiver: &IdentNode{Val: "blk"}, MethodName: "call", Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 176:
+	case 181:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			root(yylex).currentMethod.AddParam(&Param{Name: "blk", Kind: ExplicitBlock})
 			yyVAL.node = &MethodCall{Receiver: &IdentNode{Val: "blk"}, MethodName: "call", Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 177:
+	case 182:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			call := &MethodCall{MethodName: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			call.SetBlock(yyDollar[2].blk)
 			yyVAL.node = call
 		}
-	case 178:
+	case 183:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			// Bare method call with a block and no args: at_exit { ... }. Without
+			// this, IDENT would reduce to a local variable before the block.
+			call := &MethodCall{MethodName: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+			call.SetBlock(yyDollar[2].blk)
+			if root(yylex).currentClass != nil {
+				root(yylex).currentClass.MethodSet.AddCall(call)
+			} else {
+				root(yylex).AddCall(call)
+			}
+			yyVAL.node = call
+		}
+	case 184:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			// Bare predicate/bang method call with no args: get?, empty?, save!
@@ -2672,7 +2787,7 @@ yydefault:
 			}
 			yyVAL.node = call
 		}
-	case 180:
+	case 186:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			call := yyDollar[1].node.(*MethodCall)
@@ -2684,7 +2799,7 @@ yydefault:
 			}
 			yyVAL.node = call
 		}
-	case 181:
+	case 187:
 		yyDollar = yyS[yypt-7 : yypt+1]
 		{
 			blk := &Block{Body: &Body{Statements: yyDollar[6].node_list}, ParamList: NewParamList()}
@@ -2693,13 +2808,13 @@ yydefault:
 			}
 			yyVAL.node = &LambdaNode{Block: blk, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 182:
+	case 188:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			blk := &Block{Body: &Body{Statements: yyDollar[3].node_list}, ParamList: NewParamList()}
 			yyVAL.node = &LambdaNode{Block: blk, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 183:
+	case 189:
 		yyDollar = yyS[yypt-7 : yypt+1]
 		{
 			blk := &Block{Body: &Body{Statements: yyDollar[6].node_list}, ParamList: NewParamList()}
@@ -2708,47 +2823,47 @@ yydefault:
 			}
 			yyVAL.node = &LambdaNode{Block: blk, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 184:
+	case 190:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.node = &Condition{Condition: yyDollar[2].node, True: yyDollar[4].node_list, False: yyDollar[5].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 185:
+	case 191:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.node = &Condition{Condition: &NotExpressionNode{Arg: yyDollar[2].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}, True: yyDollar[4].node_list, False: yyDollar[5].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 186:
+	case 192:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = &WhileNode{Condition: yyDollar[2].node, Body: yyDollar[3].node_list, Pos: Pos{lineNo: yyDollar[2].node.LineNo(), file: currentFile}}
 		}
-	case 187:
+	case 193:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = &WhileNode{Condition: &NotExpressionNode{Arg: yyDollar[2].node, Pos: Pos{lineNo: yyDollar[2].node.LineNo(), file: currentFile}}, Body: yyDollar[3].node_list, Pos: Pos{lineNo: yyDollar[2].node.LineNo(), file: currentFile}}
 		}
-	case 188:
+	case 194:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = &WhileNode{Condition: &BooleanNode{Val: "true", Pos: Pos{lineNo: currentLineNo, file: currentFile}}, Body: yyDollar[3].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 189:
+	case 195:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = &WhileNode{Condition: &BooleanNode{Val: "true", Pos: Pos{lineNo: currentLineNo, file: currentFile}}, Body: yyDollar[3].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 190:
+	case 196:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			yyVAL.node = &CaseNode{Value: yyDollar[2].node, Whens: yyDollar[4].whens, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 191:
+	case 197:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = &CaseNode{Whens: yyDollar[3].whens, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 192:
+	case 198:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			pm := &PatternMatchNode{Value: yyDollar[2].node, InClauses: yyDollar[4].in_clauses, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
@@ -2759,12 +2874,12 @@ yydefault:
 			}
 			yyVAL.node = pm
 		}
-	case 193:
+	case 199:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.node = &ForInNode{For: yyDollar[2].node_list, In: yyDollar[4].node, Body: yyDollar[5].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 194:
+	case 200:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			r := root(yylex)
@@ -2776,23 +2891,23 @@ yydefault:
 			}
 			r.cpathDepth = 0
 		}
-	case 195:
+	case 201:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			root(yylex).inSingletonClass = true
 		}
-	case 196:
+	case 202:
 		yyDollar = yyS[yypt-7 : yypt+1]
 		{
 			root(yylex).inSingletonClass = false
 			yyVAL.node = &NoopNode{}
 		}
-	case 197:
+	case 203:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			root(yylex).inSingletonClass = true
 		}
-	case 198:
+	case 204:
 		yyDollar = yyS[yypt-7 : yypt+1]
 		{
 			r := root(yylex)
@@ -2800,7 +2915,7 @@ yydefault:
 			r.PopSingletonTarget()
 			yyVAL.node = &NoopNode{}
 		}
-	case 199:
+	case 205:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			r := root(yylex)
@@ -2812,7 +2927,7 @@ yydefault:
 			r.cpathDepth = 0
 			yyVAL.node = module
 		}
-	case 200:
+	case 206:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[1].meth.Body = yyDollar[2].body
@@ -2820,17 +2935,17 @@ yydefault:
 			root(yylex).State.Pop()
 			yyVAL.node = yyDollar[1].meth
 		}
-	case 201:
+	case 207:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &BreakNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 202:
+	case 208:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &NextNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 203:
+	case 209:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			if len(yyDollar[3].args) == 1 {
@@ -2839,92 +2954,132 @@ yydefault:
 				yyVAL.node = &NextNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			}
 		}
-	case 207:
+	case 210:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.node = &RedoNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+		}
+	case 211:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.node = &RetryNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+		}
+	case 215:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.str = yyDollar[2].str
 		}
-	case 208:
+	case 216:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.rescue_clauses = []*RescueClause{}
 		}
-	case 209:
+	case 217:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.rescue_clauses = append(yyDollar[1].rescue_clauses, yyDollar[2].rescue_clause)
 		}
-	case 210:
+	case 218:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			yyVAL.rescue_clause = &RescueClause{ExceptionVar: yyDollar[3].str, Body: yyDollar[5].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 211:
+	case 219:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.rescue_clause = &RescueClause{ExceptionTypes: yyDollar[2].str_list, ExceptionVar: yyDollar[4].str, Body: yyDollar[6].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 212:
+	case 220:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.rescue_clause = &RescueClause{ExceptionTypes: yyDollar[2].str_list, Body: yyDollar[4].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 213:
+	case 221:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.rescue_clause = &RescueClause{Body: yyDollar[3].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 214:
+	case 222:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.str_list = []string{yyDollar[1].str}
 		}
-	case 215:
+	case 223:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.str_list = append(yyDollar[1].str_list, yyDollar[3].str)
 		}
-	case 216:
+	case 224:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.node_list = nil
 		}
-	case 217:
+	case 225:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node_list = yyDollar[2].node_list
 		}
-	case 221:
+	case 229:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			yyVAL.node = &Condition{Condition: yyDollar[2].node, True: yyDollar[4].node_list, False: yyDollar[5].node, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 223:
+	case 231:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = &Condition{True: yyDollar[2].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}, elseBranch: true}
 		}
-	case 224:
+	case 232:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node_list = []Node{yyDollar[1].node}
 		}
-	case 226:
+	case 234:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.params = []*Param{}
 		}
-	case 227:
-		yyDollar = yyS[yypt-3 : yypt+1]
+	case 235:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.params = append(yyDollar[2].params, yyDollar[3].params...)
+		}
+	case 236:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.params = yyDollar[3].params
+		}
+	case 237:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.params = []*Param{}
+		}
+	case 238:
+		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.params = yyDollar[2].params
 		}
-	case 228:
+	case 239:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.params = []*Param{yyDollar[1].param}
+		}
+	case 240:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.params = append(yyDollar[1].params, yyDollar[3].param)
+		}
+	case 241:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.param = &Param{Name: yyDollar[1].str, Kind: BlockLocal}
+		}
+	case 242:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.blk = yyDollar[2].blk
 		}
-	case 229:
+	case 243:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			call := yyDollar[1].node.(*MethodCall)
@@ -2936,14 +3091,14 @@ yydefault:
 			}
 			yyVAL.node = call
 		}
-	case 230:
+	case 244:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			call := &MethodCall{Receiver: yyDollar[1].node, MethodName: yyDollar[3].str, Args: yyDollar[4].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			root(yylex).AddCall(call)
 			yyVAL.node = call
 		}
-	case 231:
+	case 245:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			call := &MethodCall{Receiver: yyDollar[1].node, MethodName: yyDollar[3].str, Args: yyDollar[4].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
@@ -2951,7 +3106,7 @@ yydefault:
 			root(yylex).AddCall(call)
 			yyVAL.node = call
 		}
-	case 232:
+	case 246:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			call := &MethodCall{Receiver: yyDollar[1].node, MethodName: yyDollar[3].str, Args: yyDollar[4].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
@@ -2959,7 +3114,7 @@ yydefault:
 			root(yylex).AddCall(call)
 			yyVAL.node = call
 		}
-	case 233:
+	case 247:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			call := &MethodCall{MethodName: yyDollar[1].str, Args: yyDollar[2].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
@@ -2970,109 +3125,113 @@ yydefault:
 			}
 			yyVAL.node = call
 		}
-	case 234:
+	case 248:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			call := &MethodCall{Receiver: yyDollar[1].node, MethodName: yyDollar[3].str, Args: yyDollar[4].args, Op: yyDollar[2].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			root(yylex).AddCall(call)
 			yyVAL.node = call
 		}
-	case 235:
+	case 249:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = &SuperNode{Args: yyDollar[2].args, Method: root(yylex).currentMethod, Class: root(yylex).currentClass, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 236:
+	case 250:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &SuperNode{Method: root(yylex).currentMethod, Class: root(yylex).currentClass, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 237:
+	case 251:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = &BracketAccessNode{Composite: yyDollar[1].node, Args: yyDollar[3].args, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 238:
+	case 252:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.blk = yyDollar[2].blk
 		}
-	case 239:
+	case 253:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.blk = yyDollar[2].blk
 		}
-	case 240:
+	case 254:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			blk := &Block{Body: &Body{Statements: yyDollar[2].node_list}, ParamList: NewParamList()}
 			for _, p := range yyDollar[1].params {
-				blk.AddParam(p)
+				if p.Kind == BlockLocal {
+					blk.AddLocal(p)
+				} else {
+					blk.AddParam(p)
+				}
 			}
 			synthesizeNumberedParams(blk)
 			yyVAL.blk = blk
 		}
-	case 241:
+	case 255:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.whens = append([]*WhenNode{yyDollar[1].when}, yyDollar[2].whens...)
 		}
-	case 242:
+	case 256:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.when = &WhenNode{Conditions: yyDollar[2].args, Statements: yyDollar[4].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 243:
+	case 257:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.whens = []*WhenNode{}
 		}
-	case 244:
+	case 258:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.whens = []*WhenNode{{Statements: yyDollar[2].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}}
 		}
-	case 246:
+	case 260:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.in_clauses = append([]*InClause{yyDollar[1].in_clause}, yyDollar[2].in_clauses...)
 		}
-	case 247:
+	case 261:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.in_clause = &InClause{Pattern: yyDollar[2].node, Statements: yyDollar[4].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 248:
+	case 262:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.in_clauses = []*InClause{}
 		}
-	case 249:
+	case 263:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.in_clauses = []*InClause{{Statements: yyDollar[2].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}}
 		}
-	case 251:
+	case 265:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = &ArrayPatternNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 252:
+	case 266:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = &ArrayPatternNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 253:
+	case 267:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &ArrayPatternNode{Elements: yyDollar[2].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 254:
+	case 268:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &ArrayPatternNode{Elements: yyDollar[2].node_list, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 256:
+	case 270:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if yyDollar[1].str == "_" {
@@ -3081,121 +3240,121 @@ yydefault:
 				yyVAL.node = &IdentNode{Val: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 			}
 		}
-	case 258:
+	case 272:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &NilNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 259:
+	case 273:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &BooleanNode{Val: "true", Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 260:
+	case 274:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &BooleanNode{Val: "false", Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 261:
+	case 275:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node_list = Statements{yyDollar[1].node}
 		}
-	case 262:
+	case 276:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node_list = append(yyDollar[1].node_list, yyDollar[3].node)
 		}
-	case 265:
+	case 280:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			str := root(yylex).StringStack.Pop()
 			str.delim = yyDollar[3].str
 			yyVAL.node = str
 		}
-	case 266:
+	case 281:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = &StringNode{BodySegments: []string{yyDollar[2].str}, Kind: getStringKind(yyDollar[1].str), Pos: Pos{lineNo: currentLineNo, file: currentFile}, delim: yyDollar[3].str}
 		}
-	case 270:
+	case 285:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			root(yylex).State.Push(InString)
 			root(yylex).StringStack.Push(&StringNode{Kind: getStringKind(yyDollar[1].str), Interps: make(map[int][]Node), Pos: Pos{lineNo: currentLineNo, file: currentFile}})
 			yyVAL.str = ""
 		}
-	case 271:
+	case 286:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			root(yylex).State.Push(InString)
 			root(yylex).StringStack.Push(&StringNode{Kind: getStringKind(yyDollar[1].str), Interps: make(map[int][]Node), Pos: Pos{lineNo: currentLineNo, file: currentFile}})
 			yyVAL.str = ""
 		}
-	case 272:
+	case 287:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			root(yylex).State.Push(InString)
 			root(yylex).StringStack.Push(&StringNode{Kind: getStringKind(yyDollar[1].str), Interps: make(map[int][]Node), Pos: Pos{lineNo: currentLineNo, file: currentFile}})
 			yyVAL.str = ""
 		}
-	case 273:
+	case 288:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			root(yylex).State.Pop()
 			yyVAL.str = yyDollar[1].str
 		}
-	case 274:
+	case 289:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			curr := root(yylex).StringStack.Peek()
 			curr.BodySegments = append(curr.BodySegments, yyDollar[2].str)
 			yyVAL.str = ""
 		}
-	case 275:
+	case 290:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.str = ""
 		}
-	case 276:
+	case 291:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.str = ""
 		}
-	case 277:
+	case 292:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			curr := root(yylex).StringStack.Peek()
 			curr.Interps[len(curr.BodySegments)] = append(curr.Interps[len(curr.BodySegments)], yyDollar[2].node)
 			yyVAL.str = ""
 		}
-	case 278:
+	case 293:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			regexp := root(yylex).StringStack.Pop()
 			yyVAL.node = regexp
 		}
-	case 279:
+	case 294:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			regexp := root(yylex).StringStack.Pop()
 			regexp.Flags = yyDollar[4].str
 			yyVAL.node = regexp
 		}
-	case 280:
+	case 295:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			root(yylex).State.Push(InString)
 			root(yylex).StringStack.Push(&StringNode{Kind: Regexp, Interps: make(map[int][]Node), Pos: Pos{lineNo: currentLineNo, file: currentFile}})
 			yyVAL.str = ""
 		}
-	case 281:
+	case 296:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			root(yylex).State.Pop()
 			yyVAL.str = ""
 		}
-	case 282:
+	case 297:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			method := NewMethod(yyDollar[2].str, root(yylex))
@@ -3206,7 +3365,7 @@ yydefault:
 			method.Pos = Pos{lineNo: currentLineNo, file: currentFile}
 			yyVAL.meth = method
 		}
-	case 283:
+	case 298:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			method := NewMethod(yyDollar[4].str, root(yylex))
@@ -3214,7 +3373,7 @@ yydefault:
 			method.Pos = Pos{lineNo: currentLineNo, file: currentFile}
 			yyVAL.meth = method
 		}
-	case 284:
+	case 299:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			for _, p := range yyDollar[2].params {
@@ -3226,7 +3385,7 @@ yydefault:
 			yyVAL.meth = yyDollar[1].meth
 			yylex.(*Lexer).resetExpr = true
 		}
-	case 285:
+	case 300:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			for _, p := range yyDollar[2].params {
@@ -3238,22 +3397,36 @@ yydefault:
 			yyVAL.meth = yyDollar[1].meth
 			yylex.(*Lexer).resetExpr = true
 		}
-	case 286:
+	case 301:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.params = nil
 		}
-	case 287:
+	case 302:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.params = yyDollar[2].params
 		}
-	case 288:
+	case 303:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &SymbolNode{Val: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 290:
+	case 304:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			sym := root(yylex).StringStack.Pop()
+			sym.delim = yyDollar[3].str
+			yyVAL.node = sym
+		}
+	case 305:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			root(yylex).State.Push(InString)
+			root(yylex).StringStack.Push(&StringNode{Kind: getStringKind(yyDollar[1].str), Interps: make(map[int][]Node), Pos: Pos{lineNo: currentLineNo, file: currentFile}})
+			yyVAL.str = ""
+		}
+	case 307:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			var negative Node
@@ -3267,32 +3440,32 @@ yydefault:
 			}
 			yyVAL.node = negative
 		}
-	case 291:
+	case 308:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &IntNode{Val: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 292:
+	case 309:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &Float64Node{Val: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 293:
+	case 310:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &RationalNode{Val: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 294:
+	case 311:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &ImaginaryNode{Val: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 295:
+	case 312:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &IdentNode{Val: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 296:
+	case 313:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			ivar := &IVarNode{Val: yyDollar[1].str, Class: root(yylex).currentClass, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
@@ -3302,290 +3475,300 @@ yydefault:
 				cls.AddIVar(ivar.NormalizedVal(), &IVar{Name: ivar.NormalizedVal()})
 			}
 		}
-	case 297:
+	case 314:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &GVarNode{Val: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 298:
+	case 315:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &ConstantNode{Val: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 299:
+	case 316:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &CVarNode{Val: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 300:
+	case 317:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &NilNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 301:
+	case 318:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &SelfNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 302:
+	case 319:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &BooleanNode{Val: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 303:
+	case 320:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = &BooleanNode{Val: yyDollar[1].str, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
 		}
-	case 308:
+	case 325:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.str = ""
 		}
-	case 309:
+	case 326:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.str = yyDollar[2].str
 		}
-	case 310:
+	case 327:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			yyVAL.str = yyDollar[4].str
 		}
-	case 311:
+	case 328:
 		yyDollar = yyS[yypt-7 : yypt+1]
 		{
 			yyVAL.str = yyDollar[6].str
 		}
-	case 312:
+	case 329:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.str = yyDollar[3].str
+		}
+	case 330:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			yyVAL.str = yyDollar[5].str
+		}
+	case 331:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.params = yyDollar[2].params
 		}
-	case 313:
+	case 332:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.params = yyDollar[1].params
 		}
-	case 315:
+	case 334:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.params = append(append(yyDollar[1].params, yyDollar[3].param), yyDollar[4].params...)
 		}
-	case 316:
+	case 335:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.params = append(yyDollar[1].params, yyDollar[2].params...)
 		}
-	case 317:
+	case 336:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.params = append([]*Param{yyDollar[1].param}, yyDollar[2].params...)
 		}
-	case 318:
+	case 337:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.params = []*Param{yyDollar[1].param}
 		}
-	case 319:
+	case 338:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.params = yyDollar[2].params
 		}
-	case 320:
+	case 339:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.params = []*Param{}
 		}
-	case 321:
+	case 340:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.params = append(append(yyDollar[1].params, yyDollar[3].params...), yyDollar[4].params...)
 		}
-	case 322:
+	case 341:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.params = append(yyDollar[1].params, yyDollar[2].params...)
 		}
-	case 323:
+	case 342:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.params = append(yyDollar[1].params, yyDollar[2].params...)
 		}
-	case 324:
+	case 343:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.params = yyDollar[1].params
 		}
-	case 325:
+	case 344:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.params = []*Param{}
 		}
-	case 326:
+	case 345:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.params = append(append(append(yyDollar[1].params, yyDollar[3].params...), yyDollar[5].param), yyDollar[6].params...)
 		}
-	case 327:
+	case 346:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.params = append(append(yyDollar[1].params, yyDollar[3].param), yyDollar[4].params...)
 		}
-	case 328:
+	case 347:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.params = append(append(yyDollar[1].params, yyDollar[3].param), yyDollar[4].params...)
 		}
-	case 329:
+	case 348:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.params = append([]*Param{yyDollar[1].param}, yyDollar[2].params...)
 		}
-	case 330:
+	case 349:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.params = []*Param{{Name: yyDollar[1].str, Kind: Positional}}
 		}
-	case 331:
+	case 350:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.params = append(yyDollar[1].params, &Param{Name: yyDollar[3].str, Kind: Positional})
 		}
-	case 332:
+	case 351:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.param = &Param{Name: yyDollar[1].str, Kind: Positional}
 		}
-	case 333:
+	case 352:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.param = &Param{Kind: Destructured, Nested: yyDollar[2].params}
 		}
-	case 334:
+	case 353:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.params = []*Param{yyDollar[1].param}
 		}
-	case 335:
+	case 354:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.params = append(yyDollar[1].params, yyDollar[3].param)
 		}
-	case 336:
+	case 355:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.param = &Param{Name: strings.Trim(yyDollar[1].str, ":"), Default: yyDollar[2].node, Kind: Keyword}
 		}
-	case 337:
+	case 356:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.param = &Param{Name: strings.Trim(yyDollar[1].str, ":"), Kind: Keyword}
 		}
-	case 338:
+	case 357:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.params = []*Param{yyDollar[1].param}
 		}
-	case 339:
+	case 358:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.params = append(yyDollar[1].params, yyDollar[3].param)
 		}
-	case 340:
+	case 359:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.param = &Param{Name: yyDollar[2].str, Kind: DoubleSplat}
 		}
-	case 341:
+	case 360:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.param = &Param{Name: yyDollar[1].str, Default: yyDollar[3].node, Kind: Named}
 		}
-	case 342:
+	case 361:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.params = []*Param{yyDollar[1].param}
 		}
-	case 343:
+	case 362:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.params = append(yyDollar[1].params, yyDollar[3].param)
 		}
-	case 344:
+	case 363:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.param = &Param{Name: yyDollar[2].str, Kind: Splat}
 		}
-	case 345:
+	case 364:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.param = &Param{Name: yyDollar[2].str, Kind: ExplicitBlock}
 		}
-	case 346:
+	case 365:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.params = []*Param{yyDollar[2].param}
 		}
-	case 347:
+	case 366:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.params = []*Param{}
 		}
-	case 348:
+	case 367:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.kvs = []*KeyValuePair{}
 		}
-	case 350:
+	case 369:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.kvs = []*KeyValuePair{yyDollar[1].kv}
 		}
-	case 351:
+	case 370:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.kvs = append(yyDollar[1].kvs, yyDollar[3].kv)
 		}
-	case 352:
+	case 371:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.kv = &KeyValuePair{Key: yyDollar[1].node, Value: yyDollar[3].node}
 		}
-	case 353:
+	case 372:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.kv = &KeyValuePair{Label: strings.TrimRight(yyDollar[1].str, ":"), Value: yyDollar[2].node}
 		}
-	case 354:
+	case 373:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			// Value-omission hash shorthand: {action:} means {action: action}
 			name := strings.TrimRight(yyDollar[1].str, ":")
 			yyVAL.kv = &KeyValuePair{Label: name, Value: &IdentNode{Val: name, Pos: Pos{lineNo: currentLineNo, file: currentFile}}}
 		}
-	case 355:
+	case 374:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.kv = &KeyValuePair{Value: yyDollar[2].node, DoubleSplat: true}
 		}
-	case 365:
+	case 384:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.str = yyDollar[2].str
 		}
-	case 366:
+	case 385:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.str = yyDollar[2].str
 		}
-	case 373:
+	case 392:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			root(yylex).AddComment(Comment{Text: strings.TrimSpace(yyDollar[1].str), LineNo: currentLineNo})
 			yyVAL.str = yyDollar[1].str
 		}
-	case 377:
+	case 396:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.node = nil
@@ -37,7 +37,9 @@ func scopeAccessPrefix(node Node) string {
 %}
 
 %nonassoc <str> LOWEST
+%nonassoc <str> IF_MOD UNLESS_MOD WHILE_MOD UNTIL_MOD
 %right <str> ASSIGN MODASSIGN MULASSIGN ADDASSIGN SUBASSIGN DIVASSIGN LSHIFTASSIGN RSHIFTASSIGN ORASSIGN
+%left <str> RESCUE_MOD
 %right <str>  QMARK COLON
 %nonassoc <str> DOT2 DOT3 
 %left <str> LOGICALOR
@@ -57,24 +59,24 @@ func scopeAccessPrefix(node Node) string {
 %token <str> FLOAT
 %token <str> RATIONAL IMAGINARY
 %token <str> TRUE FALSE
-%token <str> CLASS MODULE DEF END IF IF_MOD UNLESS UNLESS_MOD BEGIN RESCUE RESCUE_MOD THEN ELSE WHILE WHILE_MOD RETURN YIELD SELF CONSTANT 
-%token <str> ENSURE ELSIF CASE WHEN UNTIL UNTIL_MOD FOR BREAK NEXT SUPER ALIAS DO DO_COND DO_BLOCK PRIVATE PROTECTED IN
+%token <str> CLASS MODULE DEF END IF UNLESS BEGIN RESCUE THEN ELSE WHILE RETURN YIELD SELF CONSTANT 
+%token <str> ENSURE ELSIF CASE WHEN UNTIL FOR BREAK NEXT REDO RETRY SUPER ALIAS DO DO_COND DO_BLOCK PRIVATE PROTECTED IN
 
 %token <str> IVAR CVAR GVAR METHODIDENT IDENT COMMENT LABEL
 
 %token <str> ANDDOT DOT LBRACE LBRACEBLOCK RBRACE NEWLINE COMMA DOUBLESPLAT
-%token <str> STRINGBEG STRINGEND INTERPBEG INTERPEND STRINGBODY REGEXBEG REGEXEND REGEXPOPT RAWSTRINGBEG RAWSTRINGEND WORDSBEG RAWWORDSBEG XSTRINGBEG RAWXSTRINGBEG
+%token <str> STRINGBEG STRINGEND INTERPBEG INTERPEND STRINGBODY REGEXBEG REGEXEND REGEXPOPT RAWSTRINGBEG RAWSTRINGEND WORDSBEG RAWWORDSBEG XSTRINGBEG RAWXSTRINGBEG DSYMBEG
 %token <str> SEMICOLON LBRACKET LBRACKETSTART RBRACKET LPAREN LPARENSTART RPAREN HASHROCKET
-%token <str> SCOPE LAMBDA LOOP
+%token <str> SCOPE SCOPESTART LAMBDA LOOP
 
 
-%type <str> fcall operation rparen op fname then term relop rbracket string_beg string_end string_contents string_interp regex_beg regex_end cpath singleton_cpath op_asgn superclass private do raw_string_beg class module comment call_op
-%type <node> symbol numeric user_variable keyword_variable simple_numeric expr arg primary literal lhs var_ref var_lhs primary_value expr_value command_asgn command_rhs command command_call regexp expr_value_do block_command block_call 
+%type <str> fcall operation rparen op fname then term relop rbracket string_beg dsym_beg string_end string_contents string_interp regex_beg regex_end cpath singleton_cpath op_asgn superclass private do raw_string_beg class module comment call_op
+%type <node> symbol dsym numeric user_variable keyword_variable simple_numeric expr arg primary literal lhs var_ref var_lhs primary_value expr_value command_asgn command_rhs command command_call regexp expr_value_do block_command block_call 
 %type <node> arg_rhs arg_value method_call stmt if_tail opt_else none rel_expr string raw_string mlhs_item mlhs_node 
 %type <node_list> compstmt stmts root mlhs mlhs_basic mlhs_head mlhs_inner for_var
 %type <args> args call_args opt_call_args paren_args opt_paren_args aref_args command_args mrhs mrhs_arg
-%type <param> f_arg_item f_kw f_opt f_block_arg f_rest_arg f_kwrest
-%type <params> f_arglist f_opt_paren_args f_args f_arg opt_block_param f_kwarg opt_args_tail args_tail f_optarg opt_f_block_arg f_marg_list
+%type <param> f_arg_item f_kw f_opt f_block_arg f_rest_arg f_kwrest bvar
+%type <params> f_arglist f_opt_paren_args f_args f_arg opt_block_param opt_bv_decl bv_decls f_kwarg opt_args_tail args_tail f_optarg opt_f_block_arg f_marg_list
 %type <body> bodystmt
 %type <when> when
 %type <whens> case_body cases
@@ -202,7 +204,10 @@ stmt:
   {
     $$ = &WhileNode{Condition: &NotExpressionNode{Arg: $3, Pos: Pos{lineNo: currentLineNo, file: currentFile}}, Body: Statements{$1}, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
   }
-//| stmt RESCUE_MOD stmt
+| stmt RESCUE_MOD stmt
+  {
+    $$ = &RescueModNode{Expr: $1, Fallback: $3, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+  }
 | command_asgn
 | mlhs ASSIGN command_call
   {
@@ -277,7 +282,10 @@ command_asgn:
 
 command_rhs: 
   command_call %prec ASSIGN
-//| command_call kRESCUE_MOD stmt
+| command_call RESCUE_MOD stmt
+  {
+    $$ = &RescueModNode{Expr: $1, Fallback: $3, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+  }
 | command_asgn
 
 expr: 
@@ -331,6 +339,11 @@ command:
     $$ = call
   }
 | primary_value call_op operation command_args %prec LOWEST
+  {
+    call := &MethodCall{Receiver: $1, MethodName: $3, Args: $4, Op: $2, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+    root(yylex).AddCall(call)
+    $$ = call
+  }
 //| primary_value call_op operation2 command_args cmd_brace_block
 | SUPER command_args
   {
@@ -453,7 +466,7 @@ module:
     root(yylex).nextConstantType = MODULE
     $$ = $1
   } 
-cpath: //SCOPE Constant
+cpath:
   CONSTANT
   {
     r := root(yylex)
@@ -483,6 +496,20 @@ cpath: //SCOPE Constant
     }
     $$ = $1 + "::" + $3
   }
+| SCOPESTART CONSTANT
+  {
+    // class ::Foo / module ::Foo define Foo in the root namespace even when
+    // lexically nested inside another module.
+    r := root(yylex)
+    r.rootCpath = true
+    if r.nextConstantType == MODULE {
+      r.PushModule($2, currentLineNo)
+    } else {
+      r.PushClass($2, currentLineNo)
+    }
+    r.cpathDepth = 0
+    $$ = "::" + $2
+  }
 
 singleton_cpath:
   CONSTANT
@@ -713,7 +740,10 @@ aref_args:  // none here in Ruby grammar
 //| assocs trailer
 
 arg_rhs: arg %prec ASSIGN
-//| arg kRESCUE_MOD arg
+| arg RESCUE_MOD arg
+  {
+    $$ = &RescueModNode{Expr: $1, Fallback: $3, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+  }
 
 
 paren_args: 
@@ -864,7 +894,10 @@ primary:
   {
     $$ = &ScopeAccessNode{Receiver: $1, Constant: $3, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
   }
-// | tCOLON3 tCONSTANT
+| SCOPESTART CONSTANT
+  {
+    $$ = &ConstantNode{Val: $2, TopLevel: true, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+  }
 | LBRACKETSTART aref_args rbracket
   {
     $$ = &ArrayNode{Args: $2, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
@@ -895,6 +928,19 @@ primary:
     call.SetBlock($2)
     $$ = call
    }
+| IDENT brace_block
+  {
+    // Bare method call with a block and no args: at_exit { ... }. Without
+    // this, IDENT would reduce to a local variable before the block.
+    call := &MethodCall{MethodName: $1, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+    call.SetBlock($2)
+    if root(yylex).currentClass != nil {
+      root(yylex).currentClass.MethodSet.AddCall(call)
+    } else {
+      root(yylex).AddCall(call)
+    }
+    $$ = call
+  }
 | METHODIDENT
   {
     // Bare predicate/bang method call with no args: get?, empty?, save!
@@ -1055,8 +1101,14 @@ primary:
       $$ = &NextNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
     }
   }
-//| kREDO
-//| kRETRY
+| REDO
+  {
+    $$ = &RedoNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+  }
+| RETRY
+  {
+    $$ = &RetryNode{Pos: Pos{lineNo: currentLineNo, file: currentFile}}
+  }
 
 primary_value: primary
 
@@ -1182,19 +1234,42 @@ opt_block_param:
   {
     $$ = []*Param{}
   }
-| PIPE f_arg PIPE // block_param_def here in ruby
+| PIPE f_arg opt_bv_decl PIPE // block_param_def here in ruby
   {
-    $$ = $2
+    $$ = append($2, $3...)
+  }
+| PIPE SEMICOLON bv_decls PIPE
+  {
+    $$ = $3
   }
 
 //block_param_def: tPIPE opt_bv_decl tPIPE
 //| tOROP
 //| tPIPE block_param opt_bv_decl tPIPE
-//opt_bv_decl: opt_nl
-//| opt_nl tSEMI bv_decls opt_nl
-//bv_decls: bvar
-//| bv_decls tCOMMA bvar
-//bvar: tIDENTIFIER
+opt_bv_decl:
+  {
+    $$ = []*Param{}
+  }
+| SEMICOLON bv_decls
+  {
+    $$ = $2
+  }
+
+bv_decls:
+  bvar
+  {
+    $$ = []*Param{$1}
+  }
+| bv_decls COMMA bvar
+  {
+    $$ = append($1, $3)
+  }
+
+bvar:
+  IDENT
+  {
+    $$ = &Param{Name: $1, Kind: BlockLocal}
+  }
 //| f_bad_arg
 //lambda:   {
 //f_larglist: tLPAREN2 f_args opt_bv_decl tRPAREN
@@ -1286,7 +1361,11 @@ brace_body:
   {
     blk := &Block{Body: &Body{Statements: $2}, ParamList: NewParamList()}
     for _, p := range $1 {
-      blk.AddParam(p)
+      if p.Kind == BlockLocal {
+        blk.AddLocal(p)
+      } else {
+        blk.AddParam(p)
+      }
     }
     synthesizeNumberedParams(blk)
     $$ = blk
@@ -1404,7 +1483,7 @@ p_pattern_list:
 literal: 
   numeric
 | symbol
-//| dsym
+| dsym
 
 string: 
   string_beg string_contents string_end
@@ -1572,7 +1651,22 @@ symbol:
   {
     $$ = &SymbolNode{Val: $1, Pos: Pos{lineNo: currentLineNo, file: currentFile}}
   }
-//dsym: tSYMBEG string_contents tSTRING_END
+
+dsym:
+  dsym_beg string_contents string_end
+  {
+    sym := root(yylex).StringStack.Pop()
+    sym.delim = $3
+    $$ = sym
+  }
+
+dsym_beg:
+  DSYMBEG
+  {
+    root(yylex).State.Push(InString)
+    root(yylex).StringStack.Push(&StringNode{Kind: getStringKind($1), Interps: make(map[int][]Node), Pos: Pos{lineNo: currentLineNo, file: currentFile}})
+    $$ = ""
+  }
 
 
 numeric: 
@@ -1672,6 +1766,14 @@ superclass:
   {
     $$ = $6
   }
+| LT SCOPESTART CONSTANT term
+  {
+    $$ = $3
+  }
+| LT SCOPESTART CONSTANT SCOPE CONSTANT term
+  {
+    $$ = $5
+  }
 
 f_arglist: 
   LPAREN f_args rparen
@@ -17,6 +17,7 @@ type RubyLocal struct {
 	Calls       []*MethodCall
 	Constraints []TypeConstraint
 	isRefinable bool // true if this variable's type can be refined (e.g., empty arrays)
+	placeholder bool // declared by Root.AddCall for a receiver not yet assigned
 }
 
 func (rl *RubyLocal) String() string       { return rl._type.String() }
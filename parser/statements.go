@@ -600,11 +600,11 @@ func (b *Body) InferReturnType(scope ScopeChain, class *Class) error {
 	case *AssignmentNode:
 		var ret *ReturnNode
 		if s.OpAssignment {
-			ret = &ReturnNode{Val: s.Left}
+			ret = &ReturnNode{Val: s.Left, Pos: s.Pos}
 		} else if _, ok := s.Left[0].(*IVarNode); ok {
-			ret = &ReturnNode{Val: s.Left}
+			ret = &ReturnNode{Val: s.Left, Pos: s.Pos}
 		} else {
-			ret = &ReturnNode{Val: []Node{s.Right[0]}}
+			ret = &ReturnNode{Val: []Node{s.Right[0]}, Pos: s.Pos}
 		}
 		if retType, err := GetType(ret, scope, class); err != nil {
 			return err
@@ -615,7 +615,7 @@ func (b *Body) InferReturnType(scope ScopeChain, class *Class) error {
 	default:
 		if scope.Name() != Main {
 			if finalStatement.Type() != types.NilType {
-				ret := &ReturnNode{Val: []Node{finalStatement}}
+				ret := &ReturnNode{Val: []Node{finalStatement}, Pos: Pos{lineNo: finalStatement.LineNo(), file: finalStatement.File()}}
 				if _, err := GetType(ret, scope, class); err != nil {
 					return err
 				}
@@ -623,7 +623,7 @@ func (b *Body) InferReturnType(scope ScopeChain, class *Class) error {
 			} else if len(b.ExplicitReturns) > 0 {
 				// Method has explicit returns and ends with nil — still emit
 				// return nil so the Optional return type is satisfied.
-				ret := &ReturnNode{Val: []Node{finalStatement}}
+				ret := &ReturnNode{Val: []Node{finalStatement}, Pos: Pos{lineNo: finalStatement.LineNo(), file: finalStatement.File()}}
 				ret.SetType(types.NilType)
 				b.Statements[finalStatementIdx] = ret
 			}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/redneckbeard/thanos/stdlib"
 	"github.com/redneckbeard/thanos/types"
@@ -19,6 +20,9 @@ const (
 	RawWords
 	Exec
 	RawExec
+	Symbols
+	RawSymbols
+	DynamicSymbol
 )
 
 func getStringKind(delim string) StringKind {
@@ -31,6 +35,8 @@ func getStringKind(delim string) StringKind {
 		return Regexp
 	case "`":
 		return Exec
+	case `:"`:
+		return DynamicSymbol
 	}
 	kind := delim[1:2]
 	switch kind {
@@ -38,6 +44,10 @@ func getStringKind(delim string) StringKind {
 		return RawWords
 	case "W":
 		return Words
+	case "i":
+		return RawSymbols
+	case "I":
+		return Symbols
 	case "x":
 		return RawExec
 	case "X":
@@ -47,13 +57,16 @@ func getStringKind(delim string) StringKind {
 }
 
 var stringDelims = map[StringKind]string{
-	DoubleQuote: `"`,
-	Words:       `"`,
-	SingleQuote: "'",
-	RawWords:    "'",
-	Regexp:      "/",
-	RawExec:     "`",
-	Exec:        "`",
+	DoubleQuote:   `"`,
+	Words:         `"`,
+	SingleQuote:   "'",
+	RawWords:      "'",
+	Symbols:       `"`,
+	RawSymbols:    "'",
+	DynamicSymbol: `"`,
+	Regexp:        "/",
+	RawExec:       "`",
+	Exec:          "`",
 }
 
 var validEscapes = []rune{'a', 'b', 'f', 'n', 'r', 't', 'v', '\\'}
@@ -93,7 +106,7 @@ func (n *StringNode) GoString() string {
 			pattern = "`(?"+goFlags+")" + pattern[1:]
 		}
 		return pattern
-	case SingleQuote, RawWords:
+	case SingleQuote, RawWords, RawSymbols:
 		return n.FmtString("`")
 	default:
 		return n.FmtString(`"`)
@@ -157,18 +170,91 @@ func (n *StringNode) FmtString(delim string) string {
 func (n *StringNode) String() string {
 	if len(n.OrderedInterps()) == 0 {
 		str := n.FmtString(stringDelims[n.Kind])
-		if n.Kind == RawWords || n.Kind == Words {
+		switch n.Kind {
+		case RawWords, Words:
 			str = fmt.Sprintf("%%w[%s]", str)
+		case RawSymbols, Symbols:
+			str = fmt.Sprintf("%%i[%s]", str)
+		case DynamicSymbol:
+			str = ":" + str
 		}
 		return str
 	}
 	str := fmt.Sprintf(`%s %% (%s)`, n.FmtString(stringDelims[n.Kind]), stdlib.Join[Node](n.OrderedInterps(), ", "))
-	if n.Kind == RawWords || n.Kind == Words {
+	switch n.Kind {
+	case RawWords, Words:
 		return fmt.Sprintf("%%w[%s]", str)
+	case RawSymbols, Symbols:
+		return fmt.Sprintf("%%i[%s]", str)
+	case DynamicSymbol:
+		return fmt.Sprintf(":(%s)", str)
 	}
 	return fmt.Sprintf("(%s)", str)
 }
 
+// Words splits the string into one StringNode per whitespace-separated
+// word. The compiler's stringElements uses it both for %W and %I lists,
+// which become slices of the words, and for backtick and %x strings, whose
+// words become the arguments to stdlib.Backtick. Ruby splits on whitespace in
+// the literal source before interpolating, so an interpolation belongs to
+// whatever word the text around it belongs to and whitespace inside an
+// interpolated value never starts a new word.
+func (n *StringNode) Words() []*StringNode {
+	kind := DoubleQuote
+	switch n.Kind {
+	case RawWords, RawSymbols, RawExec:
+		kind = SingleQuote
+	}
+	var (
+		words []*StringNode
+		curr  *StringNode
+	)
+	flush := func() {
+		if curr != nil {
+			words = append(words, curr)
+			curr = nil
+		}
+	}
+	word := func() *StringNode {
+		if curr == nil {
+			curr = &StringNode{Kind: kind, Interps: make(map[int][]Node), Pos: n.Pos, delim: n.delim, _type: types.StringType}
+		}
+		return curr
+	}
+	addInterps := func(i int) {
+		if interps, exists := n.Interps[i]; exists {
+			w := word()
+			w.Interps[len(w.BodySegments)] = append(w.Interps[len(w.BodySegments)], interps...)
+		}
+	}
+	for i, seg := range n.BodySegments {
+		addInterps(i)
+		fields := strings.Fields(seg)
+		if len(fields) == 0 {
+			if seg != "" {
+				flush()
+			}
+			continue
+		}
+		if strings.TrimLeftFunc(seg, unicode.IsSpace) != seg {
+			flush()
+		}
+		for j, field := range fields {
+			if j > 0 {
+				flush()
+			}
+			w := word()
+			w.BodySegments = append(w.BodySegments, field)
+		}
+		if strings.TrimRightFunc(seg, unicode.IsSpace) != seg {
+			flush()
+		}
+	}
+	addInterps(len(n.BodySegments))
+	flush()
+	return words
+}
+
 func (n *StringNode) Type() types.Type {
 	return n._type
 }
@@ -198,6 +284,10 @@ func (n *StringNode) TargetType(scope ScopeChain, class *Class) (types.Type, err
 		return types.RegexpType, nil
 	case Words, RawWords:
 		return types.NewArray(types.StringType), nil
+	case Symbols, RawSymbols:
+		return types.NewArray(types.SymbolType), nil
+	case DynamicSymbol:
+		return types.SymbolType, nil
 	default:
 		return types.StringType, nil
 	}
@@ -207,10 +297,10 @@ func (n *StringNode) Copy() Node { return n }
 
 func (n *StringNode) TranslateEscapes(segment string) (string, error) {
 	switch n.Kind {
-	case SingleQuote, RawWords, RawExec:
+	case SingleQuote, RawWords, RawExec, RawSymbols:
 		escapeless := strings.ReplaceAll(segment, `\`+n.delim, n.delim)
 		return strings.ReplaceAll(escapeless, `\\`, `\`), nil
-	case DoubleQuote, Words, Exec:
+	case DoubleQuote, Words, Exec, Symbols, DynamicSymbol:
 		var (
 			stripped []rune
 			lastSeen rune
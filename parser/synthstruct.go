@@ -73,15 +73,66 @@ func promoteTupleToSynthStruct(arrayVarName string, tuple *types.Tuple, scope Sc
 	}
 	ss.Fields = fields
 
-	// Track which module this SynthStruct belongs to so the compiler
-	// emits it in the correct package.
+	ss.ModuleName = synthStructModule(scope)
+
+	SynthStructs = append(SynthStructs, ss)
+	return ss
+}
+
+// synthStructModule returns the qualified name of the innermost module in
+// scope, so the compiler emits a SynthStruct in the correct package.
+func synthStructModule(scope ScopeChain) string {
 	for i := len(scope) - 1; i >= 0; i-- {
 		if mod, ok := scope[i].(*Module); ok {
-			ss.ModuleName = mod.QualifiedName()
-			break
+			return mod.QualifiedName()
+		}
+	}
+	return ""
+}
+
+// promoteHashToSynthStruct converts a symbol-keyed hash literal whose values
+// don't share a type into a SynthStruct with one field per key. Literals with
+// the same keys and value types share a struct, so `[{a: 1, b: "x"}, {a: 2,
+// b: "y"}]` is a slice of one struct type. varName is the variable the
+// literal is assigned to, if any, and only names the struct.
+func promoteHashToSynthStruct(varName string, keys []string, values []types.Type, scope ScopeChain) *types.SynthStruct {
+	fields := make([]types.SynthField, len(keys))
+	for i, key := range keys {
+		fields[i] = types.SynthField{Name: GoName(key), Type: values[i], Key: key}
+	}
+
+	for _, ss := range SynthStructs {
+		if sameSynthFields(ss.Fields, fields) {
+			return ss
 		}
 	}
 
+	base := "Hash"
+	if varName != "" {
+		base = strings.ToUpper(varName[:1]) + varName[1:]
+	}
+	name := base + "Entry"
+	for i := 2; findSynthStruct(name) != nil; i++ {
+		name = fmt.Sprintf("%sEntry%d", base, i)
+	}
+
+	ss := types.NewSynthStruct(name, fields)
+	ss.ModuleName = synthStructModule(scope)
 	SynthStructs = append(SynthStructs, ss)
 	return ss
 }
+
+// sameSynthFields reports whether two keyed field lists have the same keys
+// with the same types, in any order.
+func sameSynthFields(a, b []types.SynthField) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ss := types.NewSynthStruct("", a)
+	for _, f := range b {
+		if match, ok := ss.FieldByKey(f.Key); !ok || !match.Type.Equals(f.Type) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,489 @@
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/redneckbeard/thanos/types"
+)
+
+// A Minitest or RSpec suite is compiled into a Go test. Rather than teach the
+// grammar and the analysis about test classes, hooks, lets and matchers, the
+// entry file is first rewritten into plain describe/it blocks calling
+// Minitest-style assertions, which the types package compiles into subtests
+// and t.Errorf checks. The rewrite works line by line and never adds or
+// removes a line, so that diagnostics and source maps point at the suite as
+// written. Blocks are matched by indentation: a block opened on a line ends
+// at the first `end` (or `}`) indented no further.
+
+var (
+	minitestClass = regexp.MustCompile(`^(\s*)class\s+(\w+)\s*<\s*(?:Minitest::Test|MiniTest::Test|Minitest::Unit::TestCase|MiniTest::Unit::TestCase|Test::Unit::TestCase)\s*$`)
+	minitestDef   = regexp.MustCompile(`^(\s*)def\s+(setup|teardown|test_\w+)\s*(?:\(\s*\))?\s*$`)
+	specGroup     = regexp.MustCompile(`^(\s*)(?:RSpec\.)?(?:describe|context|feature)\b\s*(.*?)\s+do\s*$`)
+	specExample   = regexp.MustCompile(`^(\s*)(?:it|specify|example|scenario)\b\s*(.*?)\s+do\s*$`)
+	specOneLiner  = regexp.MustCompile(`^(\s*)(?:it|specify|example)\b\s*(.*?)\s*\{(.*)\}\s*$`)
+	specHook      = regexp.MustCompile(`^(\s*)(before|after)\b\s*(?:\(?\s*:(?:each|all|example|context)\s*\)?)?\s*(?:do|\{(.*)\})\s*$`)
+	specLet       = regexp.MustCompile(`^(\s*)(?:let!?|subject!?)\s*(?:\(\s*:(\w+)\s*\))?\s*\{(.*)\}\s*$`)
+	specSubject   = regexp.MustCompile(`^(\s*)subject!?\s*\{`)
+	expectBlock   = regexp.MustCompile(`^(\s*)expect\s*\{(.*)\}\s*\.(to|not_to|to_not)\s+raise_error\b\s*(.*?)\s*$`)
+	expectOpen    = regexp.MustCompile(`^(\s*)expect\s*(?:do|\{)\s*$`)
+	expectClose   = regexp.MustCompile(`^(?:end|\})\s*\.(to|not_to|to_not)\s+raise_error\b\s*(.*?)\s*$`)
+	isExpected    = regexp.MustCompile(`\bis_expected\b`)
+	operatorMatch = regexp.MustCompile(`^be\s*(==|!=|>=|<=|>|<)\s*(.+)$`)
+	matcherCall   = regexp.MustCompile(`^(\w+[?!]?)\s*(?:\((.*)\)|\s(.+))?$`)
+	withinMatch   = regexp.MustCompile(`^be_within\s*\((.*)\)\s*\.of\s*\((.*)\)$`)
+	requireLine   = regexp.MustCompile(`^\s*require\s*\(?\s*['"](minitest/autorun|minitest|rspec|rspec/autorun)['"]`)
+	commentLine   = regexp.MustCompile(`^\s*#`)
+	heredocStart  = regexp.MustCompile("^<<([~-]?)([\"'`]?)(\\w+)([\"'`]?)")
+)
+
+// isTestSuite reports whether the file at path defines a Minitest test class
+// or has a top-level RSpec describe. Since a program may well have a method of
+// its own called describe, that only counts if the file also says it is a
+// test suite, by requiring Minitest or RSpec or by being named like one.
+func isTestSuite(path string, lines []string) bool {
+	if base := filepath.Base(path); !strings.HasSuffix(base, "_test.rb") && !strings.HasSuffix(base, "_spec.rb") {
+		required := false
+		for _, line := range lines {
+			if requireLine.MatchString(line) {
+				required = true
+				break
+			}
+		}
+		if !required {
+			return false
+		}
+	}
+	for _, line := range lines {
+		if minitestClass.MatchString(line) {
+			return true
+		}
+		if m := specGroup.FindStringSubmatch(line); m != nil && m[1] == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// blockEnd returns the index of the line that closes the block opened on
+// lines[start], or -1 if there is none.
+func blockEnd(lines []string, start int) int {
+	indent := indentation(lines[start])
+	for i := start + 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || commentLine.MatchString(lines[i]) || indentation(lines[i]) > indent {
+			continue
+		}
+		if trimmed == "end" || trimmed == "}" || strings.HasPrefix(trimmed, "end.") || strings.HasPrefix(trimmed, "}.") || strings.HasPrefix(trimmed, "end ") {
+			return i
+		}
+		return -1
+	}
+	return -1
+}
+
+// describeName turns the arguments of describe or it into a single string
+// literal: `Calc, "#add"` becomes "Calc #add". Metadata is dropped.
+func describeName(args string, line int) string {
+	args = strings.TrimSpace(args)
+	if strings.HasPrefix(args, "(") && strings.HasSuffix(args, ")") {
+		args = args[1 : len(args)-1]
+	}
+	var parts []string
+	for _, arg := range strings.Split(args, ",") {
+		arg = strings.TrimSpace(arg)
+		switch {
+		case arg == "" || strings.HasPrefix(arg, ":") || strings.Contains(arg, ":") && !strings.Contains(arg, "::"):
+			// a tag like :focus or focus: true
+		case len(arg) >= 2 && (arg[0] == '"' || arg[0] == '\''):
+			parts = append(parts, arg[1:len(arg)-1])
+		default:
+			parts = append(parts, arg)
+		}
+	}
+	if len(parts) == 0 {
+		return strconv.Quote(fmt.Sprintf("example at line %d", line))
+	}
+	return strconv.Quote(strings.Join(parts, " "))
+}
+
+// closingParen returns the index of the parenthesis that closes the one at
+// s[open], skipping over string literals.
+func closingParen(s string, open int) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// lowerExpectation rewrites `expect(actual).to matcher` into the equivalent
+// Minitest assertion, leaving lines it doesn't understand alone.
+func lowerExpectation(line string) string {
+	line = isExpected.ReplaceAllString(line, "expect(subject)")
+	start := strings.Index(line, "expect(")
+	if start < 0 {
+		return line
+	}
+	open := start + len("expect")
+	end := closingParen(line, open)
+	if end < 0 {
+		return line
+	}
+	actual := line[open+1 : end]
+	rest := strings.TrimSpace(line[end+1:])
+	var negated bool
+	switch {
+	case strings.HasPrefix(rest, ".to "):
+		rest = rest[len(".to "):]
+	case strings.HasPrefix(rest, ".not_to "):
+		rest, negated = rest[len(".not_to "):], true
+	case strings.HasPrefix(rest, ".to_not "):
+		rest, negated = rest[len(".to_not "):], true
+	default:
+		return line
+	}
+	if assertion := lowerMatcher(actual, strings.TrimSpace(rest), negated); assertion != "" {
+		return line[:start] + assertion
+	}
+	return line
+}
+
+func lowerMatcher(actual, matcher string, negated bool) string {
+	assert := func(name string, args ...string) string {
+		prefix := "assert_"
+		if negated {
+			prefix = "refute_"
+		}
+		if name == "" {
+			prefix = strings.TrimSuffix(prefix, "_")
+		}
+		return prefix + name + "(" + strings.Join(args, ", ") + ")"
+	}
+	if m := operatorMatch.FindStringSubmatch(matcher); m != nil {
+		return assert("", actual+" "+m[1]+" "+m[2])
+	}
+	if m := withinMatch.FindStringSubmatch(matcher); m != nil && !negated {
+		return assert("in_delta", m[2], actual, m[1])
+	}
+	m := matcherCall.FindStringSubmatch(matcher)
+	if m == nil {
+		return ""
+	}
+	name, arg := m[1], m[2]
+	if arg == "" {
+		arg = m[3]
+	}
+	switch name {
+	case "eq", "eql", "equal", "be":
+		if arg == "" {
+			return ""
+		}
+		return assert("equal", arg, actual)
+	case "be_nil":
+		return assert("nil", actual)
+	case "be_truthy", "be_true":
+		return assert("", actual)
+	case "be_falsey", "be_falsy", "be_false":
+		negated = !negated
+		return assert("", actual)
+	case "include":
+		return assert("includes", actual, arg)
+	case "be_empty":
+		return assert("empty", actual)
+	case "match":
+		return assert("match", arg, actual)
+	case "have_key":
+		return assert("", actual+".key?("+arg+")")
+	}
+	if strings.HasPrefix(name, "be_") && arg == "" {
+		return assert("", actual+"."+strings.TrimPrefix(name, "be_")+"?")
+	}
+	return ""
+}
+
+// normalizeTestSuite rewrites a Minitest or RSpec suite into describe/it
+// blocks, reporting whether src was a suite at all.
+func normalizeTestSuite(path string, src []byte) ([]byte, bool) {
+	lines := strings.Split(string(src), "\n")
+	if !isTestSuite(path, lines) {
+		return src, false
+	}
+	out := make([]string, len(lines))
+	copy(out, lines)
+	// The lines that close rewritten blocks, by what they become.
+	closers := map[int]string{}
+	close := func(start int, with string) {
+		if end := blockEnd(lines, start); end >= 0 {
+			closers[end] = strings.Repeat(" ", indentation(lines[end])) + with
+		}
+	}
+	// The lines of the test classes and outermost describe blocks, where
+	// instance variables are shared between setup and the tests.
+	inSuite := make([]bool, len(lines))
+	enter := func(start int) {
+		if inSuite[start] {
+			return
+		}
+		for j, end := start, blockEnd(lines, start); j <= end; j++ {
+			inSuite[j] = true
+		}
+	}
+	minitestEnd := -1
+	for i, line := range lines {
+		if with, ok := closers[i]; ok {
+			out[i] = with
+			continue
+		}
+		if requireLine.MatchString(line) {
+			out[i] = ""
+			continue
+		}
+		if m := minitestClass.FindStringSubmatch(line); m != nil {
+			out[i] = fmt.Sprintf("%sdescribe %s do", m[1], strconv.Quote(m[2]))
+			minitestEnd = blockEnd(lines, i)
+			enter(i)
+			continue
+		}
+		if i < minitestEnd {
+			if m := minitestDef.FindStringSubmatch(line); m != nil {
+				switch m[2] {
+				case "setup":
+					out[i] = m[1] + types.SetupMarker
+					close(i, types.EndSetupMarker)
+				case "teardown":
+					out[i] = m[1] + types.TeardownMarker
+					close(i, types.EndTeardownMarker)
+				default:
+					out[i] = fmt.Sprintf("%sit %s do", m[1], strconv.Quote(m[2]))
+				}
+				continue
+			}
+		}
+		if m := specGroup.FindStringSubmatch(line); m != nil {
+			out[i] = fmt.Sprintf("%sdescribe %s do", m[1], describeName(m[2], i+1))
+			enter(i)
+			continue
+		}
+		if m := specExample.FindStringSubmatch(line); m != nil {
+			out[i] = fmt.Sprintf("%sit %s do", m[1], describeName(m[2], i+1))
+			continue
+		}
+		if m := specOneLiner.FindStringSubmatch(line); m != nil {
+			out[i] = fmt.Sprintf("%sit(%s) { %s }", m[1], describeName(m[2], i+1), lowerExpectation(strings.TrimSpace(m[3])))
+			continue
+		}
+		if m := specHook.FindStringSubmatch(line); m != nil {
+			begin, end := types.SetupMarker, types.EndSetupMarker
+			if m[2] == "after" {
+				begin, end = types.TeardownMarker, types.EndTeardownMarker
+			}
+			if strings.HasSuffix(strings.TrimSpace(line), "do") {
+				out[i] = m[1] + begin
+				close(i, end)
+			} else {
+				out[i] = fmt.Sprintf("%s%s; %s; %s", m[1], begin, strings.TrimSpace(m[3]), end)
+			}
+			continue
+		}
+		if m := specLet.FindStringSubmatch(line); m != nil {
+			name := m[2]
+			if name == "" {
+				if !specSubject.MatchString(line) {
+					continue
+				}
+				name = "subject"
+			}
+			out[i] = fmt.Sprintf("%s%s; %s = %s; %s", m[1], types.SetupMarker, name, strings.TrimSpace(m[3]), types.EndSetupMarker)
+			continue
+		}
+		if m := expectBlock.FindStringSubmatch(line); m != nil {
+			if m[3] == "to" {
+				out[i] = fmt.Sprintf("%sassert_raises(%s) { %s }", m[1], strings.Trim(m[4], "()"), strings.TrimSpace(m[2]))
+			} else {
+				out[i] = m[1] + strings.TrimSpace(m[2])
+			}
+			continue
+		}
+		if m := expectOpen.FindStringSubmatch(line); m != nil {
+			if end := blockEnd(lines, i); end >= 0 {
+				if c := expectClose.FindStringSubmatch(strings.TrimSpace(lines[end])); c != nil {
+					if c[1] == "to" {
+						out[i] = fmt.Sprintf("%sassert_raises(%s) do", m[1], strings.Trim(c[2], "()"))
+						closers[end] = strings.Repeat(" ", indentation(lines[end])) + "end"
+					} else {
+						out[i], closers[end] = "", ""
+					}
+					continue
+				}
+			}
+		}
+		out[i] = lowerExpectation(line)
+	}
+	ivars := &ivarRewriter{}
+	for i, line := range out {
+		out[i] = ivars.rewrite(line, inSuite[i])
+	}
+	return []byte(strings.Join(out, "\n")), true
+}
+
+// ivarRewriter turns the instance variables of a suite into locals, line by
+// line, leaving string literals and heredocs as they are apart from the code
+// interpolated into them. Literals left open at the end of a line stay open
+// into the next.
+type ivarRewriter struct {
+	// frames are the literals and interpolations open at this point,
+	// innermost last. With none open, or an interpolation innermost, the
+	// scanner is in code.
+	frames []literalFrame
+	// heredocs are the heredocs whose bodies follow, in the order they were
+	// opened.
+	heredocs []heredoc
+}
+
+type literalFrame struct {
+	open, close  byte // for an interpolation, '{' and '}'
+	interpolates bool
+	code         bool // an interpolation rather than a string
+	depth        int  // open delimiters nested inside
+}
+
+type heredoc struct {
+	id           string
+	indented     bool // <<~ or <<-, whose terminator may be indented
+	interpolates bool
+}
+
+// closingDelimiter is what closes a %-literal opened with c.
+func closingDelimiter(c byte) byte {
+	switch c {
+	case '(':
+		return ')'
+	case '[':
+		return ']'
+	case '{':
+		return '}'
+	case '<':
+		return '>'
+	}
+	return c
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// rewrite scans line, dropping the @ from instance variables in its code if
+// apply is set.
+func (r *ivarRewriter) rewrite(line string, apply bool) string {
+	if len(r.heredocs) > 0 {
+		doc := r.heredocs[0]
+		terminator := line
+		if doc.indented {
+			terminator = strings.TrimSpace(line)
+		}
+		if terminator == doc.id {
+			r.heredocs = r.heredocs[1:]
+			return line
+		}
+		if !doc.interpolates {
+			return line
+		}
+		// The body is a string that no delimiter closes.
+		r.frames = append(r.frames, literalFrame{interpolates: true})
+		defer func() { r.frames = r.frames[:len(r.frames)-1] }()
+	}
+	var out strings.Builder
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		top := len(r.frames) - 1
+		if top >= 0 && !r.frames[top].code {
+			f := &r.frames[top]
+			switch {
+			case c == '\\' && i+1 < len(line):
+				out.WriteByte(c)
+				i++
+				c = line[i]
+			case f.interpolates && c == '#' && i+1 < len(line) && line[i+1] == '{':
+				out.WriteString("#{")
+				i++
+				r.frames = append(r.frames, literalFrame{open: '{', close: '}', code: true})
+				continue
+			case c == f.close && f.close != 0 && f.depth == 0:
+				r.frames = r.frames[:top]
+			case c == f.close && f.close != 0:
+				f.depth--
+			case c == f.open && f.open != f.close:
+				f.depth++
+			}
+			out.WriteByte(c)
+			continue
+		}
+		switch {
+		case c == '#':
+			// A comment runs to the end of the line.
+			out.WriteString(line[i:])
+			return out.String()
+		case c == '"' || c == '`':
+			r.frames = append(r.frames, literalFrame{open: c, close: c, interpolates: true})
+		case c == '\'':
+			r.frames = append(r.frames, literalFrame{open: c, close: c})
+		case c == '%' && i+1 < len(line):
+			j, interpolates := i+1, true
+			if strings.IndexByte("qQwWiI", line[j]) >= 0 {
+				interpolates = line[j] >= 'A' && line[j] <= 'Z'
+				j++
+			}
+			if j < len(line) && !isWordByte(line[j]) && line[j] != ' ' && line[j] != '=' {
+				out.WriteString(line[i : j+1])
+				r.frames = append(r.frames, literalFrame{open: line[j], close: closingDelimiter(line[j]), interpolates: interpolates})
+				i = j
+				continue
+			}
+		case c == '<' && strings.HasPrefix(line[i:], "<<"):
+			if m := heredocStart.FindStringSubmatch(line[i:]); m != nil && m[2] == m[4] && (m[1] != "" || m[2] != "" || m[3][0] >= 'A' && m[3][0] <= 'Z') {
+				r.heredocs = append(r.heredocs, heredoc{id: m[3], indented: m[1] != "", interpolates: m[2] != "'"})
+				out.WriteString(m[0])
+				i += len(m[0]) - 1
+				continue
+			}
+		case c == '{' && top >= 0:
+			r.frames[top].depth++
+		case c == '}' && top >= 0:
+			if r.frames[top].depth == 0 {
+				r.frames = r.frames[:top]
+			} else {
+				r.frames[top].depth--
+			}
+		case c == '@' && apply && i+1 < len(line) && isWordByte(line[i+1]) && (i == 0 || line[i-1] != '@' && !isWordByte(line[i-1])):
+			continue
+		}
+		out.WriteByte(c)
+	}
+	return out.String()
+}
@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTestSuite(t *testing.T) {
+	tests := []struct {
+		input, expected string
+	}{
+		{`require "minitest/autorun"
+
+class CalcTest < Minitest::Test
+  def setup
+    @calc = Calc.new(10)
+  end
+
+  def test_add
+    assert_equal 12, @calc.add(2)
+  end
+
+  def helper
+    1
+  end
+end`, `
+
+describe "CalcTest" do
+  __setup__
+    calc = Calc.new(10)
+  __end_setup__
+
+  it "test_add" do
+    assert_equal 12, calc.add(2)
+  end
+
+  def helper
+    1
+  end
+end`},
+		{`RSpec.describe Calc do
+  let(:calc) { Calc.new(10) }
+  after { calc.reset }
+
+  context "adding" do
+    it "adds" do
+      expect(calc.add(2)).to eq(12)
+      expect(calc.add(2)).not_to be_nil
+      expect(calc.items).to include(3)
+      expect(calc.ratio).to be_within(0.1).of(2.5)
+      expect(calc.add(1)).to be > 10
+      expect(calc).to be_positive
+    end
+  end
+
+  it { expect(calc.base).to eq(10) }
+
+  specify "raising" do
+    expect { calc.div(0) }.to raise_error(ZeroDivisionError)
+    expect do
+      calc.div(0)
+    end.to raise_error(ZeroDivisionError)
+  end
+end`, `describe "Calc" do
+  __setup__; calc = Calc.new(10); __end_setup__
+  __teardown__; calc.reset; __end_teardown__
+
+  describe "adding" do
+    it "adds" do
+      assert_equal(12, calc.add(2))
+      refute_nil(calc.add(2))
+      assert_includes(calc.items, 3)
+      assert_in_delta(2.5, calc.ratio, 0.1)
+      assert(calc.add(1) > 10)
+      assert(calc.positive?)
+    end
+  end
+
+  it("example at line 16") { assert_equal(10, calc.base) }
+
+  it "raising" do
+    assert_raises(ZeroDivisionError) { calc.div(0) }
+    assert_raises(ZeroDivisionError) do
+      calc.div(0)
+    end
+  end
+end`},
+		{`require "minitest/autorun"
+
+class UserTest < Minitest::Test
+  def setup
+    @user = "bob" # @user's name
+    @body = <<~TEXT
+      Dear @user, from #{@user}
+    TEXT
+  end
+
+  def test_email
+    assert_equal "bob@example.com", "#{@user}@example.com"
+    assert_equal '@user', %q(@user)
+    assert_equal "@user
+      #{@user} @user", @body + %(@user)
+  end
+end`, `
+
+describe "UserTest" do
+  __setup__
+    user = "bob" # @user's name
+    body = <<~TEXT
+      Dear @user, from #{user}
+    TEXT
+  __end_setup__
+
+  it "test_email" do
+    assert_equal "bob@example.com", "#{user}@example.com"
+    assert_equal '@user', %q(@user)
+    assert_equal "@user
+      #{user} @user", body + %(@user)
+  end
+end`},
+	}
+
+	for i, tt := range tests {
+		out, ok := normalizeTestSuite("calc_test.rb", []byte(tt.input))
+		if !ok {
+			t.Errorf("[%d] expected the input to be recognized as a test suite", i)
+			continue
+		}
+		if got := string(out); got != tt.expected {
+			t.Errorf("[%d] expected:\n%s\ngot:\n%s", i, tt.expected, got)
+		}
+		if in, got := strings.Count(tt.input, "\n"), strings.Count(string(out), "\n"); in != got {
+			t.Errorf("[%d] normalizing changed the line count from %d to %d", i, in+1, got+1)
+		}
+	}
+
+	program := "class Calc\n  def add(x)\n    x + 1\n  end\nend\nputs Calc.new.add(@x)\n"
+	if out, ok := normalizeTestSuite("calc.rb", []byte(program)); ok || string(out) != program {
+		t.Errorf("expected a program without tests to be left alone, got:\n%s", out)
+	}
+
+	spec := "describe \"Calc\" do\n  it \"adds\" do\n    expect(1 + 1).to eq(2)\n  end\nend\n"
+	for _, tt := range []struct {
+		path, src string
+		suite     bool
+	}{
+		{"main.rb", spec, false},
+		{"calc_spec.rb", spec, true},
+		{"calc_test.rb", spec, true},
+		{"main.rb", "require \"rspec\"\n\n" + spec, true},
+	} {
+		if _, ok := normalizeTestSuite(tt.path, []byte(tt.src)); ok != tt.suite {
+			t.Errorf("expected %s to be a test suite: %t, got %t", tt.path, tt.suite, ok)
+		}
+	}
+}
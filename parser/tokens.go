@@ -26,6 +26,7 @@ var tokenNames = map[int]string{
 	DOT2:          "DOT2",
 	DOT3:          "DOT3",
 	DOUBLESPLAT:   "DOUBLESPLAT",
+	DSYMBEG:       "DSYMBEG",
 	ELSE:          "ELSE",
 	ELSIF:         "ELSIF",
 	END:           "END",
@@ -81,12 +82,15 @@ var tokenNames = map[int]string{
 	RAWWORDSBEG:   "RAWWORDSBEG",
 	RAWXSTRINGBEG: "RAWXSTRINGBEG",
 	RBRACE:        "RBRACE",
+	REDO:          "REDO",
 	REGEXBEG:      "REGEXBEG",
 	REGEXEND:      "REGEXEND",
 	RSHIFT:        "RSHIFT",
 	RSHIFTASSIGN:  "RSHIFTASSIGN",
 	RESCUE:        "RESCUE",
+	RETRY:         "RETRY",
 	SCOPE:         "SCOPE",
+	SCOPESTART:    "SCOPESTART",
 	SLASH:         "SLASH",
 	SPACESHIP:     "SPACESHIP",
 	STRINGBEG:     "STRINGBEG",
@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/redneckbeard/thanos/types"
+)
+
+// Unions stores the union types inferred for the program, for the compiler to
+// emit as sealed interfaces. Each set of member types is registered once, so
+// unions can be compared by identity like other named types.
+var Unions []*types.Union
+
+// ResetUnions clears the global list (for test isolation).
+func ResetUnions() {
+	Unions = nil
+}
+
+// unionOf returns the registered union of the given types, registering it if
+// this is the first time the combination has been seen. Nil, Optional and
+// values of unknown type can't be union members, and neither can two types that
+// would share a wrapper name.
+func unionOf(ts ...types.Type) (*types.Union, error) {
+	for _, t := range ts {
+		if t == nil {
+			return nil, fmt.Errorf("a value of unknown type cannot be a member of a union")
+		}
+	}
+	u := types.NewUnion(ts...)
+	seen := map[string]types.Type{}
+	for _, m := range u.Members {
+		if m == types.NilType || types.ContainsAnyType(m) {
+			return nil, fmt.Errorf("%s cannot be a member of a union", m)
+		}
+		switch m.(type) {
+		case types.Optional, types.Multiple:
+			return nil, fmt.Errorf("%s cannot be a member of a union", m)
+		}
+		name := types.UnionMemberName(m)
+		if other, ok := seen[name]; ok {
+			return nil, fmt.Errorf("%s and %s cannot be members of the same union", other, m)
+		}
+		seen[name] = m
+	}
+	for _, existing := range Unions {
+		if existing.Equals(u) {
+			return existing, nil
+		}
+	}
+	Unions = append(Unions, u)
+	return u, nil
+}
+
+// widenToUnion widens a local that already holds a value of one type to the
+// union of that type and t. It reports false if the two can't form a union.
+func widenToUnion(rl *RubyLocal, t types.Type) bool {
+	u, err := unionOf(rl.Type(), t)
+	if err != nil {
+		return false
+	}
+	rl.SetType(u)
+	rl.AddConstraint(TypeConstraint{Kind: AssignedType, Type: t})
+	return true
+}
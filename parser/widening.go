@@ -231,8 +231,11 @@ func (r *Root) applyWidening(w typeWidening) {
 
 	// Emit warning when user code widens gem/library code
 	if method.FromGem {
-		Note("note: widening return type of %s from %s to %s (consumer checks .nil? on elements)\n",
+		d := NewDiagnostic(w.sourceCall, SeverityNote, "type-widened",
+			"widening return type of %s from %s to %s (consumer checks .nil? on elements)",
 			method.Name, method.Body.ReturnType, w.widerType)
+		d.Related = []RelatedLocation{{Location: nodeLocation(method), Message: "method " + method.Name + " defined here"}}
+		Report(d)
 	}
 
 	// Update the method's return type
@@ -0,0 +1,57 @@
+package stdlib
+
+import (
+	"fmt"
+	"iter"
+	"os"
+	"strings"
+)
+
+// LookupEnv is ENV[key]: the value of the environment variable, or nil if it
+// isn't set.
+func LookupEnv(key string) *string {
+	if v, ok := os.LookupEnv(key); ok {
+		return &v
+	}
+	return nil
+}
+
+func HasEnv(key string) bool {
+	_, ok := os.LookupEnv(key)
+	return ok
+}
+
+// FetchEnv is ENV.fetch with a block or with neither block nor default. If
+// the variable isn't set, the block is called with its name, or without a
+// block, KeyError is raised.
+func FetchEnv(key string, missing func(string) string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	if missing != nil {
+		return missing(key)
+	}
+	panic(&KeyError{StandardError{RubyError{Msg: fmt.Sprintf("key not found: %q", key)}}})
+}
+
+// Environ iterates over the environment's variables and their values, in the
+// order the process was given them, as ENV.each does.
+func Environ() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for _, kv := range os.Environ() {
+			k, v, _ := strings.Cut(kv, "=")
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// EnvHash is ENV.to_h.
+func EnvHash() *OrderedMap[string, string] {
+	h := NewOrderedMap[string, string]()
+	for k, v := range Environ() {
+		h.Set(k, v)
+	}
+	return h
+}
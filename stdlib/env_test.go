@@ -0,0 +1,30 @@
+package stdlib
+
+import "testing"
+
+func TestEnv(t *testing.T) {
+	t.Setenv("THANOS_ENV_TEST", "set")
+	if v := LookupEnv("THANOS_ENV_TEST"); v == nil || *v != "set" {
+		t.Fatalf("expected the variable's value, got %v", v)
+	}
+	if v := LookupEnv("THANOS_ENV_TEST_UNSET"); v != nil {
+		t.Fatalf("expected nil for an unset variable, got %q", *v)
+	}
+	if !HasEnv("THANOS_ENV_TEST") || HasEnv("THANOS_ENV_TEST_UNSET") {
+		t.Fatal("expected HasEnv to report only the set variable")
+	}
+	if v := FetchEnv("THANOS_ENV_TEST_UNSET", func(k string) string { return "no " + k }); v != "no THANOS_ENV_TEST_UNSET" {
+		t.Fatalf("expected the block's value, got %q", v)
+	}
+	if v, ok := EnvHash().Data["THANOS_ENV_TEST"]; !ok || v != "set" {
+		t.Fatalf("expected the variable in the hash, got %q", v)
+	}
+	defer func() {
+		err, ok := recover().(*KeyError)
+		if !ok || err.Error() != `key not found: "THANOS_ENV_TEST_UNSET"` {
+			t.Fatalf("expected KeyError for an unset variable, got %v", err)
+		}
+	}()
+	FetchEnv("THANOS_ENV_TEST_UNSET", nil)
+	t.Fatal("expected fetch to raise")
+}
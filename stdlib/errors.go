@@ -62,3 +62,11 @@ type StopIteration struct {
 type RegexpError struct {
 	StandardError
 }
+
+type ThreadError struct {
+	StandardError
+}
+
+type ClosedQueueError struct {
+	StopIteration
+}
@@ -0,0 +1,73 @@
+package stdlib
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// SystemExit is raised by exit and abort. Like Ruby's, it isn't a
+// StandardError, so it unwinds through ensure blocks to main, where Exited
+// turns it into the process's exit status.
+type SystemExit struct {
+	RubyError
+	Status int
+}
+
+func Exit(status int) {
+	panic(&SystemExit{RubyError{Msg: "exit"}, status})
+}
+
+// Abort prints msg to standard error and exits with status 1.
+func Abort(msg string) {
+	fmt.Fprintln(os.Stderr, msg)
+	panic(&SystemExit{RubyError{Msg: msg}, 1})
+}
+
+var atExit struct {
+	sync.Mutex
+	hooks []func()
+}
+
+// AtExit registers a block to run when the program exits, after any
+// registered before it, as at_exit does.
+func AtExit(f func()) {
+	atExit.Lock()
+	defer atExit.Unlock()
+	atExit.hooks = append(atExit.hooks, f)
+}
+
+// Exited is deferred at the top of main in programs that call exit, abort or
+// at_exit. It runs the at_exit blocks, last registered first, whether main
+// returned or something unwound it, and then exits with the status of a
+// SystemExit or raises again whatever else was raised.
+func Exited() {
+	r := recover()
+	for {
+		atExit.Lock()
+		n := len(atExit.hooks)
+		if n == 0 {
+			atExit.Unlock()
+			break
+		}
+		hook := atExit.hooks[n-1]
+		atExit.hooks = atExit.hooks[:n-1]
+		atExit.Unlock()
+		hook()
+	}
+	switch e := r.(type) {
+	case nil:
+	case *SystemExit:
+		os.Exit(e.Status)
+	default:
+		panic(r)
+	}
+}
+
+// ExitStatus is the status exit(true) or exit(false) exits with.
+func ExitStatus(success bool) int {
+	if success {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,26 @@
+package stdlib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExitedRunsHooksThenRaises(t *testing.T) {
+	var ran []int
+	AtExit(func() { ran = append(ran, 1) })
+	AtExit(func() { ran = append(ran, 2) })
+	defer func() {
+		err, ok := recover().(*RuntimeError)
+		if !ok || err.Error() != "boom" {
+			t.Fatalf("expected Exited to raise the RuntimeError again, got %v", err)
+		}
+		if !reflect.DeepEqual(ran, []int{2, 1}) {
+			t.Fatalf("expected at_exit blocks to run last registered first, got %v", ran)
+		}
+	}()
+	func() {
+		defer Exited()
+		panic(&RuntimeError{StandardError{RubyError{Msg: "boom"}}})
+	}()
+	t.Fatal("expected Exited to raise")
+}
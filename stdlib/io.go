@@ -0,0 +1,158 @@
+package stdlib
+
+import (
+	"bufio"
+	"io"
+	"iter"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Stdin buffers standard input for gets, $stdin, STDIN and ARGF alike, so
+// that a line one of them has read ahead isn't lost to the others.
+var Stdin = bufio.NewReader(os.Stdin)
+
+var (
+	readersMu sync.Mutex
+	readers   = map[*os.File]*bufio.Reader{os.Stdin: Stdin}
+)
+
+// reader returns the buffered reader shared by every read from f.
+func reader(f *os.File) *bufio.Reader {
+	readersMu.Lock()
+	defer readersMu.Unlock()
+	r, ok := readers[f]
+	if !ok {
+		r = bufio.NewReader(f)
+		readers[f] = r
+	}
+	return r
+}
+
+// readLine reads up to and including the next newline, or returns nil at the
+// end of input, where Ruby's gets returns nil.
+func readLine(r *bufio.Reader) *string {
+	line, err := r.ReadString('\n')
+	if err != nil && line == "" {
+		return nil
+	}
+	return &line
+}
+
+func lines(next func() *string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for line := next(); line != nil; line = next() {
+			if !yield(*line) {
+				return
+			}
+		}
+	}
+}
+
+// Gets is IO#gets: the next line of f, newline included, or nil at the end.
+func Gets(f *os.File) *string {
+	return readLine(reader(f))
+}
+
+// EachLine is IO#each_line.
+func EachLine(f *os.File) iter.Seq[string] {
+	return lines(func() *string { return Gets(f) })
+}
+
+// ReadAll is IO#read with no length: the rest of f.
+func ReadAll(f *os.File) string {
+	data, err := io.ReadAll(reader(f))
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+// ReadLines is IO#readlines.
+func ReadLines(f *os.File) []string {
+	var all []string
+	for line := range EachLine(f) {
+		all = append(all, line)
+	}
+	return all
+}
+
+// ArgFiles is Ruby's ARGF: the files named on the command line read one
+// after another as a single stream, or standard input if none were named.
+// Like Kernel#gets, which reads from it, it looks at the arguments only
+// when first read.
+type ArgFiles struct {
+	paths   []string
+	file    *os.File
+	r       *bufio.Reader
+	started bool
+}
+
+var ARGF = &ArgFiles{}
+
+// current returns the reader for the file being read, or nil once every
+// file has been read.
+func (a *ArgFiles) current() *bufio.Reader {
+	if !a.started {
+		a.started = true
+		a.paths = os.Args[1:]
+		if len(a.paths) == 0 {
+			a.r = Stdin
+		} else {
+			a.advance()
+		}
+	}
+	return a.r
+}
+
+// advance closes the file being read and opens the next one.
+func (a *ArgFiles) advance() {
+	if a.file != nil {
+		a.file.Close()
+		a.file = nil
+	}
+	a.r = nil
+	if len(a.paths) == 0 {
+		return
+	}
+	f, err := os.Open(a.paths[0])
+	if err != nil {
+		panic(err)
+	}
+	a.paths = a.paths[1:]
+	a.file, a.r = f, bufio.NewReader(f)
+}
+
+func (a *ArgFiles) Gets() *string {
+	for r := a.current(); r != nil; r = a.current() {
+		if line := readLine(r); line != nil {
+			return line
+		}
+		if a.file == nil {
+			return nil
+		}
+		a.advance()
+	}
+	return nil
+}
+
+func (a *ArgFiles) EachLine() iter.Seq[string] {
+	return lines(a.Gets)
+}
+
+func (a *ArgFiles) ReadAll() string {
+	var b strings.Builder
+	for line := range a.EachLine() {
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+func (a *ArgFiles) ReadLines() []string {
+	var all []string
+	for line := range a.EachLine() {
+		all = append(all, line)
+	}
+	return all
+}
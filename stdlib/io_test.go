@@ -0,0 +1,53 @@
+package stdlib
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestGetsAndEachLine(t *testing.T) {
+	f, _ := os.CreateTemp("", "gets.test")
+	defer os.Remove(f.Name())
+	f.WriteString("first\nsecond\nthird")
+	f.Seek(0, 0)
+
+	if line := Gets(f); line == nil || *line != "first\n" {
+		t.Fatalf("expected the first line with its newline, got %v", line)
+	}
+	var rest []string
+	for line := range EachLine(f) {
+		rest = append(rest, line)
+	}
+	if !reflect.DeepEqual(rest, []string{"second\n", "third"}) {
+		t.Fatalf("expected each_line to pick up where gets left off, got %q", rest)
+	}
+	if line := Gets(f); line != nil {
+		t.Fatalf("expected nil at the end of input, got %q", *line)
+	}
+}
+
+func TestArgFiles(t *testing.T) {
+	var paths []string
+	for _, content := range []string{"a\nb\n", "", "c"} {
+		f, _ := os.CreateTemp("", "argf.test")
+		defer os.Remove(f.Name())
+		f.WriteString(content)
+		f.Close()
+		paths = append(paths, f.Name())
+	}
+	args := os.Args
+	defer func() { os.Args = args }()
+	os.Args = append([]string{"prog"}, paths...)
+
+	argf := &ArgFiles{}
+	if line := argf.Gets(); line == nil || *line != "a\n" {
+		t.Fatalf("expected the first line of the first file, got %v", line)
+	}
+	if lines := argf.ReadLines(); !reflect.DeepEqual(lines, []string{"b\n", "c"}) {
+		t.Fatalf("expected the files to be read as one stream, got %q", lines)
+	}
+	if line := argf.Gets(); line != nil {
+		t.Fatalf("expected nil once every file is read, got %q", *line)
+	}
+}
@@ -1,10 +1,45 @@
 package stdlib
 
 import (
+	"fmt"
 	"math"
 	"math/big"
+	"strconv"
+	"strings"
 )
 
+// Integer parses s as Kernel#Integer does. Surrounding whitespace, a sign
+// and underscores between digits are allowed, as is a 0b, 0o, 0d or 0x
+// prefix that agrees with base; with a base of 0 the prefix picks it, and a
+// bare leading 0 means octal. Anything else raises ArgumentError.
+func Integer(s string, base int) int {
+	digits := strings.TrimSpace(s)
+	sign := ""
+	if digits != "" && (digits[0] == '+' || digits[0] == '-') {
+		sign, digits = digits[:1], digits[1:]
+	}
+	if len(digits) > 2 && digits[0] == '0' {
+		prefixed, ok := map[byte]int{'b': 2, 'o': 8, 'd': 10, 'x': 16}[digits[1]|0x20]
+		if ok && (base == 0 || base == prefixed) {
+			base, digits = prefixed, digits[2:]
+		}
+	}
+	if base == 0 {
+		base = 10
+		if len(digits) > 1 && digits[0] == '0' {
+			base = 8
+		}
+	}
+	if digits == "" || digits[0] == '_' || strings.HasSuffix(digits, "_") || strings.Contains(digits, "__") {
+		panic(&ArgumentError{StandardError{RubyError{Msg: fmt.Sprintf("invalid value for Integer(): %q", s)}}})
+	}
+	n, err := strconv.ParseInt(sign+strings.ReplaceAll(digits, "_", ""), base, 0)
+	if err != nil {
+		panic(&ArgumentError{StandardError{RubyError{Msg: fmt.Sprintf("invalid value for Integer(): %q", s)}}})
+	}
+	return int(n)
+}
+
 // Abs returns the absolute value of an integer.
 func Abs(n int) int {
 	if n < 0 {
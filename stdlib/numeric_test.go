@@ -0,0 +1,40 @@
+package stdlib
+
+import "testing"
+
+func TestInteger(t *testing.T) {
+	tests := []struct {
+		s        string
+		base     int
+		expected int
+	}{
+		{"42", 0, 42},
+		{"  -42\n", 0, -42},
+		{"+1_000", 0, 1000},
+		{"0x1F", 0, 31},
+		{"0b101", 0, 5},
+		{"0o17", 0, 15},
+		{"017", 0, 15},
+		{"0d09", 0, 9},
+		{"0", 0, 0},
+		{"ff", 16, 255},
+		{"0xff", 16, 255},
+		{"101", 2, 5},
+	}
+	for i, tt := range tests {
+		if got := Integer(tt.s, tt.base); got != tt.expected {
+			t.Errorf("[%d] expected Integer(%q, %d) to be %d, got %d", i, tt.s, tt.base, tt.expected, got)
+		}
+	}
+	for i, s := range []string{"", "abc", "12abc", "1__0", "_1", "1_", "09", "0x", "- 1", "1.5"} {
+		func() {
+			defer func() {
+				err, ok := recover().(*ArgumentError)
+				if !ok || err.Error() != `invalid value for Integer(): "`+s+`"` {
+					t.Errorf("[%d] expected ArgumentError for %q, got %v", i, s, err)
+				}
+			}()
+			Integer(s, 0)
+		}()
+	}
+}
@@ -0,0 +1,44 @@
+package stdlib
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// ParallelMap calls f on each of items, at most GOMAXPROCS at a time, and
+// returns the results in the order of items. It is what
+// items.map { |i| Thread.new { ... } }.map(&:value) compiles to. If any call
+// raises, the error returned is that of the earliest item whose call raised,
+// which is the one Thread#value would have raised first in Ruby.
+func ParallelMap[T, R any](items []T, f func(T) R) ([]R, error) {
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer func() {
+				if r := recover(); r != nil {
+					if err, ok := r.(error); ok {
+						errs[i] = err
+					} else {
+						errs[i] = fmt.Errorf("%v", r)
+					}
+				}
+			}()
+			results[i] = f(item)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
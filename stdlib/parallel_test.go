@@ -0,0 +1,46 @@
+package stdlib
+
+import (
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMap(t *testing.T) {
+	items := []int{5, 1, 4, 2, 3}
+	results, err := ParallelMap(items, func(i int) int {
+		time.Sleep(time.Duration(i) * time.Millisecond)
+		return i * i
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(results, []int{25, 1, 16, 4, 9}) {
+		t.Fatalf("expected results in the order of the items, got %v", results)
+	}
+
+	var calls atomic.Int32
+	_, err = ParallelMap([]int{1, 2, 3, 4}, func(i int) string {
+		calls.Add(1)
+		if i%2 == 0 {
+			panic(&ArgumentError{StandardError{RubyError{Msg: "even"}}})
+		}
+		return "odd"
+	})
+	if _, ok := err.(*ArgumentError); !ok {
+		t.Fatalf("expected the ArgumentError raised for 2, got %v", err)
+	}
+	if calls.Load() != 4 {
+		t.Fatalf("expected every item to be mapped, got %d calls", calls.Load())
+	}
+
+	_, err = ParallelMap([]string{"a"}, func(s string) int {
+		var m map[string]int
+		m[s] = 1
+		return 0
+	})
+	if err == nil {
+		t.Fatal("expected a runtime panic to be returned as an error")
+	}
+}
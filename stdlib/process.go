@@ -0,0 +1,53 @@
+package stdlib
+
+import (
+	"os"
+	"os/exec"
+)
+
+// ProcessStatus is a Process::Status, the status of a command that a
+// backtick string or system ran.
+type ProcessStatus struct {
+	pid, status int
+}
+
+// LastStatus is $?, the status of the last command run, or nil before any
+// has been.
+var LastStatus *ProcessStatus
+
+func (s *ProcessStatus) Pid() int        { return s.pid }
+func (s *ProcessStatus) Exitstatus() int { return s.status }
+func (s *ProcessStatus) SuccessQ() bool  { return s.status == 0 }
+
+// recordStatus sets LastStatus from a command that has finished. A command
+// that couldn't be started exits 127, as it does from a shell.
+func recordStatus(cmd *exec.Cmd) {
+	LastStatus = &ProcessStatus{status: 127}
+	if cmd.ProcessState != nil {
+		LastStatus = &ProcessStatus{pid: cmd.ProcessState.Pid(), status: cmd.ProcessState.ExitCode()}
+	}
+}
+
+// Backtick runs the command named by the first of its words with the rest as
+// arguments, as a backtick or %x string does, and returns its standard output.
+func Backtick(words ...string) string {
+	cmd := exec.Command(words[0], words[1:]...)
+	output, _ := cmd.Output()
+	recordStatus(cmd)
+	return string(output)
+}
+
+// System runs a command as Kernel#system does, with the program's standard
+// streams: a single string is a shell command line, and more than one are
+// the program and its arguments. It returns whether the command exited 0,
+// and, unlike Ruby, false rather than nil if it couldn't be started.
+func System(command string, args ...string) bool {
+	cmd := exec.Command(command, args...)
+	if len(args) == 0 {
+		cmd = exec.Command("/bin/sh", "-c", command)
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err := cmd.Run()
+	recordStatus(cmd)
+	return err == nil
+}
@@ -0,0 +1,30 @@
+package stdlib
+
+import "testing"
+
+func TestProcessStatus(t *testing.T) {
+	tests := []struct {
+		run      func() any
+		expected any
+		status   int
+	}{
+		{func() any { return Backtick("echo", "hi") }, "hi\n", 0},
+		{func() any { return Backtick("false") }, "", 1},
+		{func() any { return Backtick("thanos-no-such-command") }, "", 127},
+		{func() any { return System("exit 3") }, false, 3},
+		{func() any { return System("true") }, true, 0},
+		{func() any { return System("test", "-n", "") }, false, 1},
+		{func() any { return System("thanos-no-such-command", "x") }, false, 127},
+	}
+	for i, tt := range tests {
+		if got := tt.run(); got != tt.expected {
+			t.Errorf("[%d] expected %q, got %q", i, tt.expected, got)
+		}
+		if LastStatus.Exitstatus() != tt.status || LastStatus.SuccessQ() != (tt.status == 0) {
+			t.Errorf("[%d] expected $? to have exited %d, got %+v", i, tt.status, *LastStatus)
+		}
+		if tt.status != 127 && LastStatus.Pid() == 0 {
+			t.Errorf("[%d] expected $? to have the command's pid", i)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package stdlib
+
+import "sync"
+
+// Thread is a Ruby Thread: a block running in its own goroutine. Whatever
+// the block raises is held until the thread is joined, and raised again in
+// the joining goroutine, as Ruby does.
+type Thread[T any] struct {
+	done  chan struct{}
+	value T
+	err   any
+}
+
+func NewThread[T any](f func() T) *Thread[T] {
+	t := &Thread[T]{done: make(chan struct{})}
+	go func() {
+		defer close(t.done)
+		defer func() { t.err = recover() }()
+		t.value = f()
+	}()
+	return t
+}
+
+// Go starts a thread for a block that isn't run for its value.
+func Go(f func()) *Thread[any] {
+	return NewThread(func() any {
+		f()
+		return nil
+	})
+}
+
+func (t *Thread[T]) Join() *Thread[T] {
+	<-t.done
+	if t.err != nil {
+		panic(t.err)
+	}
+	return t
+}
+
+func (t *Thread[T]) Value() T {
+	return t.Join().value
+}
+
+func (t *Thread[T]) Alive() bool {
+	select {
+	case <-t.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Queue is a Ruby Thread::Queue. It is unbounded, so pushing never blocks;
+// a pop on an empty queue waits on a channel of its own until a push hands
+// it a value or the queue is closed.
+type Queue[T any] struct {
+	mu      sync.Mutex
+	items   []T
+	waiters []chan *T
+	closed  bool
+}
+
+func NewQueue[T any]() *Queue[T] {
+	return &Queue[T]{}
+}
+
+func (q *Queue[T]) Push(v T) *Queue[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		panic(&ClosedQueueError{StopIteration{StandardError{RubyError{Msg: "queue closed"}}}})
+	}
+	if len(q.waiters) > 0 {
+		w := q.waiters[0]
+		q.waiters = q.waiters[1:]
+		w <- &v
+		return q
+	}
+	q.items = append(q.items, v)
+	return q
+}
+
+// Pop removes the value at the front of the queue, waiting for one if the
+// queue is empty. Once the queue is closed and empty, Pop returns nil, as
+// Ruby's does, so that a value that was pushed is never mistaken for the end.
+func (q *Queue[T]) Pop() *T {
+	q.mu.Lock()
+	if len(q.items) > 0 {
+		v := q.items[0]
+		q.items = q.items[1:]
+		q.mu.Unlock()
+		return &v
+	}
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	w := make(chan *T, 1)
+	q.waiters = append(q.waiters, w)
+	q.mu.Unlock()
+	return <-w
+}
+
+// TryPop is pop(true): it raises ThreadError rather than waiting when the
+// queue is empty.
+func (q *Queue[T]) TryPop() *T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		panic(&ThreadError{StandardError{RubyError{Msg: "queue empty"}}})
+	}
+	v := q.items[0]
+	q.items = q.items[1:]
+	return &v
+}
+
+// Close stops the queue taking new values. Pops waiting on an empty queue
+// return nil.
+func (q *Queue[T]) Close() *Queue[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	for _, w := range q.waiters {
+		close(w)
+	}
+	q.waiters = nil
+	return q
+}
+
+func (q *Queue[T]) Closed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+func (q *Queue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+func (q *Queue[T]) Empty() bool {
+	return q.Size() == 0
+}
+
+func (q *Queue[T]) Clear() *Queue[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items = nil
+	return q
+}
+
+func (q *Queue[T]) NumWaiting() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.waiters)
+}
+
+// ConditionVariable is a Ruby Thread::ConditionVariable. Unlike sync.Cond,
+// it isn't tied to one mutex: each Wait names the mutex it releases.
+type ConditionVariable struct {
+	mu      sync.Mutex
+	waiters []chan struct{}
+}
+
+func NewConditionVariable() *ConditionVariable {
+	return &ConditionVariable{}
+}
+
+// Wait unlocks m, waits to be signalled and locks m again.
+func (c *ConditionVariable) Wait(m *sync.Mutex) *ConditionVariable {
+	w := make(chan struct{})
+	c.mu.Lock()
+	c.waiters = append(c.waiters, w)
+	c.mu.Unlock()
+	m.Unlock()
+	<-w
+	m.Lock()
+	return c
+}
+
+func (c *ConditionVariable) Signal() *ConditionVariable {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.waiters) > 0 {
+		close(c.waiters[0])
+		c.waiters = c.waiters[1:]
+	}
+	return c
+}
+
+func (c *ConditionVariable) Broadcast() *ConditionVariable {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, w := range c.waiters {
+		close(w)
+	}
+	c.waiters = nil
+	return c
+}
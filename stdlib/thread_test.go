@@ -0,0 +1,86 @@
+package stdlib
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestThreadValueAndJoin(t *testing.T) {
+	th := NewThread(func() int { return 42 })
+	if v := th.Value(); v != 42 {
+		t.Fatalf("expected 42, got %d", v)
+	}
+	if th.Alive() {
+		t.Fatal("expected a joined thread not to be alive")
+	}
+
+	failing := Go(func() { panic(&ArgumentError{StandardError{RubyError{Msg: "bad"}}}) })
+	defer func() {
+		err, ok := recover().(*ArgumentError)
+		if !ok || err.Error() != "bad" {
+			t.Fatalf("expected join to raise the thread's ArgumentError, got %v", err)
+		}
+	}()
+	failing.Join()
+	t.Fatal("expected join to raise")
+}
+
+func TestQueue(t *testing.T) {
+	q := NewQueue[int]()
+	var got []int
+	consumer := NewThread(func() []int {
+		for v := q.Pop(); v != nil; v = q.Pop() {
+			got = append(got, *v)
+		}
+		return got
+	})
+	for i := 0; i < 5; i++ {
+		q.Push(i)
+	}
+	q.Close()
+	if v := consumer.Value(); !reflect.DeepEqual(v, []int{0, 1, 2, 3, 4}) {
+		t.Fatalf("expected values in the order pushed, got %v", v)
+	}
+	if !q.Closed() || !q.Empty() || q.Pop() != nil {
+		t.Fatal("expected the queue to be closed and empty, and to pop nil")
+	}
+
+	func() {
+		defer func() {
+			if _, ok := recover().(*ClosedQueueError); !ok {
+				t.Fatal("expected pushing to a closed queue to raise ClosedQueueError")
+			}
+		}()
+		q.Push(6)
+	}()
+	func() {
+		defer func() {
+			if _, ok := recover().(*ThreadError); !ok {
+				t.Fatal("expected a non-blocking pop of an empty queue to raise ThreadError")
+			}
+		}()
+		NewQueue[string]().TryPop()
+	}()
+}
+
+func TestConditionVariable(t *testing.T) {
+	var m sync.Mutex
+	cv := NewConditionVariable()
+	ready := false
+	waiter := NewThread(func() bool {
+		m.Lock()
+		defer m.Unlock()
+		for !ready {
+			cv.Wait(&m)
+		}
+		return ready
+	})
+	m.Lock()
+	ready = true
+	cv.Broadcast()
+	m.Unlock()
+	if !waiter.Value() {
+		t.Fatal("expected the waiter to see ready")
+	}
+}
@@ -29,3 +29,41 @@ gauntlet("multiple method calls") do
 
   puts add_one(double(3))
 end
+
+gauntlet("block-local variables shadow outer locals") do
+  acc = "outer"
+  total = 0
+  [1, 2, 3].each do |x; acc|
+    acc = x * 10
+    total += acc
+  end
+  puts acc
+  puts total
+end
+
+gauntlet("block-local variable assigned in a branch") do
+  note = "untouched"
+  [1, 2].each do |x; note|
+    if x.odd?
+      note = "odd"
+    else
+      note = "even"
+    end
+    puts note
+  end
+  puts note
+end
+
+gauntlet("block-local variable in a yielding method's block") do
+  def twice
+    yield 1
+    yield 2
+  end
+
+  label = "keep"
+  twice() do |n; label|
+    label = "n=#{n}"
+    puts label
+  end
+  puts label
+end
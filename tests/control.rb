@@ -206,6 +206,169 @@ gauntlet("raise and ensure") do
   end
 end
 
+gauntlet("rescue modifier on raising expression") do
+  def double(s)
+    raise ArgumentError, "not a number" if s == "x"
+    s.to_i * 2
+  end
+
+  n = double("x") rescue -1
+  puts n
+end
+
+gauntlet("rescue modifier on non-raising expression") do
+  def double(s)
+    raise ArgumentError, "not a number" if s == "x"
+    s.to_i * 2
+  end
+
+  n = double("21") rescue -1
+  puts n
+end
+
+gauntlet("rescue modifier with nil fallback") do
+  def double(s)
+    raise ArgumentError, "not a number" if s == "x"
+    s.to_i * 2
+  end
+
+  n = double("x") rescue nil
+  puts n.nil?
+end
+
+gauntlet("rescue modifier as statement") do
+  def check(s)
+    raise "bad input" if s == "x"
+    puts "ok: " + s
+  end
+
+  check("a") rescue puts("rescued a")
+  check("x") rescue puts("rescued x")
+end
+
+gauntlet("rescue modifier as method return value") do
+  def double(s)
+    raise ArgumentError, "not a number" if s == "x"
+    s.to_i * 2
+  end
+
+  def safe_double(s)
+    double(s) rescue 0
+  end
+
+  puts safe_double("x")
+  puts safe_double("5")
+end
+
+gauntlet("rescue modifier on Integer()") do
+  def parse(s)
+    Integer(s) rescue 0
+  end
+
+  puts parse("42")
+  puts parse(" -0x1f ")
+  puts parse("1_000")
+  puts parse("abc")
+  puts parse("12abc")
+  puts Integer("ff", 16)
+  puts Integer(3.9)
+end
+
+gauntlet("rescue modifier with next, break and return") do
+  def total(strs)
+    sum = 0
+    strs.each do |s|
+      n = Integer(s) rescue next
+      sum += n
+    end
+    sum
+  end
+
+  def leading(strs)
+    nums = []
+    for s in strs
+      nums << (Integer(s) rescue break)
+    end
+    nums
+  end
+
+  def double(s)
+    Integer(s) rescue return 0
+    Integer(s) * 2
+  end
+
+  puts total(["1", "x", "2"])
+  puts leading(["1", "2", "z", "3"]).join(",")
+  puts double("x")
+  puts double("4")
+end
+
+gauntlet("retry with a counter") do
+  attempts = 0
+  begin
+    attempts += 1
+    puts "attempt #{attempts}"
+    raise "flaky" if attempts < 3
+    puts "connected"
+  rescue
+    puts "failed"
+    retry if attempts < 3
+  ensure
+    puts "ensure ran after #{attempts} attempts"
+  end
+end
+
+gauntlet("retry typed rescue in method") do
+  def poll(responses)
+    calls = 0
+    begin
+      calls += 1
+      status = responses[calls - 1]
+      raise ArgumentError, "pending" if status == "pending"
+      puts "#{status} after #{calls} calls"
+    rescue ArgumentError
+      retry if calls < responses.length
+      puts "gave up after #{calls} calls"
+    end
+    calls
+  end
+
+  puts poll(["pending", "pending", "ready"])
+  puts poll(["pending", "pending"])
+end
+
+gauntlet("redo in while") do
+  i = 0
+  repeats = 0
+  while i < 3
+    i += 1
+    puts "pass #{i}"
+    if i == 3 && repeats < 2
+      repeats += 1
+      redo
+    end
+  end
+end
+
+gauntlet("redo in times") do
+  count = 0
+  3.times do |n|
+    count += 1
+    redo if count == 2
+    puts "iteration #{n} count #{count}"
+  end
+end
+
+gauntlet("redo in each") do
+  seen = []
+  [1, 2, 3].each do |x|
+    seen << x
+    redo if x == 2 && seen.length < 3
+    puts "item #{x}"
+  end
+  puts seen.length
+end
+
 gauntlet("lambda basic") do
   double = ->(x) { x * 2 }
   puts double.call(5)
@@ -0,0 +1,30 @@
+gauntlet("ENV lookups") do
+  puts ENV["THANOS_GAUNTLET_UNSET"].nil?
+  level = ENV["THANOS_GAUNTLET_UNSET"] || "info"
+  puts level
+  puts ENV.key?("THANOS_GAUNTLET_UNSET")
+  puts ENV["HOME"] == ENV.to_h["HOME"]
+end
+
+gauntlet("ENV.fetch") do
+  puts ENV.fetch("THANOS_GAUNTLET_UNSET", "default")
+  puts ENV.fetch("THANOS_GAUNTLET_UNSET") { |name| "no #{name}" }
+  puts ENV.fetch("THANOS_GAUNTLET_PORT", "8080").to_i + 1
+  begin
+    ENV.fetch("THANOS_GAUNTLET_UNSET")
+  rescue KeyError => e
+    puts e.message
+  end
+end
+
+gauntlet("setting ENV") do
+  ENV["THANOS_GAUNTLET_SET"] = "yes"
+  puts ENV["THANOS_GAUNTLET_SET"]
+  puts ENV.fetch("THANOS_GAUNTLET_SET")
+  puts ENV.include?("THANOS_GAUNTLET_SET")
+  ENV.each do |name, value|
+    puts "#{name}=#{value}" if name == "THANOS_GAUNTLET_SET"
+  end
+  ENV["THANOS_GAUNTLET_SET"] = nil
+  puts ENV.member?("THANOS_GAUNTLET_SET")
+end
@@ -0,0 +1,59 @@
+gauntlet("exit runs ensure and at_exit blocks") do
+  at_exit { puts "registered first, runs last" }
+  at_exit do
+    puts "registered last, runs first"
+  end
+  begin
+    puts "before exit"
+    exit
+  rescue => e
+    puts "bare rescue caught #{e.message}"
+  ensure
+    puts "ensure"
+  end
+  puts "after exit"
+end
+
+gauntlet("exit from a method") do
+  def check(n)
+    if n > 2
+      puts "done at #{n}"
+      exit(true)
+    end
+    n
+  end
+
+  at_exit { puts "exiting" }
+  [1, 2, 3, 4].each { |n| puts check(n) }
+end
+
+gauntlet("at_exit runs when main returns") do
+  at_exit { puts "goodbye" }
+  puts "hello"
+end
+
+gauntlet("sleep") do
+  puts sleep(0)
+  puts sleep(0.01)
+end
+
+gauntlet("Process.pid") do
+  pid = Process.pid
+  puts pid > 0
+  puts pid == Process.pid
+end
+
+gauntlet("$? after backticks and system") do
+  puts `echo hi`.chomp
+  puts $?.exitstatus
+  puts $?.success?
+  `false`
+  puts $?.exitstatus
+  puts $?.success?
+  puts $?.pid != Process.pid
+  puts system("exit 3")
+  puts $?.exitstatus
+  if system("test", "-n", "x") && $?.success?
+    puts "ran"
+  end
+end
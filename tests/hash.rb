@@ -420,3 +420,34 @@ gauntlet("Hash#shift") do
   puts "#{k}: #{v}"
   puts h.length
 end
+
+gauntlet("dynamic symbol keys") do
+  counts = {field_0: 0, field_1: 0, field_2: 0}
+  [1, 2, 3].each_with_index do |n, i|
+    counts[:"field_#{i}"] += n * 10
+  end
+  counts.each { |k, v| puts "#{k}: #{v}" }
+  key = :"field_#{1}"
+  puts key == :field_1
+  puts counts[key]
+end
+
+gauntlet("heterogeneous hash as struct") do
+  config = {name: "api", port: 80, tls: true}
+  config[:port] = 8443
+  puts config[:name]
+  puts config[:port] + 1
+  puts config[:tls]
+end
+
+gauntlet("array of heterogeneous hashes") do
+  def weighted(servers)
+    servers.select { |s| s[:weight] > 2 }.map { |s| s[:host] }
+  end
+
+  servers = [{host: "a", weight: 2}, {weight: 5, host: "b"}, {host: "c", weight: 3}]
+  puts weighted(servers).join(",")
+  servers.each do |s|
+    puts "#{s[:host]}=#{s[:weight]}"
+  end
+end
@@ -0,0 +1,38 @@
+gauntlet("writing to standard output") do
+  $stdout.sync = true
+  $stdout.puts "synced", 2, 1.5
+  STDOUT.print "a", "b", "\n"
+  $stdout.write("written\n")
+  $stdout << "chained" << "\n"
+  STDOUT.printf("%d-%s\n", 3, "z")
+  STDOUT.puts
+  puts $stdout.sync
+end
+
+gauntlet("writing to standard error") do
+  STDERR.puts "to stderr"
+  $stderr.print "also to stderr\n"
+  n = 2
+  $stderr.puts "line #{n}"
+  puts "to stdout"
+end
+
+gauntlet("gets at the end of input") do
+  count = 0
+  while line = gets
+    count += 1
+    puts "got #{line}"
+  end
+  puts count
+  puts $stdin.gets.nil?
+  puts "header: #{STDIN.gets}"
+end
+
+gauntlet("each_line and read at the end of input") do
+  STDIN.each_line { |line| puts line.upcase }
+  ARGF.each_line do |line|
+    print line
+  end
+  puts STDIN.read.empty?
+  puts ARGF.readlines.size
+end
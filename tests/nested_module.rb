@@ -56,3 +56,37 @@ gauntlet("module reopening add method") do
   puts Outer2::Inner2.first
   puts Outer2::Inner2.second
 end
+
+gauntlet("top-level constant references") do
+  SCALE = 10
+
+  class Base
+    def label
+      "base"
+    end
+  end
+
+  module Units
+    SCALE = 3
+
+    class ::Gadget < ::Base
+      def label
+        "gadget on " + super
+      end
+    end
+
+    class Metric
+      FACTOR = 6
+
+      def scaled(n)
+        n * FACTOR * ::Units::SCALE
+      end
+    end
+  end
+
+  puts ::SCALE
+  puts Units::SCALE
+  puts ::Units::Metric::FACTOR
+  puts Units::Metric.new.scaled(2)
+  puts Gadget.new.label
+end
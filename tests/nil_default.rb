@@ -37,3 +37,23 @@ gauntlet("optional return nil") do
 
   puts find_index([10, 20, 30], 99).nil?
 end
+
+gauntlet("nil arg at one call site, concrete at another") do
+  def pad(width)
+    width ||= 4
+    "x" * width
+  end
+
+  puts pad(nil)
+  puts pad(2)
+end
+
+gauntlet("concrete arg before nil arg") do
+  def label(name)
+    name ||= "anonymous"
+    "user: " + name
+  end
+
+  puts label("ada")
+  puts label(nil)
+end
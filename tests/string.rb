@@ -16,6 +16,20 @@ gauntlet("String#hex") do
   end
 end
 
+gauntlet("interpolated word arrays") do
+  prefix = "pre"
+  n = 3
+  words = %W[#{prefix}-a b c#{n}d #{"x y"}]
+  puts words.length
+  words.each { |w| puts w }
+end
+
+gauntlet("symbol word arrays") do
+  kind = "int"
+  %i[foo bar].each { |s| puts s }
+  %I[#{kind}_val #{kind}_ptr raw].each { |s| puts s }
+end
+
 gauntlet("String#split") do
   " now's  the time".split.each {|s| puts s}
   " now's  the time".split(' ').each {|s| puts s}
@@ -0,0 +1,136 @@
+gauntlet("threads share a mutex") do
+  count = 0
+  m = Mutex.new
+  threads = []
+  10.times do |i|
+    threads << Thread.new do
+      m.synchronize { count += i }
+    end
+  end
+  threads.each(&:join)
+  puts count
+end
+
+gauntlet("thread value") do
+  t = Thread.new(6) { |x| x * 7 }
+  puts t.value
+  puts t.alive?
+end
+
+gauntlet("synchronize value") do
+  m = Mutex.new
+  total = m.synchronize { 40 + 2 }
+  puts total
+end
+
+gauntlet("queue producer and consumer") do
+  q = Queue.new
+  producer = Thread.new do
+    [1, 2, 3, 4].each { |n| q.push(n * 10) }
+    q.close
+  end
+  sum = 0
+  while (v = q.pop)
+    sum += v
+  end
+  producer.join
+  puts sum
+  puts q.empty?
+  puts q.pop.nil?
+end
+
+gauntlet("queue consumer sees pushed zeros and empty strings") do
+  q = Queue.new
+  [0, 1, 0].each { |n| q << n }
+  q.close
+  consumer = Thread.new do
+    seen = []
+    while (n = q.pop)
+      seen << n
+    end
+    seen
+  end
+  puts consumer.value.length
+
+  words = Queue.new
+  words << ""
+  words << "b"
+  words.close
+  count = 0
+  while (w = words.shift)
+    count += 1
+    puts "[#{w}]"
+  end
+  puts count
+end
+
+gauntlet("queue errors") do
+  q = Queue.new
+  q << "a"
+  puts q.size
+  puts q.pop(true)
+  begin
+    q.pop(true)
+  rescue ThreadError => e
+    puts "empty: #{e.message}"
+  end
+  q.close
+  begin
+    q.push("b")
+  rescue ClosedQueueError => e
+    puts "closed: #{e.message}"
+  end
+end
+
+gauntlet("join raises the thread's exception") do
+  t = Thread.new do
+    raise ArgumentError, "boom"
+  end
+  begin
+    t.join
+  rescue ArgumentError => e
+    puts "joined: #{e.message}"
+  end
+end
+
+gauntlet("condition variable") do
+  m = Mutex.new
+  cv = ConditionVariable.new
+  ready = false
+  waiter = Thread.new do
+    m.synchronize do
+      cv.wait(m) until ready
+      puts "woke"
+    end
+  end
+  m.synchronize do
+    ready = true
+    cv.signal
+  end
+  waiter.join
+end
+
+gauntlet("map threads to their values") do
+  def slow_square(i)
+    i * i
+  end
+
+  items = [5, 3, 8, 1]
+  results = items.map { |i| Thread.new { slow_square(i) } }.map(&:value)
+  puts results.join(" ")
+  words = %w[x y]
+  puts words.map { |w| Thread.new { w * 3 } }.map { |t| t.value }.join(",")
+end
+
+gauntlet("map threads raises the first error") do
+  def check(i)
+    raise ArgumentError, "bad #{i}" if i > 1
+    i
+  end
+
+  begin
+    [1, 2, 3].map { |i| Thread.new { check(i) } }.map(&:value)
+  rescue ArgumentError => e
+    puts "raised: #{e.message}"
+  end
+end
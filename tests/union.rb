@@ -0,0 +1,85 @@
+gauntlet("union from branch assignments") do
+  def pick(flag)
+    if flag
+      x = 42
+    else
+      x = "forty-two"
+    end
+    x
+  end
+
+  puts pick(true)
+  puts pick(false)
+end
+
+gauntlet("union from conditional expression") do
+  def weight(metric)
+    metric ? 1.5 : 2
+  end
+
+  puts weight(true)
+  puts weight(false)
+end
+
+gauntlet("union reassigned on one path") do
+  def label(n)
+    x = n
+    x = "many" if n > 2
+    "got #{x}"
+  end
+
+  puts label(1)
+  puts label(5)
+end
+
+gauntlet("union method call dispatches on member") do
+  def size_of(flag)
+    v = flag ? 12345 : "abc"
+    v.to_s.length
+  end
+
+  puts size_of(true)
+  puts size_of(false)
+end
+
+gauntlet("union case when class") do
+  def describe(flag)
+    v = flag ? 7 : "seven"
+    case v
+    when Integer
+      n = v * 2
+      "int #{n}"
+    when String
+      "str #{v.upcase}"
+    end
+  end
+
+  puts describe(true)
+  puts describe(false)
+end
+
+gauntlet("union equality compares the matching member") do
+  def pick(flag)
+    flag ? 1 : "one"
+  end
+
+  a = pick(true)
+  b = pick(false)
+  puts a == 1
+  puts b == 1
+  puts b == "one"
+  puts a != 1
+  puts a == b
+  puts b == :one
+end
+
+gauntlet("locals in sibling blocks are separate") do
+  [1, 2].each do |i|
+    w = i * 2
+    puts w.abs
+  end
+  %w[a b].each do |s|
+    w = s + "!"
+    puts w.upcase
+  end
+end
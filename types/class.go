@@ -157,22 +157,23 @@ func (cr *classRegistry) MustGet(name string) *Class {
 	panic(fmt.Sprintf("Failed to find class %s", name))
 }
 
+// RegisterClass adds cls to the registry, linking it to its parent and any
+// registered class that names it as theirs, so that the ancestry chain is in
+// place whichever of the two is registered first.
 func (cr *classRegistry) RegisterClass(cls *Class) {
 	cr.Lock()
+	defer cr.Unlock()
 	cr.registry[cls.name] = cls
-	cr.Unlock()
-	go func() {
-		for cls.parent == nil && cls.parentName != "" {
-			//TODO probably use a WaitGroup instead so Analyze can be guaranteed that all ancestry chains are established
-			cr.Lock()
-			parent, found := cr.registry[cls.parentName]
-			cr.Unlock()
-			if found {
-				cls.parent = parent
-				parent.children = append(parent.children, cls)
-			}
+	if parent, found := cr.registry[cls.parentName]; found && cls.parent == nil {
+		cls.parent = parent
+		parent.children = append(parent.children, cls)
+	}
+	for _, child := range cr.registry {
+		if child != cls && child.parent == nil && child.parentName == cls.name {
+			child.parent = cls
+			cls.children = append(cls.children, child)
 		}
-	}()
+	}
 }
 
 func (cr *classRegistry) Initialize() error {
@@ -119,4 +119,6 @@ func (c Complex) Resolve(m string) (MethodSpec, bool) {
 	return c.proto.Resolve(m, false)
 }
 
+var ComplexClass = NewClass("Complex", "Numeric", nil, ClassRegistry)
+
 var ComplexType Type
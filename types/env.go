@@ -0,0 +1,176 @@
+package types
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/redneckbeard/thanos/bst"
+)
+
+// Env is the type of ENV. Its methods compile to calls on the os package, or
+// on helpers in stdlib where Ruby's ENV wants more than one call, so the
+// environment is read when a method is called and never copied. ENV on its
+// own is what ENV.to_h returns, a snapshot of the environment as a Hash.
+type Env struct {
+	*proto
+}
+
+var EnvType = Env{newProto("ENV", "Object", ClassRegistry)}
+
+func (t Env) Equals(t2 Type) bool { return t == t2 }
+func (t Env) String() string      { return "EnvType" }
+func (t Env) GoType() string      { return "*stdlib.OrderedMap[string, string]" }
+func (t Env) IsComposite() bool   { return false }
+
+func (t Env) MethodReturnType(m string, b Type, args []Type) (Type, error) {
+	return t.proto.MustResolve(m, false).ReturnType(t, b, args)
+}
+
+func (t Env) BlockArgTypes(m string, args []Type) []Type {
+	spec := t.proto.MustResolve(m, false)
+	return spec.BlockArgs(t, args)
+}
+
+func (t Env) TransformAST(m string, rcvr ast.Expr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+	return t.proto.MustResolve(m, false).TransformAST(TypeExpr{t, rcvr}, args, blk, it)
+}
+
+func (t Env) HasMethod(m string) bool {
+	return t.proto.HasMethod(m, false)
+}
+
+func (t Env) Resolve(m string) (MethodSpec, bool) {
+	return t.proto.Resolve(m, false)
+}
+
+func (t Env) MustResolve(m string) MethodSpec {
+	spec, ok := t.Resolve(m)
+	if !ok {
+		panic("Could not resolve method '" + m + "' on ENV")
+	}
+	return spec
+}
+
+func (t Env) GetMethodSpec(m string) (MethodSpec, bool) {
+	return t.Resolve(m)
+}
+
+func (t Env) Methods() map[string]MethodSpec {
+	return t.proto.Methods()
+}
+
+// envCall is an ENV method that calls the stdlib function goFunc with the
+// method's arguments.
+func envCall(goFunc string, returnType Type) MethodSpec {
+	return MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return returnType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{
+				Expr:    bst.Call("stdlib", goFunc, UnwrapTypeExprs(args)...),
+				Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
+			}
+		},
+	}
+}
+
+func init() {
+	EnvType.Def("[]", envCall("LookupEnv", NewOptional(StringType)))
+	EnvType.Def("key?", envCall("HasEnv", BoolType))
+	EnvType.proto.MakeAlias("key?", "has_key?", false)
+	EnvType.proto.MakeAlias("key?", "include?", false)
+	EnvType.proto.MakeAlias("key?", "member?", false)
+	EnvType.Def("to_h", envCall("EnvHash", NewHash(StringType, StringType)))
+
+	EnvType.Def("[]=", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			if len(args) != 2 || (args[1] != StringType && args[1] != NilType) {
+				return nil, fmt.Errorf("ENV values must be Strings")
+			}
+			return args[1], nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			// ENV["X"] = nil unsets X.
+			call := bst.Call("os", "Setenv", args[0].Expr, args[1].Expr)
+			if args[1].Type == NilType {
+				call = bst.Call("os", "Unsetenv", args[0].Expr)
+			}
+			return Transform{
+				Stmts:   []ast.Stmt{&ast.ExprStmt{X: call}},
+				Imports: []string{"os"},
+			}
+		},
+	})
+
+	EnvType.Def("fetch", MethodSpec{
+		blockArgs: func(r Type, args []Type) []Type {
+			return []Type{StringType}
+		},
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			if len(args) == 2 && args[1] != StringType {
+				return nil, fmt.Errorf("ENV.fetch default must be a String, not %s", args[1])
+			}
+			if b != nil && b != StringType {
+				return nil, fmt.Errorf("ENV.fetch block must return a String, not %s", b)
+			}
+			return StringType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			imports := []string{"github.com/redneckbeard/thanos/stdlib"}
+			if len(args) == 2 {
+				return Transform{
+					Expr:    bst.Call("stdlib", "OrDefault", bst.Call("stdlib", "LookupEnv", args[0].Expr), args[1].Expr),
+					Imports: imports,
+				}
+			}
+			var missing ast.Expr = it.Get("nil")
+			if blk != nil {
+				if len(blk.Args) == 0 {
+					blk.Args = []ast.Expr{it.Get("_")}
+				}
+				blk.ArgTypes = []Type{StringType}
+				blk.ReturnType = StringType
+				missing = blk.FuncLit(it)
+			}
+			return Transform{
+				Expr:    bst.Call("stdlib", "FetchEnv", args[0].Expr, missing),
+				Imports: imports,
+			}
+		},
+	})
+
+	EnvType.Def("each", MethodSpec{
+		blockArgs: func(r Type, args []Type) []Type {
+			return []Type{StringType, StringType}
+		},
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return r, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			stripBlockReturn(blk)
+			blankUnusedBlockArgs(blk)
+			loop := &ast.RangeStmt{
+				X:    bst.Call("stdlib", "Environ"),
+				Body: &ast.BlockStmt{List: blk.Statements},
+			}
+			// `for _, _ := range` declares nothing, so leave off blanks at the end
+			blank := func(e ast.Expr) bool {
+				ident, ok := e.(*ast.Ident)
+				return ok && ident.Name == "_"
+			}
+			if len(blk.Args) > 1 && !blank(blk.Args[1]) {
+				loop.Key, loop.Value, loop.Tok = blk.Args[0], blk.Args[1], token.DEFINE
+			} else if !blank(blk.Args[0]) {
+				loop.Key, loop.Tok = blk.Args[0], token.DEFINE
+			}
+			return Transform{
+				Expr:    rcvr.Expr,
+				Stmts:   []ast.Stmt{loop},
+				Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
+			}
+		},
+	})
+	EnvType.proto.MakeAlias("each", "each_pair", false)
+}
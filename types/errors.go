@@ -14,6 +14,8 @@ var ExceptionClasses = []string{
 	"NotImplementedError",
 	"StopIteration",
 	"RegexpError",
+	"ThreadError",
+	"ClosedQueueError",
 }
 
 // ExceptionParents maps each exception class to its parent for inheritance matching
@@ -30,6 +32,8 @@ var ExceptionParents = map[string]string{
 	"NotImplementedError": "StandardError",
 	"StopIteration":       "StandardError",
 	"RegexpError":         "StandardError",
+	"ThreadError":         "StandardError",
+	"ClosedQueueError":    "StopIteration",
 }
 
 func init() {
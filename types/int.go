@@ -274,6 +274,7 @@ func init() {
 			return BoolType, nil
 		},
 		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			stripBlockReturn(blk)
 			var blockVar ast.Expr
 			if len(blk.Args) == 1 {
 				blockVar = blk.Args[0]
@@ -0,0 +1,195 @@
+package types
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/redneckbeard/thanos/bst"
+)
+
+// IO is one of the standard streams, which are the only IO objects a program
+// gets without opening a File: STDIN, STDOUT and STDERR, and $stdin, $stdout
+// and $stderr, which name the same streams. They compile to os.Stdin,
+// os.Stdout and os.Stderr. Reads go through a buffered reader in stdlib that
+// gets, STDIN and ARGF share. ARGF, the files named on the command line read
+// as one stream, has the reading methods of an IO but is a stdlib.ArgFiles.
+type IO struct {
+	*proto
+	goType string
+}
+
+var (
+	IOType   = IO{newProto("IO", "Object", ClassRegistry), "*os.File"}
+	ARGFType = IO{newProto("ARGF", "Object", ClassRegistry), "*stdlib.ArgFiles"}
+)
+
+func (t IO) Equals(t2 Type) bool { return t == t2 }
+func (t IO) String() string      { return t.proto.class + "Type" }
+func (t IO) GoType() string      { return t.goType }
+func (t IO) IsComposite() bool   { return false }
+
+func (t IO) MethodReturnType(m string, b Type, args []Type) (Type, error) {
+	return t.proto.MustResolve(m, false).ReturnType(t, b, args)
+}
+
+func (t IO) BlockArgTypes(m string, args []Type) []Type {
+	spec := t.proto.MustResolve(m, false)
+	return spec.BlockArgs(t, args)
+}
+
+func (t IO) TransformAST(m string, rcvr ast.Expr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+	return t.proto.MustResolve(m, false).TransformAST(TypeExpr{t, rcvr}, args, blk, it)
+}
+
+func (t IO) HasMethod(m string) bool {
+	return t.proto.HasMethod(m, false)
+}
+
+func (t IO) Resolve(m string) (MethodSpec, bool) {
+	return t.proto.Resolve(m, false)
+}
+
+func (t IO) MustResolve(m string) MethodSpec {
+	spec, ok := t.Resolve(m)
+	if !ok {
+		panic("Could not resolve method '" + m + "' on " + t.proto.class)
+	}
+	return spec
+}
+
+func (t IO) GetMethodSpec(m string) (MethodSpec, bool) {
+	return t.Resolve(m)
+}
+
+func (t IO) Methods() map[string]MethodSpec {
+	return t.proto.Methods()
+}
+
+// ioRead is a reading method, which is a function in stdlib taking the
+// *os.File for an IO and a method on the stdlib.ArgFiles for ARGF.
+func ioRead(goName string, returnType Type) MethodSpec {
+	return MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return returnType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{
+				Expr:    ioReadCall(rcvr, goName),
+				Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
+			}
+		},
+	}
+}
+
+func ioReadCall(rcvr TypeExpr, goName string) *ast.CallExpr {
+	if rcvr.Type == ARGFType {
+		return bst.Call(rcvr.Expr, goName)
+	}
+	return bst.Call("stdlib", goName, rcvr.Expr)
+}
+
+// ioWrite is a writing method, which like Kernel's print and puts returns
+// nil.
+func ioWrite(write func(out ast.Expr, args []TypeExpr) Transform) MethodSpec {
+	return MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return NilType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return write(rcvr.Expr, args)
+		},
+	}
+}
+
+func printfTo(out ast.Expr, args []TypeExpr) Transform {
+	return Transform{
+		Stmts: []ast.Stmt{
+			&ast.ExprStmt{X: fprint(out, "Printf", UnwrapTypeExprs(args)...)},
+		},
+		Imports: []string{"fmt"},
+	}
+}
+
+func init() {
+	for _, t := range []IO{IOType, ARGFType} {
+		t.Def("gets", ioRead("Gets", NewOptional(StringType)))
+		t.Def("read", ioRead("ReadAll", StringType))
+		t.Def("readlines", ioRead("ReadLines", NewArray(StringType)))
+		t.Def("each_line", MethodSpec{
+			blockArgs: func(r Type, args []Type) []Type {
+				return []Type{StringType}
+			},
+			ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+				return r, nil
+			},
+			TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+				stripBlockReturn(blk)
+				return Transform{
+					Expr: rcvr.Expr,
+					Stmts: []ast.Stmt{
+						&ast.RangeStmt{
+							Key:  blk.Args[0],
+							Tok:  token.DEFINE,
+							X:    ioReadCall(rcvr, "EachLine"),
+							Body: &ast.BlockStmt{List: blk.Statements},
+						},
+					},
+					Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
+				}
+			},
+		})
+		t.proto.MakeAlias("each_line", "each", false)
+	}
+	IOType.Def("puts", ioWrite(putsTo))
+	IOType.Def("print", ioWrite(printTo))
+	IOType.Def("printf", ioWrite(printfTo))
+	IOType.Def("<<", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return r, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			t := printTo(rcvr.Expr, args)
+			t.Expr = rcvr.Expr
+			return t
+		},
+	})
+	// IO#write returns the number of bytes written, as fmt.Fprint does, so
+	// like File#write it is best used as a statement.
+	IOType.Def("write", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return IntType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{
+				Expr:    fprint(rcvr.Expr, "Print", UnwrapTypeExprs(args)...),
+				Imports: []string{"fmt"},
+			}
+		},
+	})
+	// Writes to an *os.File aren't buffered, so the stream is always in sync
+	// and there is never anything to flush.
+	IOType.Def("sync", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return BoolType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{Expr: it.Get("true")}
+		},
+	})
+	IOType.Def("sync=", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return BoolType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{Expr: args[0].Expr}
+		},
+	})
+	IOType.Def("flush", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return r, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{Expr: rcvr.Expr}
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 
@@ -43,6 +44,9 @@ func (t kernel) MustResolve(m string) MethodSpec {
 }
 
 func (t kernel) HasMethod(m string) bool {
+	if testingMethods[m] && !compilingTests {
+		return false
+	}
 	return t.proto.HasMethod(m, false)
 }
 
@@ -56,14 +60,7 @@ func init() {
 			return NilType, nil
 		},
 		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
-			return Transform{
-				Stmts: []ast.Stmt{
-					&ast.ExprStmt{
-						X: bst.Call("fmt", "Print", UnwrapTypeExprs(args)...),
-					},
-				},
-				Imports: []string{"fmt"},
-			}
+			return printTo(nil, args)
 		},
 	})
 	KernelType.Def("puts", MethodSpec{
@@ -71,42 +68,17 @@ func init() {
 			return NilType, nil
 		},
 		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
-			stmts := []ast.Stmt{}
-			imports := []string{"fmt"}
-			// `puts` inserts newlines after every argument, so we have one print function call for each here
-			for _, arg := range args {
-				// For any args that are interpolated strings, at this point we've
-				// already translated them to the appropriate C-style interpolated
-				// string. It would be weird in Go to call out to fmt.Sprintf for an
-				// arg to fmt.Println, so here we grab those nodes, change the method
-				// call to Printf, and insert a newline into the end of the string.
-				if call, ok := arg.Expr.(*ast.CallExpr); ok {
-					if fname, hasReceiver := call.Fun.(*ast.SelectorExpr); hasReceiver && fname.Sel.Name == "Sprintf" {
-						fname.Sel = it.Get("Printf")
-						lit := call.Args[0].(*ast.BasicLit)
-						lit.Value = lit.Value[:len(lit.Value)-1] + `\n"`
-						stmts = append(stmts, &ast.ExprStmt{X: call})
-						continue
-					}
-				}
-				printArg := arg.Expr
-				if _, isOpt := arg.Type.(Optional); isOpt {
-					printArg = &ast.StarExpr{X: arg.Expr}
-				}
-				if arg.Type == FloatType {
-					stmts = append(stmts, &ast.ExprStmt{
-						X: bst.Call("fmt", "Println", bst.Call("stdlib", "FormatFloat", printArg)),
-					})
-					imports = append(imports, "github.com/redneckbeard/thanos/stdlib")
-				} else {
-					stmts = append(stmts, &ast.ExprStmt{
-						X: bst.Call("fmt", "Println", printArg),
-					})
-				}
-			}
+			return putsTo(nil, args)
+		},
+	})
+	KernelType.Def("gets", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return NewOptional(StringType), nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
 			return Transform{
-				Stmts:   stmts,
-				Imports: imports,
+				Expr:    bst.Call(bst.Dot("stdlib", "ARGF"), "Gets"),
+				Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
 			}
 		},
 	})
@@ -219,6 +191,160 @@ func init() {
 			}
 		},
 	})
+	// exit and abort raise SystemExit rather than calling os.Exit, so that
+	// ensure blocks run as it unwinds; stdlib.Exited, deferred at the top of
+	// main, runs the at_exit blocks and then exits with its status.
+	KernelType.Def("exit", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return NilType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return exitTransform(bst.Call("stdlib", "Exit", exitStatus(args, 0)))
+		},
+	})
+	KernelType.Def("abort", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			if len(args) > 0 && args[0] != StringType {
+				return nil, fmt.Errorf("abort takes a String message, not %s", args[0])
+			}
+			return NilType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			if len(args) == 0 {
+				return exitTransform(bst.Call("stdlib", "Exit", bst.Int(1)))
+			}
+			return exitTransform(bst.Call("stdlib", "Abort", args[0].Expr))
+		},
+	})
+	KernelType.Def("at_exit", MethodSpec{
+		blockArgs: func(r Type, args []Type) []Type {
+			return []Type{}
+		},
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return NilType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			stripBlockReturn(blk)
+			blk.ReturnType = nil
+			return exitTransform(bst.Call("stdlib", "AtExit", blk.FuncLit(it)))
+		},
+	})
+	// exit! skips both, as in Ruby.
+	KernelType.Def("exit!", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return NilType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			status := exitStatus(args, 1)
+			imports := []string{"os"}
+			if _, lit := status.(*ast.BasicLit); !lit && args[0].Type == BoolType {
+				imports = append(imports, "github.com/redneckbeard/thanos/stdlib")
+			}
+			return Transform{
+				Stmts: []ast.Stmt{
+					&ast.ExprStmt{X: bst.Call("os", "Exit", status)},
+				},
+				Imports: imports,
+			}
+		},
+	})
+
+	KernelType.Def("system", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			if len(args) == 0 {
+				return nil, fmt.Errorf("system takes a command")
+			}
+			for _, arg := range args {
+				if arg != StringType {
+					return nil, fmt.Errorf("system takes String arguments, not %s", arg)
+				}
+			}
+			return BoolType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{
+				Expr:    bst.Call("stdlib", "System", UnwrapTypeExprs(args)...),
+				Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
+			}
+		},
+	})
+
+	KernelType.Def("sleep", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			if len(args) > 0 && args[0] != IntType && args[0] != FloatType {
+				return nil, fmt.Errorf("sleep takes a number of seconds, not %s", args[0])
+			}
+			return IntType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			// Without a duration, sleep never returns.
+			if len(args) == 0 {
+				return Transform{
+					Stmts: []ast.Stmt{&ast.SelectStmt{Body: &ast.BlockStmt{}}},
+					Expr:  bst.Int(0),
+				}
+			}
+			secs := args[0].Expr
+			var duration, slept ast.Expr
+			var imports []string
+			if args[0].Type == FloatType {
+				duration = bst.Call("time", "Duration", bst.Binary(secs, token.MUL, bst.Call(nil, "float64", bst.Dot("time", "Second"))))
+				slept = bst.Call(nil, "int", bst.Call("math", "Round", secs))
+				imports = []string{"time", "math"}
+			} else {
+				duration = bst.Binary(bst.Call("time", "Duration", secs), token.MUL, bst.Dot("time", "Second"))
+				slept = secs
+				imports = []string{"time"}
+			}
+			return Transform{
+				Stmts:   []ast.Stmt{&ast.ExprStmt{X: bst.Call("time", "Sleep", duration)}},
+				Expr:    slept,
+				Imports: imports,
+			}
+		},
+	})
+
+	// Integer parses Strings strictly, raising ArgumentError where to_i would
+	// return 0, so that `Integer(s) rescue 0` falls back.
+	KernelType.Def("Integer", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			if len(args) == 0 || len(args) > 2 {
+				return nil, fmt.Errorf("Integer takes a value and an optional base, got %d arguments", len(args))
+			}
+			switch args[0] {
+			case StringType:
+				if len(args) == 2 && args[1] != IntType {
+					return nil, fmt.Errorf("Integer takes an Integer base, not %s", args[1])
+				}
+			case IntType, FloatType:
+				if len(args) == 2 {
+					return nil, fmt.Errorf("Integer takes a base only for a String, not %s", args[0])
+				}
+			default:
+				return nil, fmt.Errorf("Integer can't convert %s to an Integer", args[0])
+			}
+			return IntType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			switch args[0].Type {
+			case IntType:
+				return Transform{Expr: args[0].Expr}
+			case FloatType:
+				return Transform{
+					Expr:    &ast.CallExpr{Fun: it.Get("int"), Args: []ast.Expr{bst.Call("math", "Trunc", args[0].Expr)}},
+					Imports: []string{"math"},
+				}
+			}
+			var base ast.Expr = bst.Int(0)
+			if len(args) == 2 {
+				base = args[1].Expr
+			}
+			return Transform{
+				Expr:    bst.Call("stdlib", "Integer", args[0].Expr, base),
+				Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
+			}
+		},
+	})
 
 	KernelType.Def("block_given?", MethodSpec{
 		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
@@ -233,8 +359,14 @@ func init() {
 
 // buildExceptionLiteral creates &stdlib.ClassName{...{RubyError: stdlib.RubyError{Msg: msg}}}
 func buildExceptionLiteral(className string, msgExpr ast.Expr, it bst.IdentTracker) ast.Expr {
-	// Build the nested struct literal chain based on the exception hierarchy
-	innermost := &ast.CompositeLit{
+	// Build the nested struct literal chain based on the exception hierarchy,
+	// e.g. for ArgumentError, whose parent is StandardError:
+	// &stdlib.ArgumentError{StandardError: stdlib.StandardError{RubyError: stdlib.RubyError{Msg: msg}}}
+	chain := []string{className}
+	for parent, ok := ExceptionParents[className]; ok; parent, ok = ExceptionParents[parent] {
+		chain = append(chain, parent)
+	}
+	var lit ast.Expr = &ast.CompositeLit{
 		Type: bst.Dot("stdlib", "RubyError"),
 		Elts: []ast.Expr{
 			&ast.KeyValueExpr{
@@ -243,46 +375,112 @@ func buildExceptionLiteral(className string, msgExpr ast.Expr, it bst.IdentTrack
 			},
 		},
 	}
-
-	parent, hasParent := ExceptionParents[className]
-	if !hasParent {
-		// It's StandardError itself or unknown — wrap directly with RubyError
-		return &ast.UnaryExpr{
-			Op: token.AND,
-			X: &ast.CompositeLit{
-				Type: bst.Dot("stdlib", className),
-				Elts: []ast.Expr{
-					&ast.KeyValueExpr{
-						Key:   it.Get("RubyError"),
-						Value: innermost,
-					},
+	field := "RubyError"
+	for i := len(chain) - 1; i >= 0; i-- {
+		lit = &ast.CompositeLit{
+			Type: bst.Dot("stdlib", chain[i]),
+			Elts: []ast.Expr{
+				&ast.KeyValueExpr{
+					Key:   it.Get(field),
+					Value: lit,
 				},
 			},
 		}
+		field = chain[i]
 	}
+	return &ast.UnaryExpr{Op: token.AND, X: lit}
+}
 
-	// For types like ArgumentError whose parent is StandardError:
-	// &stdlib.ArgumentError{StandardError: stdlib.StandardError{RubyError: stdlib.RubyError{Msg: msg}}}
-	parentLit := &ast.CompositeLit{
-		Type: bst.Dot("stdlib", parent),
-		Elts: []ast.Expr{
-			&ast.KeyValueExpr{
-				Key:   it.Get("RubyError"),
-				Value: innermost,
-			},
+// printTo prints args as `print` does, to out, or to standard output if out
+// is nil.
+func printTo(out ast.Expr, args []TypeExpr) Transform {
+	return Transform{
+		Stmts: []ast.Stmt{
+			&ast.ExprStmt{X: fprint(out, "Print", UnwrapTypeExprs(args)...)},
 		},
+		Imports: []string{"fmt"},
 	}
+}
 
-	return &ast.UnaryExpr{
-		Op: token.AND,
-		X: &ast.CompositeLit{
-			Type: bst.Dot("stdlib", className),
-			Elts: []ast.Expr{
-				&ast.KeyValueExpr{
-					Key:   it.Get(parent),
-					Value: parentLit,
-				},
-			},
-		},
+// putsTo prints args as `puts` does, to out, or to standard output if out is
+// nil.
+func putsTo(out ast.Expr, args []TypeExpr) Transform {
+	stmts := []ast.Stmt{}
+	imports := []string{"fmt"}
+	// `puts` inserts newlines after every argument, so we have one print function call for each here
+	for _, arg := range args {
+		// For any args that are interpolated strings, at this point we've
+		// already translated them to the appropriate C-style interpolated
+		// string. It would be weird in Go to call out to fmt.Sprintf for an
+		// arg to fmt.Println, so here we grab those nodes, change the method
+		// call to Printf, and insert a newline into the end of the string.
+		if call, ok := arg.Expr.(*ast.CallExpr); ok {
+			if fname, hasReceiver := call.Fun.(*ast.SelectorExpr); hasReceiver && fname.Sel.Name == "Sprintf" {
+				lit := call.Args[0].(*ast.BasicLit)
+				lit.Value = lit.Value[:len(lit.Value)-1] + `\n"`
+				stmts = append(stmts, &ast.ExprStmt{X: fprint(out, "Printf", call.Args...)})
+				continue
+			}
+		}
+		printArg := arg.Expr
+		if _, isOpt := arg.Type.(Optional); isOpt {
+			printArg = &ast.StarExpr{X: arg.Expr}
+		}
+		if arg.Type == FloatType {
+			stmts = append(stmts, &ast.ExprStmt{
+				X: fprint(out, "Println", bst.Call("stdlib", "FormatFloat", printArg)),
+			})
+			imports = append(imports, "github.com/redneckbeard/thanos/stdlib")
+		} else {
+			stmts = append(stmts, &ast.ExprStmt{
+				X: fprint(out, "Println", printArg),
+			})
+		}
+	}
+	if len(args) == 0 {
+		stmts = append(stmts, &ast.ExprStmt{X: fprint(out, "Println")})
+	}
+	return Transform{
+		Stmts:   stmts,
+		Imports: imports,
+	}
+}
+
+// fprint calls fmt.Print, Printf or Println, or their Fprint counterparts if
+// out is not nil.
+func fprint(out ast.Expr, fn string, args ...ast.Expr) *ast.CallExpr {
+	if out == nil {
+		return bst.Call("fmt", fn, args...)
+	}
+	return bst.Call("fmt", "Fp"+fn[1:], append([]ast.Expr{out}, args...)...)
+}
+
+// exitTransform is a statement that exit, abort or at_exit compiles to, which
+// needs stdlib.Exited deferred in main.
+func exitTransform(call ast.Expr) Transform {
+	return Transform{
+		Stmts:    []ast.Stmt{&ast.ExprStmt{X: call}},
+		Imports:  []string{"github.com/redneckbeard/thanos/stdlib"},
+		Deferred: []ast.Expr{bst.Call("stdlib", "Exited")},
+	}
+}
+
+// exitStatus is the status an exit call's arguments ask for: an Integer, or
+// true or false for 0 or 1, defaulting to status.
+func exitStatus(args []TypeExpr, status int) ast.Expr {
+	if len(args) == 0 {
+		return bst.Int(status)
+	}
+	if args[0].Type != BoolType {
+		return args[0].Expr
+	}
+	if ident, ok := args[0].Expr.(*ast.Ident); ok {
+		switch ident.Name {
+		case "true":
+			return bst.Int(0)
+		case "false":
+			return bst.Int(1)
+		}
 	}
+	return bst.Call("stdlib", "ExitStatus", args[0].Expr)
 }
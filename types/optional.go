@@ -92,6 +92,8 @@ func init() {
 		}
 	}
 	OptionalClass.Instance.Def("&&", optLogical(token.LAND))
+	OptionalClass.Instance.Def("==", optEquality(token.EQL))
+	OptionalClass.Instance.Def("!=", optEquality(token.NEQ))
 
 	OptionalClass.Instance.Def("||", MethodSpec{
 		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
@@ -122,3 +124,43 @@ func init() {
 		},
 	})
 }
+
+// optEquality compares an Optional without dereferencing a nil pointer: nil
+// is checked against the pointer itself, and a value of the element type is
+// compared with the element's own operator only once the pointer is non-nil.
+// Anything else falls back to Object's ==.
+func optEquality(tok token.Token) MethodSpec {
+	return MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return BoolType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			opt := rcvr.Type.(Optional)
+			if args[0].Type == NilType {
+				return Transform{Expr: bst.Binary(rcvr.Expr, tok, it.Get("nil"))}
+			}
+			if !args[0].Type.Equals(opt.Element) || !opt.Element.HasMethod("==") {
+				eq := ObjectType.MustResolve("==").TransformAST(rcvr, args, blk, it)
+				if tok == token.NEQ {
+					eq.Expr = &ast.UnaryExpr{Op: token.NOT, X: eq.Expr}
+				}
+				return eq
+			}
+			deref := &ast.StarExpr{X: rcvr.Expr}
+			if tok == token.EQL {
+				eq := opt.Element.TransformAST("==", deref, args, blk, it)
+				eq.Expr = bst.Binary(bst.Binary(rcvr.Expr, token.NEQ, it.Get("nil")), token.LAND, eq.Expr)
+				return eq
+			}
+			var neq Transform
+			if opt.Element.HasMethod("!=") {
+				neq = opt.Element.TransformAST("!=", deref, args, blk, it)
+			} else {
+				neq = opt.Element.TransformAST("==", deref, args, blk, it)
+				neq.Expr = &ast.UnaryExpr{Op: token.NOT, X: neq.Expr}
+			}
+			neq.Expr = bst.Binary(bst.Binary(rcvr.Expr, token.EQL, it.Get("nil")), token.LOR, neq.Expr)
+			return neq
+		},
+	}
+}
@@ -6,14 +6,43 @@ import (
 	"github.com/redneckbeard/thanos/bst"
 )
 
-var PredefinedConstants = map[string]struct {
+type Predefined struct {
 	Type    Type
 	Expr    ast.Expr
 	Imports []string
-}{
+}
+
+var PredefinedConstants = map[string]Predefined{
 	"ARGV": {
 		Type:    NewArray(StringType),
-		Expr:    bst.Dot("os", "Args"),
+		Expr:    &ast.SliceExpr{X: bst.Dot("os", "Args"), Low: bst.Int(1)},
 		Imports: []string{"os"},
 	},
+	"STDIN":  {Type: IOType, Expr: bst.Dot("os", "Stdin"), Imports: []string{"os"}},
+	"STDOUT": {Type: IOType, Expr: bst.Dot("os", "Stdout"), Imports: []string{"os"}},
+	"STDERR": {Type: IOType, Expr: bst.Dot("os", "Stderr"), Imports: []string{"os"}},
+	"ENV": {
+		Type:    EnvType,
+		Expr:    bst.Call("stdlib", "EnvHash"),
+		Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
+	},
+	"ARGF": {
+		Type:    ARGFType,
+		Expr:    bst.Dot("stdlib", "ARGF"),
+		Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
+	},
+}
+
+// PredefinedGlobals are the global variables Ruby starts a program with,
+// keyed by name without the `$`. Unlike globals the program defines, they
+// aren't declared in the output.
+var PredefinedGlobals = map[string]Predefined{
+	"stdin":  PredefinedConstants["STDIN"],
+	"stdout": PredefinedConstants["STDOUT"],
+	"stderr": PredefinedConstants["STDERR"],
+	"?": {
+		Type:    ProcessStatusType,
+		Expr:    bst.Dot("stdlib", "LastStatus"),
+		Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
+	},
 }
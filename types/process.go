@@ -0,0 +1,70 @@
+package types
+
+import (
+	"go/ast"
+
+	"github.com/redneckbeard/thanos/bst"
+	"github.com/redneckbeard/thanos/stdlib"
+)
+
+// Process has only pid; $? is a Process::Status, the stdlib.LastStatus that
+// backtick strings and system record.
+
+type processStatus struct {
+	*proto
+}
+
+var ProcessStatusType = processStatus{newProto("Process::Status", "Object", ClassRegistry)}
+
+var ProcessStatusClass = NewClass("Process::Status", "Object", ProcessStatusType, ClassRegistry)
+
+var ProcessClass = NewClass("Process", "Object", nil, ClassRegistry)
+
+func (t processStatus) Equals(t2 Type) bool { return t == t2 }
+func (t processStatus) String() string      { return "Process::Status" }
+func (t processStatus) GoType() string      { return "*stdlib.ProcessStatus" }
+func (t processStatus) IsComposite() bool   { return false }
+
+func (t processStatus) MethodReturnType(m string, b Type, args []Type) (Type, error) {
+	return t.proto.MustResolve(m, false).ReturnType(t, b, args)
+}
+
+func (t processStatus) BlockArgTypes(m string, args []Type) []Type {
+	return t.proto.MustResolve(m, false).blockArgs(t, args)
+}
+
+func (t processStatus) TransformAST(m string, rcvr ast.Expr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+	return t.proto.MustResolve(m, false).TransformAST(TypeExpr{Expr: rcvr, Type: t}, args, blk, it)
+}
+
+func (t processStatus) Resolve(m string) (MethodSpec, bool) {
+	return t.proto.Resolve(m, false)
+}
+
+func (t processStatus) MustResolve(m string) MethodSpec {
+	return t.proto.MustResolve(m, false)
+}
+
+func (t processStatus) HasMethod(m string) bool {
+	return t.proto.HasMethod(m, false)
+}
+
+func (t processStatus) Alias(existingMethod, newMethod string) {
+	t.proto.MakeAlias(existingMethod, newMethod, false)
+}
+
+func init() {
+	ProcessStatusType.GenerateMethods(&stdlib.ProcessStatus{})
+
+	ProcessClass.Def("pid", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return IntType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{
+				Expr:    bst.Call("os", "Getpid"),
+				Imports: []string{"os"},
+			}
+		},
+	})
+}
@@ -71,6 +71,7 @@ type Transform struct {
 	Expr       ast.Expr
 	Imports    []string
 	Finalizers []ast.Stmt // appended to end of main (e.g., http.ListenAndServe)
+	Deferred   []ast.Expr // stdlib calls deferred once at the top of main (e.g., stdlib.Exited())
 }
 
 type proto struct {
@@ -151,4 +151,6 @@ func (r Rational) Resolve(m string) (MethodSpec, bool) {
 	return r.proto.Resolve(m, false)
 }
 
+var RationalClass = NewClass("Rational", "Numeric", nil, ClassRegistry)
+
 var RationalType Type
@@ -1272,6 +1272,14 @@ func init() {
 		},
 	})
 	// `String#to_r`
+	StringType.Def("to_s", MethodSpec{
+		ReturnType: func(receiverType Type, blockReturnType Type, args []Type) (Type, error) {
+			return StringType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{Expr: rcvr.Expr}
+		},
+	})
 	// `String#to_str`
 	// `String#to_sym`
 	StringType.Def("tr", MethodSpec{
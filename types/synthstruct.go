@@ -13,11 +13,15 @@ import (
 type SynthField struct {
 	Name string // "Field0", "Field1", etc.
 	Type Type
+	Key  string // symbol key the field was synthesized from, for hash literals
 }
 
 // SynthStruct is a synthesized Go struct type created when Ruby code uses
 // heterogeneous array literals (Tuples) as elements of a homogeneous array.
 // For example, `links[k] = [prev, i, j]` produces a struct with three fields.
+// Symbol-keyed hash literals with mixed value types produce keyed structs
+// instead: `{name: "x", port: 80}` has fields Name and Port, and `h[:port]`
+// compiles to `h.Port`.
 type SynthStruct struct {
 	Name       string       // "LinksEntry"
 	Fields     []SynthField
@@ -41,6 +45,21 @@ func (s *SynthStruct) Equals(t2 Type) bool {
 	return false
 }
 
+// Keyed reports whether the struct was synthesized from a hash literal.
+func (s *SynthStruct) Keyed() bool {
+	return len(s.Fields) > 0 && s.Fields[0].Key != ""
+}
+
+// FieldByKey returns the field synthesized from the given symbol key.
+func (s *SynthStruct) FieldByKey(key string) (SynthField, bool) {
+	for _, f := range s.Fields {
+		if f.Key != "" && f.Key == key {
+			return f, true
+		}
+	}
+	return SynthField{}, false
+}
+
 func (s *SynthStruct) HasMethod(m string) bool {
 	switch m {
 	case "[]", "[]=", "nil?":
@@ -98,11 +117,11 @@ func (s *SynthStruct) bracketAccessSpec() MethodSpec {
 			return s.MethodReturnType("[]", blockReturnType, args)
 		},
 		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
-			if idx, ok := constIntExpr(args[0].Expr); ok && idx >= 0 && idx < len(s.Fields) {
+			if f, ok := s.constField(args[0].Expr); ok {
 				return Transform{
 					Expr: &ast.SelectorExpr{
 						X:   rcvr.Expr,
-						Sel: it.Get(s.Fields[idx].Name),
+						Sel: it.Get(f.Name),
 					},
 				}
 			}
@@ -123,13 +142,13 @@ func (s *SynthStruct) bracketAssignSpec() MethodSpec {
 		},
 		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
 			// args[0] = index, args[1] = value
-			if idx, ok := constIntExpr(args[0].Expr); ok && idx >= 0 && idx < len(s.Fields) {
+			if f, ok := s.constField(args[0].Expr); ok {
 				return Transform{
 					Stmts: []ast.Stmt{
 						bst.Assign(
 							&ast.SelectorExpr{
 								X:   rcvr.Expr,
-								Sel: it.Get(s.Fields[idx].Name),
+								Sel: it.Get(f.Name),
 							},
 							args[1].Expr,
 						),
@@ -160,6 +179,23 @@ func (s *SynthStruct) nilCheckSpec() MethodSpec {
 	}
 }
 
+// constField resolves a constant index (for tuple structs) or symbol key (for
+// hash structs, where symbols have compiled to string literals) to a field.
+func (s *SynthStruct) constField(expr ast.Expr) (SynthField, bool) {
+	if s.Keyed() {
+		if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if key, err := strconv.Unquote(lit.Value); err == nil {
+				return s.FieldByKey(key)
+			}
+		}
+		return SynthField{}, false
+	}
+	if idx, ok := constIntExpr(expr); ok && idx >= 0 && idx < len(s.Fields) {
+		return s.Fields[idx], true
+	}
+	return SynthField{}, false
+}
+
 // constIntType checks if a Type represents a known constant integer.
 // This is used during type inference where we only have Type info, not AST nodes.
 func constIntType(t Type) (int, bool) {
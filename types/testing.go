@@ -0,0 +1,495 @@
+package types
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/redneckbeard/thanos/bst"
+)
+
+// The Kernel methods below compile Minitest and RSpec suites, once the parser
+// has normalized them into describe/it blocks, into Go subtests. Every test
+// body has the *testing.T of its subtest in scope as t, and assertions report
+// their failures to it with Minitest's messages.
+
+// Setup and teardown code is bracketed by markers in the normalized Ruby, so
+// that it runs in the scope of the describe block where its locals are
+// visible to the examples. The describe transform then moves it into each
+// example.
+const (
+	SetupMarker       = "__setup__"
+	EndSetupMarker    = "__end_setup__"
+	TeardownMarker    = "__teardown__"
+	EndTeardownMarker = "__end_teardown__"
+)
+
+// examples are the bodies of the subtests compiled from `it` blocks, into
+// which enclosing describe blocks move their setup and teardown.
+var examples = map[*ast.BlockStmt]bool{}
+
+var testingT = ast.NewIdent("t")
+
+// testingMethods are the names of the Kernel methods below. They only exist
+// while a test suite is being compiled, so that any other program is free to
+// define its own describe or assert.
+var (
+	testingMethods = map[string]bool{}
+	compilingTests bool
+)
+
+// CompileTests makes the test DSL available to the program being compiled,
+// or, with false, takes it away again. Either way it forgets the examples of
+// any suite compiled before.
+func CompileTests(on bool) {
+	compilingTests = on
+	examples = map[*ast.BlockStmt]bool{}
+}
+
+func defTesting(name string, spec MethodSpec) {
+	testingMethods[name] = true
+	KernelType.Def(name, spec)
+}
+
+// subtest returns t.Run(name, func(t *testing.T) { body }).
+func subtest(name ast.Expr, body *ast.BlockStmt) ast.Stmt {
+	return &ast.ExprStmt{
+		X: bst.Call(testingT, "Run", name, &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{List: []*ast.Field{{
+					Names: []*ast.Ident{testingT},
+					Type:  &ast.StarExpr{X: bst.Dot("testing", "T")},
+				}}},
+			},
+			Body: body,
+		}),
+	}
+}
+
+// testBody returns the statements of a test block without the return of its
+// final expression, which no test needs.
+func testBody(blk *Block) []ast.Stmt {
+	stmts := blk.Statements
+	if len(stmts) == 0 {
+		return stmts
+	}
+	ret, ok := stmts[len(stmts)-1].(*ast.ReturnStmt)
+	if !ok {
+		return stmts
+	}
+	stmts = stmts[:len(stmts)-1]
+	for _, result := range ret.Results {
+		if _, isCall := result.(*ast.CallExpr); isCall {
+			stmts = append(stmts, &ast.ExprStmt{X: result})
+		}
+	}
+	return stmts
+}
+
+func markerStmt(marker string) Transform {
+	return Transform{Stmts: []ast.Stmt{&ast.LabeledStmt{Label: ast.NewIdent(marker), Stmt: &ast.EmptyStmt{}}}}
+}
+
+func isMarker(stmt ast.Stmt) (string, bool) {
+	if labeled, ok := stmt.(*ast.LabeledStmt); ok {
+		switch labeled.Label.Name {
+		case SetupMarker, EndSetupMarker, TeardownMarker, EndTeardownMarker:
+			return labeled.Label.Name, true
+		}
+	}
+	return "", false
+}
+
+// distributeSetup takes the setup and teardown code out of the statements of
+// a describe block and puts it around the body of every example inside it.
+func distributeSetup(stmts []ast.Stmt) []ast.Stmt {
+	var setup, teardown, rest []ast.Stmt
+	current := &rest
+	for _, stmt := range stmts {
+		if marker, ok := isMarker(stmt); ok {
+			switch marker {
+			case SetupMarker:
+				current = &setup
+			case TeardownMarker:
+				current = &teardown
+			default:
+				current = &rest
+			}
+			continue
+		}
+		*current = append(*current, stmt)
+	}
+	if len(setup) == 0 && len(teardown) == 0 {
+		return rest
+	}
+	for _, stmt := range rest {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			if body, ok := n.(*ast.BlockStmt); ok && examples[body] {
+				list := append(append([]ast.Stmt{}, setup...), unusedLocals(setup, body.List, teardown)...)
+				body.List = append(append(list, body.List...), teardown...)
+			}
+			return true
+		})
+	}
+	return rest
+}
+
+// unusedLocals returns `_ = x` for each local that setup declares and the
+// rest of an example doesn't use, which Go would reject.
+func unusedLocals(setup, body, teardown []ast.Stmt) []ast.Stmt {
+	used := map[string]bool{}
+	for _, stmts := range [][]ast.Stmt{body, teardown} {
+		for _, stmt := range stmts {
+			ast.Inspect(stmt, func(n ast.Node) bool {
+				if ident, ok := n.(*ast.Ident); ok {
+					used[ident.Name] = true
+				}
+				return true
+			})
+		}
+	}
+	var blanks []ast.Stmt
+	for i, stmt := range setup {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE {
+			continue
+		}
+		for _, lhs := range assign.Lhs {
+			ident, ok := lhs.(*ast.Ident)
+			if !ok || ident.Name == "_" || used[ident.Name] {
+				continue
+			}
+			laterUse := false
+			for _, later := range setup[i+1:] {
+				ast.Inspect(later, func(n ast.Node) bool {
+					if id, ok := n.(*ast.Ident); ok && id.Name == ident.Name {
+						laterUse = true
+					}
+					return true
+				})
+			}
+			if !laterUse {
+				blanks = append(blanks, bst.Assign(ast.NewIdent("_"), ast.NewIdent(ident.Name)))
+			}
+		}
+	}
+	return blanks
+}
+
+// verb picks the fmt verb that shows a value the way inspect would.
+func verb(t Type) string {
+	if t == StringType {
+		return "%q"
+	}
+	return "%v"
+}
+
+// failure returns t.Errorf(format, args...), prefixed with the message an
+// assertion was given, if any.
+func failure(msg []TypeExpr, format string, args ...ast.Expr) ast.Stmt {
+	if len(msg) > 0 {
+		format = "%s.\n" + format
+		args = append([]ast.Expr{msg[0].Expr}, args...)
+	}
+	return &ast.ExprStmt{X: bst.Call(testingT, "Errorf", append([]ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(format)}}, args...)...)}
+}
+
+func check(cond ast.Expr, fail ast.Stmt) ast.Stmt {
+	return &ast.IfStmt{Cond: cond, Body: &ast.BlockStmt{List: []ast.Stmt{fail}}}
+}
+
+// once binds each value that an assertion both tests and reports to a
+// variable named for it, so that a call is only made once, and returns the
+// definition for the if statement's init alongside the values to use in its
+// place. Identifiers and literals are used as they are.
+func once(it bst.IdentTracker, names []string, xs ...TypeExpr) (ast.Stmt, []TypeExpr) {
+	var lhs, rhs []ast.Expr
+	bound := make([]TypeExpr, len(xs))
+	for i, x := range xs {
+		bound[i] = x
+		switch x.Expr.(type) {
+		case *ast.Ident, *ast.BasicLit:
+			continue
+		}
+		v := it.New(names[i])
+		lhs, rhs = append(lhs, v), append(rhs, x.Expr)
+		bound[i].Expr = v
+	}
+	if len(lhs) == 0 {
+		return nil, bound
+	}
+	return bst.Define(lhs, rhs), bound
+}
+
+// checkOnce is check with the definition once returned as the init.
+func checkOnce(init ast.Stmt, cond ast.Expr, fail ast.Stmt) ast.Stmt {
+	stmt := check(cond, fail).(*ast.IfStmt)
+	stmt.Init = init
+	return stmt
+}
+
+func not(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident, *ast.CallExpr, *ast.ParenExpr, *ast.SelectorExpr:
+		return &ast.UnaryExpr{Op: token.NOT, X: e}
+	}
+	return &ast.UnaryExpr{Op: token.NOT, X: &ast.ParenExpr{X: expr}}
+}
+
+// nilable reports whether values of t can be nil in Go.
+func nilable(t Type) bool {
+	if _, ok := t.(Optional); ok {
+		return true
+	}
+	goType := t.GoType()
+	return t == AnyType || t == NilType || strings.HasPrefix(goType, "*") || goType == "interface{}" || goType == "error"
+}
+
+// equality returns the comparison of want and got, and the imports it needs.
+func equality(want, got TypeExpr) (ast.Expr, []string) {
+	if opt, ok := got.Type.(Optional); ok && !nilable(want.Type) {
+		return bst.Binary(bst.Binary(got.Expr, token.NEQ, ast.NewIdent("nil")), token.LAND, bst.Binary(&ast.StarExpr{X: got.Expr}, token.EQL, want.Expr)), nil
+	} else if got.Type.IsComposite() || want.Type.IsComposite() || (opt.Element != nil && opt.Element.IsComposite()) {
+		return bst.Call("reflect", "DeepEqual", want.Expr, got.Expr), []string{"reflect"}
+	}
+	return bst.Binary(want.Expr, token.EQL, got.Expr), nil
+}
+
+// predicate compiles a call to a predicate method of the receiver, like
+// include? for assert_includes.
+func predicate(rcvr TypeExpr, method string, args []TypeExpr, it bst.IdentTracker) (Transform, error) {
+	if !rcvr.Type.HasMethod(method) {
+		return Transform{}, fmt.Errorf("%s has no method '%s'", rcvr.Type, method)
+	}
+	return rcvr.Type.TransformAST(method, rcvr.Expr, args, nil, it), nil
+}
+
+// assertion defines a Kernel assertion that checks its arguments with the
+// statements compile returns.
+func assertion(name string, compile func(args []TypeExpr, blk *Block, it bst.IdentTracker) ([]ast.Stmt, []string)) {
+	defTesting(name, MethodSpec{
+		blockArgs: func(r Type, args []Type) []Type {
+			return []Type{}
+		},
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return NilType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			stmts, imports := compile(args, blk, it)
+			return Transform{Stmts: stmts, Imports: imports}
+		},
+	})
+}
+
+func init() {
+	for _, name := range []string{"describe", "it"} {
+		example := name == "it"
+		defTesting(name, MethodSpec{
+			blockArgs: func(r Type, args []Type) []Type {
+				return []Type{}
+			},
+			ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+				return NilType, nil
+			},
+			TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+				body := &ast.BlockStmt{List: distributeSetup(testBody(blk))}
+				if example {
+					examples[body] = true
+				}
+				return Transform{
+					Stmts:   []ast.Stmt{subtest(args[0].Expr, body)},
+					Imports: []string{"testing"},
+				}
+			},
+		})
+	}
+	for _, marker := range []string{SetupMarker, EndSetupMarker, TeardownMarker, EndTeardownMarker} {
+		marker := marker
+		defTesting(marker, MethodSpec{
+			ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+				return NilType, nil
+			},
+			TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+				return markerStmt(marker)
+			},
+		})
+	}
+
+	assertion("assert_equal", func(args []TypeExpr, blk *Block, it bst.IdentTracker) ([]ast.Stmt, []string) {
+		init, bound := once(it, []string{"want", "got"}, args[0], args[1])
+		want, got := bound[0], bound[1]
+		eq, imports := equality(want, got)
+		return []ast.Stmt{checkOnce(init, not(eq), failure(args[2:], "Expected: "+verb(want.Type)+"\n  Actual: "+verb(got.Type), want.Expr, got.Expr))}, imports
+	})
+	assertion("refute_equal", func(args []TypeExpr, blk *Block, it bst.IdentTracker) ([]ast.Stmt, []string) {
+		init, bound := once(it, []string{"want", "got"}, args[0], args[1])
+		want, got := bound[0], bound[1]
+		eq, imports := equality(want, got)
+		return []ast.Stmt{checkOnce(init, eq, failure(args[2:], "Expected "+verb(got.Type)+" to not be equal to "+verb(want.Type)+".", got.Expr, want.Expr))}, imports
+	})
+	assertion("assert", func(args []TypeExpr, blk *Block, it bst.IdentTracker) ([]ast.Stmt, []string) {
+		x := args[0]
+		switch {
+		case x.Type == BoolType:
+			return []ast.Stmt{check(not(x.Expr), failure(args[1:], "Expected false to be truthy."))}, nil
+		case nilable(x.Type):
+			return []ast.Stmt{check(bst.Binary(x.Expr, token.EQL, ast.NewIdent("nil")), failure(args[1:], "Expected nil to be truthy."))}, nil
+		}
+		// Anything but false and nil is truthy.
+		return []ast.Stmt{bst.Assign(ast.NewIdent("_"), x.Expr)}, nil
+	})
+	assertion("refute", func(args []TypeExpr, blk *Block, it bst.IdentTracker) ([]ast.Stmt, []string) {
+		x := args[0]
+		switch {
+		case x.Type == BoolType:
+			return []ast.Stmt{check(x.Expr, failure(args[1:], "Expected true to not be truthy."))}, nil
+		case nilable(x.Type):
+			init, bound := once(it, []string{"got"}, x)
+			x := bound[0]
+			return []ast.Stmt{checkOnce(init, bst.Binary(x.Expr, token.NEQ, ast.NewIdent("nil")), failure(args[1:], "Expected %v to not be truthy.", x.Expr))}, nil
+		}
+		return []ast.Stmt{failure(args[1:], "Expected "+verb(x.Type)+" to not be truthy.", x.Expr)}, nil
+	})
+	assertion("assert_nil", func(args []TypeExpr, blk *Block, it bst.IdentTracker) ([]ast.Stmt, []string) {
+		x := args[0]
+		if !nilable(x.Type) {
+			return []ast.Stmt{failure(args[1:], "Expected "+verb(x.Type)+" to be nil.", x.Expr)}, nil
+		}
+		init, bound := once(it, []string{"got"}, x)
+		x = bound[0]
+		return []ast.Stmt{checkOnce(init, bst.Binary(x.Expr, token.NEQ, ast.NewIdent("nil")), failure(args[1:], "Expected %v to be nil.", x.Expr))}, nil
+	})
+	assertion("refute_nil", func(args []TypeExpr, blk *Block, it bst.IdentTracker) ([]ast.Stmt, []string) {
+		x := args[0]
+		if !nilable(x.Type) {
+			return []ast.Stmt{bst.Assign(ast.NewIdent("_"), x.Expr)}, nil
+		}
+		return []ast.Stmt{check(bst.Binary(x.Expr, token.EQL, ast.NewIdent("nil")), failure(args[1:], "Expected nil to not be nil."))}, nil
+	})
+	for _, pred := range []struct{ assert, refute, method, expected string }{
+		{"assert_includes", "refute_includes", "include?", "include"},
+		{"assert_empty", "refute_empty", "empty?", "be empty"},
+		{"assert_match", "refute_match", "match?", "match"},
+	} {
+		pred := pred
+		// assert_match takes the pattern first; the others, the receiver.
+		split := func(args []TypeExpr) (TypeExpr, []TypeExpr, []TypeExpr) {
+			switch pred.method {
+			case "empty?":
+				return args[0], nil, args[1:]
+			case "match?":
+				return args[1], args[:1], args[2:]
+			}
+			return args[0], args[1:2], args[2:]
+		}
+		message := func(rcvr TypeExpr, predArgs []TypeExpr, negated bool) (string, []ast.Expr) {
+			format := "Expected " + verb(rcvr.Type)
+			if negated {
+				format += " to not " + pred.expected
+			} else {
+				format += " to " + pred.expected
+			}
+			fmtArgs := []ast.Expr{rcvr.Expr}
+			for _, a := range predArgs {
+				format += " " + verb(a.Type)
+				fmtArgs = append(fmtArgs, a.Expr)
+			}
+			return format + ".", fmtArgs
+		}
+		for _, negated := range []bool{false, true} {
+			negated := negated
+			name := pred.assert
+			if negated {
+				name = pred.refute
+			}
+			assertion(name, func(args []TypeExpr, blk *Block, it bst.IdentTracker) ([]ast.Stmt, []string) {
+				rcvr, predArgs, msg := split(args)
+				names := []string{"got"}
+				for range predArgs {
+					names = append(names, "want")
+				}
+				init, bound := once(it, names, append([]TypeExpr{rcvr}, predArgs...)...)
+				rcvr, predArgs = bound[0], bound[1:]
+				transform, err := predicate(rcvr, pred.method, predArgs, it)
+				if err != nil {
+					panic(fmt.Sprintf("%s: %s", name, err))
+				}
+				cond := transform.Expr
+				if !negated {
+					cond = not(cond)
+				}
+				format, fmtArgs := message(rcvr, predArgs, negated)
+				stmts := transform.Stmts
+				if len(stmts) > 0 && init != nil {
+					stmts, init = append([]ast.Stmt{init}, stmts...), nil
+				}
+				return append(stmts, checkOnce(init, cond, failure(msg, format, fmtArgs...))), transform.Imports
+			})
+		}
+	}
+	assertion("assert_in_delta", func(args []TypeExpr, blk *Block, it bst.IdentTracker) ([]ast.Stmt, []string) {
+		delta := TypeExpr{Type: FloatType, Expr: &ast.BasicLit{Kind: token.FLOAT, Value: "0.001"}}
+		if len(args) > 2 {
+			delta = args[2]
+		}
+		init, bound := once(it, []string{"want", "got", "delta"}, args[0], args[1], delta)
+		want, got := bound[0], bound[1]
+		float := func(x TypeExpr) ast.Expr {
+			if x.Type == FloatType {
+				return x.Expr
+			}
+			return bst.Call(nil, "float64", x.Expr)
+		}
+		diff := bst.Call("math", "Abs", bst.Binary(float(want), token.SUB, float(got)))
+		return []ast.Stmt{checkOnce(init, bst.Binary(diff, token.GTR, bound[2].Expr), failure(args[min(len(args), 3):], "Expected |%v - %v| to be <= %v.", want.Expr, got.Expr, bound[2].Expr))}, []string{"math"}
+	})
+	// assert_raises runs its block and checks that it raised one of the given
+	// exception classes, or anything at all if there are none.
+	assertion("assert_raises", func(args []TypeExpr, blk *Block, it bst.IdentTracker) ([]ast.Stmt, []string) {
+		var classes []ast.Expr
+		var names, msg []TypeExpr
+		for _, arg := range args {
+			if class, ok := arg.Type.(*Class); ok {
+				classes = append(classes, &ast.StarExpr{X: bst.Dot("stdlib", class.name)})
+				names = append(names, arg)
+			} else {
+				msg = append(msg, arg)
+			}
+		}
+		expected := "StandardError"
+		if len(names) > 0 {
+			var classNames []string
+			for _, name := range names {
+				classNames = append(classNames, name.Type.(*Class).name)
+			}
+			expected = strings.Join(classNames, ", ")
+		}
+		r := ast.NewIdent("r")
+		raised := &ast.IfStmt{
+			Cond: bst.Binary(r, token.EQL, ast.NewIdent("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{failure(msg, expected+" expected but nothing was raised.")}},
+		}
+		var imports []string
+		if len(classes) > 0 {
+			raised.Else = &ast.BlockStmt{List: []ast.Stmt{&ast.TypeSwitchStmt{
+				Assign: &ast.ExprStmt{X: &ast.TypeAssertExpr{X: r}},
+				Body: &ast.BlockStmt{List: []ast.Stmt{
+					&ast.CaseClause{List: classes},
+					&ast.CaseClause{Body: []ast.Stmt{failure(msg, "["+expected+"] exception expected, not %v.", r)}},
+				}},
+			}}}
+			imports = append(imports, "github.com/redneckbeard/thanos/stdlib")
+		}
+		handler := &ast.FuncLit{
+			Type: &ast.FuncType{Params: &ast.FieldList{}},
+			Body: &ast.BlockStmt{List: []ast.Stmt{bst.Define(r, bst.Call(nil, "recover")), raised}},
+		}
+		body := append([]ast.Stmt{&ast.DeferStmt{Call: &ast.CallExpr{Fun: handler}}}, testBody(blk)...)
+		return []ast.Stmt{&ast.ExprStmt{X: &ast.CallExpr{Fun: &ast.FuncLit{
+			Type: &ast.FuncType{Params: &ast.FieldList{}},
+			Body: &ast.BlockStmt{List: body},
+		}}}}, imports
+	})
+}
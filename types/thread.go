@@ -0,0 +1,445 @@
+package types
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/redneckbeard/thanos/bst"
+)
+
+// Thread, Queue and ConditionVariable compile to the types of the same names
+// in stdlib; Mutex is a sync.Mutex. A thread is a goroutine, and its block a
+// closure, so locals it uses from outside the block are shared with the code
+// that started it just as they are in Ruby.
+
+type Thread struct {
+	Value    Type
+	Instance instance
+}
+
+var ThreadClass = NewClass("Thread", "Object", nil, ClassRegistry)
+
+func NewThread(value Type) Type {
+	return Thread{Value: value, Instance: ThreadClass.Instance}
+}
+
+func (t Thread) Equals(t2 Type) bool { return t == t2 }
+func (t Thread) String() string      { return fmt.Sprintf("Thread(%s)", t.Value) }
+func (t Thread) GoType() string      { return fmt.Sprintf("*stdlib.Thread[%s]", t.valueGoType()) }
+func (t Thread) IsComposite() bool   { return true }
+func (t Thread) Outer() Type         { return Thread{} }
+func (t Thread) Inner() Type         { return t.Value }
+func (t Thread) ClassName() string   { return "Thread" }
+func (t Thread) IsMultiple() bool    { return false }
+
+// valueGoType is the type parameter of the stdlib.Thread: threads whose block
+// isn't run for its value are started with stdlib.Go.
+func (t Thread) valueGoType() string {
+	if t.Value == nil || t.Value == NilType {
+		return "any"
+	}
+	return t.Value.GoType()
+}
+
+func (t Thread) HasMethod(m string) bool {
+	return t.Instance.HasMethod(m)
+}
+
+func (t Thread) MethodReturnType(m string, b Type, args []Type) (Type, error) {
+	return t.Instance.MustResolve(m).ReturnType(t, b, args)
+}
+
+func (t Thread) BlockArgTypes(m string, args []Type) []Type {
+	spec := t.Instance.MustResolve(m)
+	return spec.BlockArgs(t, args)
+}
+
+func (t Thread) TransformAST(m string, rcvr ast.Expr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+	return t.Instance.MustResolve(m).TransformAST(TypeExpr{t, rcvr}, args, blk, it)
+}
+
+func (t Thread) Resolve(m string) (MethodSpec, bool) {
+	return t.Instance.Resolve(m)
+}
+
+func (t Thread) GetMethodSpec(m string) (MethodSpec, bool) {
+	return t.Instance.Resolve(m)
+}
+
+func (t Thread) MustResolve(m string) MethodSpec {
+	return t.Instance.MustResolve(m)
+}
+
+type Queue struct {
+	Element  Type
+	Instance instance
+}
+
+var QueueClass = NewClass("Queue", "Object", nil, ClassRegistry)
+
+func NewQueue(inner Type) Type {
+	return Queue{Element: inner, Instance: QueueClass.Instance}
+}
+
+func (t Queue) Equals(t2 Type) bool { return t == t2 }
+func (t Queue) String() string      { return fmt.Sprintf("Queue(%s)", t.Element) }
+func (t Queue) GoType() string      { return fmt.Sprintf("*stdlib.Queue[%s]", t.Element.GoType()) }
+func (t Queue) IsComposite() bool   { return true }
+func (t Queue) Outer() Type         { return Queue{} }
+func (t Queue) Inner() Type         { return t.Element }
+func (t Queue) ClassName() string   { return "Queue" }
+func (t Queue) IsMultiple() bool    { return false }
+
+func (t Queue) HasMethod(m string) bool {
+	return t.Instance.HasMethod(m)
+}
+
+func (t Queue) MethodReturnType(m string, b Type, args []Type) (Type, error) {
+	return t.Instance.MustResolve(m).ReturnType(t, b, args)
+}
+
+func (t Queue) BlockArgTypes(m string, args []Type) []Type {
+	spec := t.Instance.MustResolve(m)
+	return spec.BlockArgs(t, args)
+}
+
+func (t Queue) TransformAST(m string, rcvr ast.Expr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+	return t.Instance.MustResolve(m).TransformAST(TypeExpr{t, rcvr}, args, blk, it)
+}
+
+func (t Queue) Resolve(m string) (MethodSpec, bool) {
+	return t.Instance.Resolve(m)
+}
+
+func (t Queue) GetMethodSpec(m string) (MethodSpec, bool) {
+	return t.Instance.Resolve(m)
+}
+
+func (t Queue) MustResolve(m string) MethodSpec {
+	return t.Instance.MustResolve(m)
+}
+
+type Mutex struct {
+	*proto
+}
+
+var MutexType = Mutex{newProto("Mutex", "Object", ClassRegistry)}
+
+var MutexClass = NewClass("Mutex", "Object", MutexType, ClassRegistry)
+
+func (t Mutex) Equals(t2 Type) bool { return t == t2 }
+func (t Mutex) String() string      { return "MutexType" }
+func (t Mutex) GoType() string      { return "*sync.Mutex" }
+func (t Mutex) IsComposite() bool   { return false }
+
+func (t Mutex) MethodReturnType(m string, b Type, args []Type) (Type, error) {
+	return t.proto.MustResolve(m, false).ReturnType(t, b, args)
+}
+
+func (t Mutex) BlockArgTypes(m string, args []Type) []Type {
+	spec := t.proto.MustResolve(m, false)
+	return spec.BlockArgs(t, args)
+}
+
+func (t Mutex) TransformAST(m string, rcvr ast.Expr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+	return t.proto.MustResolve(m, false).TransformAST(TypeExpr{t, rcvr}, args, blk, it)
+}
+
+func (t Mutex) HasMethod(m string) bool {
+	return t.proto.HasMethod(m, false)
+}
+
+func (t Mutex) Resolve(m string) (MethodSpec, bool) {
+	return t.proto.Resolve(m, false)
+}
+
+func (t Mutex) MustResolve(m string) MethodSpec {
+	spec, ok := t.Resolve(m)
+	if !ok {
+		panic("Could not resolve method '" + m + "' on Mutex")
+	}
+	return spec
+}
+
+func (t Mutex) GetMethodSpec(m string) (MethodSpec, bool) {
+	return t.Resolve(m)
+}
+
+func (t Mutex) Methods() map[string]MethodSpec {
+	return t.proto.Methods()
+}
+
+type ConditionVariable struct {
+	*proto
+}
+
+var ConditionVariableType = ConditionVariable{newProto("ConditionVariable", "Object", ClassRegistry)}
+
+var ConditionVariableClass = NewClass("ConditionVariable", "Object", ConditionVariableType, ClassRegistry)
+
+func (t ConditionVariable) Equals(t2 Type) bool { return t == t2 }
+func (t ConditionVariable) String() string      { return "ConditionVariableType" }
+func (t ConditionVariable) GoType() string      { return "*stdlib.ConditionVariable" }
+func (t ConditionVariable) IsComposite() bool   { return false }
+
+func (t ConditionVariable) MethodReturnType(m string, b Type, args []Type) (Type, error) {
+	return t.proto.MustResolve(m, false).ReturnType(t, b, args)
+}
+
+func (t ConditionVariable) BlockArgTypes(m string, args []Type) []Type {
+	spec := t.proto.MustResolve(m, false)
+	return spec.BlockArgs(t, args)
+}
+
+func (t ConditionVariable) TransformAST(m string, rcvr ast.Expr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+	return t.proto.MustResolve(m, false).TransformAST(TypeExpr{t, rcvr}, args, blk, it)
+}
+
+func (t ConditionVariable) HasMethod(m string) bool {
+	return t.proto.HasMethod(m, false)
+}
+
+func (t ConditionVariable) Resolve(m string) (MethodSpec, bool) {
+	return t.proto.Resolve(m, false)
+}
+
+func (t ConditionVariable) MustResolve(m string) MethodSpec {
+	spec, ok := t.Resolve(m)
+	if !ok {
+		panic("Could not resolve method '" + m + "' on ConditionVariable")
+	}
+	return spec
+}
+
+func (t ConditionVariable) GetMethodSpec(m string) (MethodSpec, bool) {
+	return t.Resolve(m)
+}
+
+func (t ConditionVariable) Methods() map[string]MethodSpec {
+	return t.proto.Methods()
+}
+
+// threadCall is a method that calls goMethod on the receiver and returns
+// whatever returnType gives for it.
+func threadCall(goMethod string, returnType func(r Type) Type) MethodSpec {
+	return MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return returnType(r), nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{
+				Expr: bst.Call(rcvr.Expr, goMethod, UnwrapTypeExprs(args)...),
+			}
+		},
+	}
+}
+
+func receiver(r Type) Type { return r }
+
+func returns(t Type) func(Type) Type {
+	return func(Type) Type { return t }
+}
+
+// mutexCall is a Mutex method run for its effect on the mutex: the call is a
+// statement, and the expression is the mutex.
+func mutexCall(goMethod string) MethodSpec {
+	return MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return r, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{
+				Stmts: []ast.Stmt{&ast.ExprStmt{X: bst.Call(rcvr.Expr, goMethod)}},
+				Expr:  rcvr.Expr,
+			}
+		},
+	}
+}
+
+// queuePush refines the element type of a queue made with Queue.new, the way
+// Array#<< does for an empty array.
+var queuePush = MethodSpec{
+	ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+		q := r.(Queue)
+		if len(args) != 1 {
+			return nil, fmt.Errorf("Queue#push takes one argument but got %d", len(args))
+		}
+		if q.Element == AnyType {
+			return NewQueue(args[0]), nil
+		}
+		if args[0] != q.Element {
+			return nil, fmt.Errorf("Tried to push %s onto %s", args[0], q)
+		}
+		return r, nil
+	},
+	RefineVariable: func(receiverName string, newType Type, scope interface{}) {
+		if scopeChain, ok := scope.(interface{ RefineVariableType(string, Type) bool }); ok {
+			scopeChain.RefineVariableType(receiverName, newType)
+		}
+	},
+	TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+		return Transform{
+			Expr: bst.Call(rcvr.Expr, "Push", args[0].Expr),
+		}
+	},
+}
+
+func init() {
+	ThreadClass.Instance.Def("initialize", MethodSpec{
+		blockArgs: func(r Type, args []Type) []Type {
+			return args
+		},
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			if b == nil {
+				return nil, fmt.Errorf("Thread.new must be called with a block")
+			}
+			return NewThread(b), nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			thread := NewThread(blk.ReturnType).(Thread)
+			var start ast.Expr
+			if thread.valueGoType() == "any" {
+				stripBlockReturn(blk)
+				blk.ReturnType = nil
+				start = bst.Call("stdlib", "Go", withoutParams(blk.FuncLit(it)))
+			} else {
+				start = bst.Call("stdlib", "NewThread", withoutParams(blk.FuncLit(it)))
+			}
+			if len(args) > 0 {
+				// Thread.new(a, b) { |x, y| ... } evaluates its arguments before
+				// the thread starts, so they're passed to a function that starts
+				// it rather than read by the goroutine later on.
+				starter := blk.FuncLit(it)
+				starter.Type.Results = &ast.FieldList{List: []*ast.Field{{Type: it.Get(thread.GoType())}}}
+				starter.Body = &ast.BlockStmt{List: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{start}}}}
+				start = &ast.CallExpr{Fun: starter, Args: UnwrapTypeExprs(args)}
+			}
+			return Transform{
+				Expr:    start,
+				Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
+			}
+		},
+	})
+	ThreadClass.Instance.Alias("initialize", "start")
+	ThreadClass.Instance.Alias("initialize", "fork")
+	ThreadClass.Instance.Def("join", threadCall("Join", receiver))
+	ThreadClass.Instance.Def("value", threadCall("Value", func(r Type) Type { return r.(Thread).Value }))
+	ThreadClass.Instance.Def("alive?", threadCall("Alive", returns(BoolType)))
+
+	MutexType.Def("initialize", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return MutexType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{
+				Expr:    &ast.UnaryExpr{Op: token.AND, X: &ast.CompositeLit{Type: bst.Dot("sync", "Mutex")}},
+				Imports: []string{"sync"},
+			}
+		},
+	})
+	MutexType.Def("synchronize", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			if b == nil {
+				return nil, fmt.Errorf("Mutex#synchronize must be called with a block")
+			}
+			return b, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			// func() T { m.Lock(); defer m.Unlock(); ... }(), so that the
+			// mutex is released however the block finishes.
+			if blk.ReturnType == nil || blk.ReturnType == NilType {
+				stripBlockReturn(blk)
+			}
+			blk.Statements = append([]ast.Stmt{
+				&ast.ExprStmt{X: bst.Call(rcvr.Expr, "Lock")},
+				&ast.DeferStmt{Call: bst.Call(rcvr.Expr, "Unlock")},
+			}, blk.Statements...)
+			return Transform{
+				Expr: &ast.CallExpr{Fun: blk.FuncLit(it)},
+			}
+		},
+	})
+	MutexType.Def("lock", mutexCall("Lock"))
+	MutexType.Def("unlock", mutexCall("Unlock"))
+	MutexType.Def("try_lock", threadCall("TryLock", returns(BoolType)))
+
+	QueueClass.Instance.Def("initialize", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			if len(args) > 0 {
+				return nil, fmt.Errorf("Queue.new takes no arguments")
+			}
+			return NewQueue(AnyType), nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			// The compiler gives Queue.new the element type the queue is
+			// refined to; on its own it can only hold anything.
+			return Transform{
+				Expr:    &ast.CallExpr{Fun: it.Get(fmt.Sprintf("stdlib.NewQueue[%s]", AnyType.GoType()))},
+				Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
+			}
+		},
+	})
+	QueueClass.Instance.Def("push", queuePush)
+	QueueClass.Instance.Alias("push", "<<")
+	QueueClass.Instance.Alias("push", "enq")
+	// pop returns nil once the queue is closed and empty, so a consumer can
+	// loop with `while (v = q.pop)`.
+	QueueClass.Instance.Def("pop", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return NewOptional(r.(Queue).Element), nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			// pop(true) raises ThreadError rather than waiting on an empty queue.
+			if len(args) > 0 {
+				if lit, ok := args[0].Expr.(*ast.Ident); ok && lit.Name == "true" {
+					return Transform{Expr: bst.Call(rcvr.Expr, "TryPop")}
+				}
+			}
+			return Transform{Expr: bst.Call(rcvr.Expr, "Pop")}
+		},
+	})
+	QueueClass.Instance.Alias("pop", "shift")
+	QueueClass.Instance.Alias("pop", "deq")
+	QueueClass.Instance.Def("close", threadCall("Close", receiver))
+	QueueClass.Instance.Def("closed?", threadCall("Closed", returns(BoolType)))
+	QueueClass.Instance.Def("empty?", threadCall("Empty", returns(BoolType)))
+	QueueClass.Instance.Def("size", threadCall("Size", returns(IntType)))
+	QueueClass.Instance.Alias("size", "length")
+	QueueClass.Instance.Def("clear", threadCall("Clear", receiver))
+	QueueClass.Instance.Def("num_waiting", threadCall("NumWaiting", returns(IntType)))
+
+	ConditionVariableType.Def("initialize", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			return ConditionVariableType, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{
+				Expr:    bst.Call("stdlib", "NewConditionVariable"),
+				Imports: []string{"github.com/redneckbeard/thanos/stdlib"},
+			}
+		},
+	})
+	ConditionVariableType.Def("wait", MethodSpec{
+		ReturnType: func(r Type, b Type, args []Type) (Type, error) {
+			if len(args) != 1 || args[0] != MutexType {
+				return nil, fmt.Errorf("ConditionVariable#wait takes the Mutex to release while waiting")
+			}
+			return r, nil
+		},
+		TransformAST: func(rcvr TypeExpr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+			return Transform{
+				Expr: bst.Call(rcvr.Expr, "Wait", args[0].Expr),
+			}
+		},
+	})
+	ConditionVariableType.Def("signal", threadCall("Signal", receiver))
+	ConditionVariableType.Def("broadcast", threadCall("Broadcast", receiver))
+}
+
+// withoutParams returns fn with its parameters removed: a thread's block
+// params are bound by the function that starts it, if it has any.
+func withoutParams(fn *ast.FuncLit) *ast.FuncLit {
+	fn.Type.Params = &ast.FieldList{}
+	return fn
+}
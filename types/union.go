@@ -0,0 +1,269 @@
+package types
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redneckbeard/thanos/bst"
+)
+
+// Union is a sum type inferred when a local holds values of different types
+// on different paths, e.g. `x = 42` on one branch of a conditional and
+// `x = "forty-two"` on the other. It compiles to a sealed Go interface
+// (IntegerOrString) with one wrapper struct per member (IntegerOrStringInteger,
+// IntegerOrStringString), each holding the underlying value in a Val field.
+// Methods that every member supports compile to a type switch over the
+// wrappers.
+type Union struct {
+	Members []Type
+}
+
+// NewUnion builds a Union from the given types, flattening nested unions and
+// dropping duplicates. Members are sorted by name so that the same set of
+// types always produces the same Go type.
+func NewUnion(ts ...Type) *Union {
+	u := &Union{}
+	for _, t := range ts {
+		if inner, ok := t.(*Union); ok {
+			for _, m := range inner.Members {
+				u.add(m)
+			}
+		} else {
+			u.add(t)
+		}
+	}
+	sort.Slice(u.Members, func(i, j int) bool {
+		return UnionMemberName(u.Members[i]) < UnionMemberName(u.Members[j])
+	})
+	return u
+}
+
+func (u *Union) add(t Type) {
+	if !u.Has(t) {
+		u.Members = append(u.Members, t)
+	}
+}
+
+// Has reports whether t is one of the union's members.
+func (u *Union) Has(t Type) bool {
+	for _, m := range u.Members {
+		if m.Equals(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Name is the name of the sealed interface, e.g. "IntegerOrString".
+func (u *Union) Name() string {
+	names := make([]string, len(u.Members))
+	for i, m := range u.Members {
+		names[i] = UnionMemberName(m)
+	}
+	return strings.Join(names, "Or")
+}
+
+// WrapperName is the name of the struct wrapping values of member t.
+func (u *Union) WrapperName(t Type) string {
+	return u.Name() + UnionMemberName(t)
+}
+
+// MarkerMethod is the unexported method that seals the interface.
+func (u *Union) MarkerMethod() string {
+	return "is" + u.Name()
+}
+
+// UnionMemberName derives the identifier used for t in union and wrapper
+// names. Two types that share a member name cannot be members of the same
+// union.
+func UnionMemberName(t Type) string {
+	switch tt := t.(type) {
+	case Array:
+		return UnionMemberName(tt.Element) + "Array"
+	case Hash:
+		return UnionMemberName(tt.Key) + UnionMemberName(tt.Value) + "Hash"
+	}
+	if name := strings.ReplaceAll(t.ClassName(), "::", ""); name != "" {
+		return name
+	}
+	name := strings.TrimPrefix(t.GoType(), "*")
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func (u *Union) GoType() string    { return u.Name() }
+func (u *Union) ClassName() string { return u.Name() }
+func (u *Union) IsComposite() bool { return false }
+func (u *Union) IsMultiple() bool  { return false }
+
+func (u *Union) String() string {
+	names := make([]string, len(u.Members))
+	for i, m := range u.Members {
+		names[i] = m.String()
+	}
+	return fmt.Sprintf("Union(%s)", strings.Join(names, ", "))
+}
+
+func (u *Union) Equals(t2 Type) bool {
+	if u2, ok := t2.(*Union); ok {
+		return u.Name() == u2.Name()
+	}
+	return false
+}
+
+func (u *Union) HasMethod(m string) bool {
+	for _, member := range u.Members {
+		if !member.HasMethod(m) {
+			return false
+		}
+	}
+	return true
+}
+
+// MethodReturnType requires every member to agree on the return type, since
+// the result of the type switch has to land in a single Go variable.
+func (u *Union) MethodReturnType(m string, blockRet Type, args []Type) (Type, error) {
+	if m == "==" || m == "!=" {
+		return BoolType, nil
+	}
+	var (
+		ret   Type
+		first Type
+	)
+	for _, member := range u.Members {
+		if !member.HasMethod(m) {
+			return nil, fmt.Errorf("Method '%s' is not supported by %s, one of the types in %s", m, member, u)
+		}
+		t, err := member.MethodReturnType(m, blockRet, args)
+		if err != nil {
+			return nil, err
+		}
+		if ret != nil && !ret.Equals(t) {
+			return nil, fmt.Errorf("Method '%s' on %s returns %s for %s but %s for %s", m, u, ret, first, t, member)
+		}
+		ret, first = t, member
+	}
+	return ret, nil
+}
+
+func (u *Union) GetMethodSpec(m string) (MethodSpec, bool) {
+	return MethodSpec{}, false
+}
+
+func (u *Union) BlockArgTypes(m string, args []Type) []Type {
+	return u.Members[0].BlockArgTypes(m, args)
+}
+
+// TransformAST dispatches the call through a type switch, applying each
+// member's own transform to the unwrapped value:
+//
+//	var result T
+//	switch v := x.(type) {
+//	case IntegerOrStringInteger:
+//		result = <Integer#m on v.Val>
+//	case IntegerOrStringString:
+//		result = <String#m on v.Val>
+//	}
+func (u *Union) TransformAST(m string, rcvr ast.Expr, args []TypeExpr, blk *Block, it bst.IdentTracker) Transform {
+	if m == "==" || m == "!=" {
+		return u.equality(m, rcvr, args[0], it)
+	}
+	argTypes := make([]Type, len(args))
+	for i, arg := range args {
+		argTypes[i] = arg.Type
+	}
+	retType, _ := u.MethodReturnType(m, nil, argTypes)
+	v := it.New("v")
+	var result *ast.Ident
+	if retType != nil && retType != NilType {
+		result = it.New("result")
+	}
+	var (
+		clauses []ast.Stmt
+		imports []string
+	)
+	for _, member := range u.Members {
+		t := member.TransformAST(m, bst.Dot(v, "Val"), args, blk, it)
+		imports = append(imports, t.Imports...)
+		body := t.Stmts
+		if t.Expr != nil {
+			if result != nil {
+				body = append(body, bst.Assign(result, t.Expr))
+			} else if _, isCall := t.Expr.(*ast.CallExpr); isCall {
+				body = append(body, &ast.ExprStmt{X: t.Expr})
+			}
+		}
+		clauses = append(clauses, &ast.CaseClause{
+			List: []ast.Expr{it.Get(u.WrapperName(member))},
+			Body: body,
+		})
+	}
+	var stmts []ast.Stmt
+	if result != nil {
+		stmts = append(stmts, &ast.DeclStmt{
+			Decl: bst.Declare(token.VAR, result, it.Get(retType.GoType())),
+		})
+	}
+	stmts = append(stmts, &ast.TypeSwitchStmt{
+		Assign: bst.Define(v, &ast.TypeAssertExpr{X: rcvr}),
+		Body:   &ast.BlockStmt{List: clauses},
+	})
+	transform := Transform{Stmts: stmts, Imports: imports}
+	if result != nil {
+		transform.Expr = result
+	}
+	return transform
+}
+
+// equality compares a union with a value of one of its members using that
+// member's own operator, once the union is known to hold it. A union holding
+// any other member, or compared with a value of a type that isn't one of its
+// members, is unequal to it, as in Ruby:
+//
+//	result := false
+//	if v, ok := x.(IntegerOrStringInteger); ok {
+//		result = v.Val == 1
+//	}
+func (u *Union) equality(m string, rcvr ast.Expr, arg TypeExpr, it bst.IdentTracker) Transform {
+	if u.Equals(arg.Type) {
+		tok := token.EQL
+		if m == "!=" {
+			tok = token.NEQ
+		}
+		return Transform{Expr: bst.Binary(rcvr, tok, arg.Expr)}
+	}
+	unequal := it.Get(strconv.FormatBool(m == "!="))
+	if !u.Has(arg.Type) {
+		return Transform{Expr: unequal}
+	}
+	v, ok, result := it.New("v"), it.New("ok"), it.New("result")
+	t := arg.Type.TransformAST(m, bst.Dot(v, "Val"), []TypeExpr{arg}, nil, it)
+	return Transform{
+		Stmts: []ast.Stmt{
+			bst.Define(result, unequal),
+			&ast.IfStmt{
+				Init: bst.Define([]ast.Expr{v, ok}, &ast.TypeAssertExpr{X: rcvr, Type: it.Get(u.WrapperName(arg.Type))}),
+				Cond: ok,
+				Body: &ast.BlockStmt{List: append(t.Stmts, bst.Assign(result, t.Expr))},
+			},
+		},
+		Expr:    result,
+		Imports: t.Imports,
+	}
+}
+
+// Wrap converts a value of member type t into the union's wrapper struct.
+func (u *Union) Wrap(expr ast.Expr, t Type, it bst.IdentTracker) ast.Expr {
+	return &ast.CompositeLit{
+		Type: it.Get(u.WrapperName(t)),
+		Elts: []ast.Expr{&ast.KeyValueExpr{Key: it.Get("Val"), Value: expr}},
+	}
+}
+
+// Unwrap asserts that expr holds member t and extracts the underlying value.
+func (u *Union) Unwrap(expr ast.Expr, t Type, it bst.IdentTracker) ast.Expr {
+	return bst.Dot(&ast.TypeAssertExpr{X: expr, Type: it.Get(u.WrapperName(t))}, "Val")
+}